@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/cli/internal/pkg/input"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+func APICmd(f factory.ConnectionFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api [method] <path>",
+		Short: "Invokes an authenticated API request",
+		Long: "This command invokes an authenticated request against the given API path, outputting the 'data' property " +
+			"of the response (or the entire response if not present) via the standard output formats.\n\n" +
+			"Fields are sent as query parameters for GET requests, otherwise as a JSON request body. When --input is " +
+			"provided, fields are sent as query parameters",
+		Example: "ukfast api /ecloud/v2/instances\n" +
+			"ukfast api /ecloud/v2/instances --paginate -o jsonpath=\"{[*].id}\"\n" +
+			"ukfast api PATCH /ecloud/v2/instances/i-abcdef12 -F name=web01\n" +
+			"ukfast api -X POST /safedns/v1/zones --input zone.json",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing path")
+			}
+			if len(args) > 2 {
+				return errors.New("Too many arguments")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return api(f, fs, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringP("method", "X", "", "Specifies the HTTP method for the request, defaulting to GET, or POST when fields or input are provided")
+	cmd.Flags().StringArrayP("field", "F", []string{}, "Specifies a field in 'key=value' format, with values true, false, null and numbers converted to their JSON types. Can be repeated")
+	cmd.Flags().StringArray("raw-field", []string{}, "Specifies a string field in 'key=value' format. Can be repeated")
+	cmd.Flags().String("input", "", "Specifies a file containing the request body, or '-' to read from stdin")
+	cmd.Flags().Bool("paginate", false, "Specifies all pages of a paginated response should be retrieved, limited by --max-items")
+	output.AddPageConcurrencyFlag(cmd)
+
+	return cmd
+}
+
+func api(f factory.ConnectionFactory, fs afero.Fs, cmd *cobra.Command, args []string) error {
+	method, _ := cmd.Flags().GetString("method")
+	path := args[0]
+	if len(args) > 1 {
+		if method != "" && !strings.EqualFold(method, args[0]) {
+			return fmt.Errorf("Method [%s] conflicts with --method [%s]", args[0], method)
+		}
+		method = args[0]
+		path = args[1]
+	}
+
+	fields, err := getAPIFields(cmd)
+	if err != nil {
+		return err
+	}
+
+	var inputBody []byte
+	if cmd.Flags().Changed("input") {
+		inputBody, err = readAPIInput(fs, cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	if method == "" {
+		method = http.MethodGet
+		if len(fields) > 0 || inputBody != nil {
+			method = http.MethodPost
+		}
+	}
+	method = strings.ToUpper(method)
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("Invalid path [%s]: %s", path, err)
+	}
+	query := u.Query()
+
+	var body interface{}
+	switch {
+	case inputBody != nil:
+		body = bytes.NewReader(inputBody)
+		addAPIQueryFields(query, fields)
+	case method == http.MethodGet:
+		addAPIQueryFields(query, fields)
+	case len(fields) > 0:
+		body = fields
+	}
+
+	conn, err := f.NewConnection()
+	if err != nil {
+		return err
+	}
+
+	req := apiRequest{conn: conn, method: method, path: u.Path, query: query, body: body}
+	paginate, _ := cmd.Flags().GetBool("paginate")
+	if !paginate {
+		data, _, err := req.invoke(0)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			return nil
+		}
+
+		return output.CommandOutput(cmd, newAPIOutputProvider(data))
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithPagination(connection.APIRequestPagination{Page: 1})
+	first, err := req.getPage(params)
+	if err != nil {
+		return err
+	}
+
+	return output.CommandOutputAllPages(cmd, params, first, req.getPage, func(page connection.Paginated) output.OutputHandlerDataProvider {
+		return newAPIOutputProvider(page.(*apiPage).data)
+	})
+}
+
+// getAPIFields returns the request fields specified via the 'field' and 'raw-field' flags
+func getAPIFields(cmd *cobra.Command) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+
+	typedFields, _ := cmd.Flags().GetStringArray("field")
+	for _, field := range typedFields {
+		key, value, err := parseAPIField(field)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = parseAPIFieldValue(value)
+	}
+
+	rawFields, _ := cmd.Flags().GetStringArray("raw-field")
+	for _, field := range rawFields {
+		key, value, err := parseAPIField(field)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = value
+	}
+
+	return fields, nil
+}
+
+func parseAPIField(field string) (string, string, error) {
+	parts := strings.SplitN(field, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", fmt.Errorf("Invalid field [%s], expected format 'key=value'", field)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// parseAPIFieldValue converts value to a bool, nil or number where possible
+func parseAPIFieldValue(value string) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+
+	return value
+}
+
+func addAPIQueryFields(query url.Values, fields map[string]interface{}) {
+	for key, value := range fields {
+		if value == nil {
+			query.Add(key, "")
+			continue
+		}
+		query.Add(key, fmt.Sprintf("%v", value))
+	}
+}
+
+func readAPIInput(fs afero.Fs, cmd *cobra.Command) ([]byte, error) {
+	path, _ := cmd.Flags().GetString("input")
+	if path == "-" {
+		return ioutil.ReadAll(input.InputReader())
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read input file: %s", err)
+	}
+
+	return content, nil
+}
+
+type apiRequest struct {
+	conn   connection.Connection
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+}
+
+type apiResponseBody struct {
+	Data json.RawMessage                `json:"data"`
+	Meta connection.APIResponseMetadata `json:"meta"`
+}
+
+// invoke invokes the request, returning the 'data' property of the response body (or the entire
+// body if not present) along with pagination metadata. If page is greater than 0, the page query
+// parameter is set to page
+func (r apiRequest) invoke(page int) (json.RawMessage, connection.APIResponseMetadataPagination, error) {
+	query := url.Values{}
+	for key, values := range r.query {
+		query[key] = values
+	}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	resource := r.path
+	if len(query) > 0 {
+		resource += "?" + query.Encode()
+	}
+
+	resp, err := r.conn.Invoke(connection.APIRequest{
+		Method:   r.method,
+		Resource: resource,
+		Body:     r.body,
+	})
+	if err != nil {
+		return nil, connection.APIResponseMetadataPagination{}, err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, connection.APIResponseMetadataPagination{}, fmt.Errorf("failed to read response body: %s", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errorBody := &connection.APIResponseBody{}
+		json.Unmarshal(content, errorBody)
+		return nil, connection.APIResponseMetadataPagination{}, fmt.Errorf("unexpected status code (%d): %s", resp.StatusCode, errorBody.ErrorString())
+	}
+
+	content = bytes.TrimSpace(content)
+	if len(content) == 0 {
+		return nil, connection.APIResponseMetadataPagination{}, nil
+	}
+
+	body := apiResponseBody{}
+	if json.Unmarshal(content, &body) != nil || body.Data == nil {
+		// Response isn't a standard API response body, so output the body as-is
+		return content, connection.APIResponseMetadataPagination{}, nil
+	}
+
+	return body.Data, body.Meta.Pagination, nil
+}
+
+// apiPage is a single page of a paginated API response
+type apiPage struct {
+	*connection.PaginatedBase
+	data json.RawMessage
+}
+
+// getPage invokes the request for the page specified within params
+func (r apiRequest) getPage(params connection.APIRequestParameters) (connection.Paginated, error) {
+	data, pagination, err := r.invoke(params.Pagination.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiPage{
+		PaginatedBase: connection.NewPaginatedBase(params, pagination, r.getPage),
+		data:          data,
+	}, nil
+}
+
+// newAPIOutputProvider returns an output provider for raw JSON data. Field data is output with
+// properties in the order returned by the API, with non-scalar values output as JSON
+func newAPIOutputProvider(data json.RawMessage) output.OutputHandlerDataProvider {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if decoder.Decode(&v) != nil {
+		v = string(data)
+	}
+
+	return output.NewGenericOutputHandlerDataProvider(
+		output.WithData(v),
+		output.WithFieldDataFunc(func() ([]*output.OrderedFields, error) {
+			return getAPIFieldData(data)
+		}),
+	)
+}
+
+func getAPIFieldData(data json.RawMessage) ([]*output.OrderedFields, error) {
+	var items []json.RawMessage
+	if json.Unmarshal(data, &items) != nil {
+		items = []json.RawMessage{data}
+	}
+
+	var rows []*output.OrderedFields
+	for _, item := range items {
+		fields := output.NewOrderedFields()
+
+		decoder := json.NewDecoder(bytes.NewReader(item))
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		if token != json.Delim('{') {
+			fields.Set("value", output.NewFieldValue(apiFieldValue(item), true))
+			rows = append(rows, fields)
+			continue
+		}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			var value json.RawMessage
+			err = decoder.Decode(&value)
+			if err != nil {
+				return nil, err
+			}
+
+			fields.Set(fmt.Sprintf("%v", key), output.NewFieldValue(apiFieldValue(value), true))
+		}
+
+		rows = append(rows, fields)
+	}
+
+	return rows, nil
+}
+
+// apiFieldValue returns the field value for raw JSON value v, unquoting strings and returning an
+// empty string for null
+func apiFieldValue(v json.RawMessage) string {
+	var s string
+	if json.Unmarshal(v, &s) == nil {
+		return s
+	}
+	if string(v) == "null" {
+		return ""
+	}
+
+	buf := &bytes.Buffer{}
+	if json.Compact(buf, v) != nil {
+		return string(v)
+	}
+
+	return buf.String()
+}
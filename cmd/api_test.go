@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+type testConnectionFactory struct {
+	server *httptest.Server
+}
+
+func (f *testConnectionFactory) NewConnection() (connection.Connection, error) {
+	return &connection.APIConnection{
+		HTTPClient:  f.server.Client(),
+		Credentials: &connection.APIKeyCredentials{APIKey: "testkey"},
+		APIScheme:   "http",
+		APIURI:      strings.TrimPrefix(f.server.URL, "http://"),
+	}, nil
+}
+
+func newTestAPIServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *testConnectionFactory) {
+	server := httptest.NewServer(handler)
+	return server, &testConnectionFactory{server: server}
+}
+
+func TestAPICmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		cmd := APICmd(nil, nil)
+		err := cmd.Args(nil, []string{"/ecloud/v2/instances"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingPath_Error", func(t *testing.T) {
+		cmd := APICmd(nil, nil)
+		err := cmd.Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Missing path", err.Error())
+	})
+
+	t.Run("TooManyArgs_Error", func(t *testing.T) {
+		cmd := APICmd(nil, nil)
+		err := cmd.Args(nil, []string{"GET", "/ecloud/v2/instances", "extra"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Too many arguments", err.Error())
+	})
+}
+
+func TestAPI(t *testing.T) {
+	t.Run("Default_GetsAndOutputsData", func(t *testing.T) {
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/ecloud/v2/instances/i-abcdef12", r.URL.Path)
+			w.Write([]byte(`{"data":{"id":"i-abcdef12","name":"web01","tags":["a"]},"meta":{}}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+
+		out := test.CatchStdOut(t, func() {
+			err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances/i-abcdef12"})
+			assert.Nil(t, err)
+		})
+
+		assert.Contains(t, out, "i-abcdef12")
+		assert.Contains(t, out, "web01")
+		assert.Contains(t, out, `["a"]`)
+	})
+
+	t.Run("Fields_PostsJSONBody", func(t *testing.T) {
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			body, _ := ioutil.ReadAll(r.Body)
+			fields := make(map[string]interface{})
+			json.Unmarshal(body, &fields)
+			assert.Equal(t, map[string]interface{}{"name": "web01", "vcpu_cores": float64(2), "backup": true, "ref": "2"}, fields)
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"id":"i-abcdef12"},"meta":{}}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+		cmd.ParseFlags([]string{"-F", "name=web01", "-F", "vcpu_cores=2", "-F", "backup=true", "--raw-field", "ref=2"})
+
+		test.CatchStdOut(t, func() {
+			err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("FieldsWithGetMethod_AddsQueryParameters", func(t *testing.T) {
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "web01", r.URL.Query().Get("name"))
+			assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+			w.Write([]byte(`{"data":[],"meta":{}}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+		cmd.ParseFlags([]string{"-X", "get", "-F", "name=web01"})
+
+		test.CatchStdOut(t, func() {
+			err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances?per_page=10"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("InputFile_SendsFileAsBody", func(t *testing.T) {
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"name":"web02"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		})
+		defer server.Close()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "body.json", []byte(`{"name":"web02"}`), 0644)
+
+		cmd := APICmd(f, fs)
+		cmd.ParseFlags([]string{"--input", "body.json"})
+
+		out := test.CatchStdOut(t, func() {
+			err := api(f, fs, cmd, []string{"PATCH", "/ecloud/v2/instances/i-abcdef12"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "", out)
+	})
+
+	t.Run("Paginate_RetrievesAllPages", func(t *testing.T) {
+		var pages []string
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			pages = append(pages, page)
+			w.Write([]byte(`{"data":[{"id":"i-page` + page + `"}],"meta":{"pagination":{"total_pages":2}}}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+		cmd.ParseFlags([]string{"--paginate"})
+
+		out := test.CatchStdOut(t, func() {
+			err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, []string{"1", "2"}, pages)
+		assert.Contains(t, out, "i-page1")
+		assert.Contains(t, out, "i-page2")
+	})
+
+	t.Run("PaginateWithMaxItems_RetrievesRequiredPages", func(t *testing.T) {
+		var pages []string
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			pages = append(pages, page)
+			w.Write([]byte(`{"data":[{"id":"i-page` + page + `a"},{"id":"i-page` + page + `b"}],"meta":{"pagination":{"total_pages":3}}}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+		cmd.Flags().Int("max-items", 0, "")
+		cmd.ParseFlags([]string{"--paginate", "--max-items=3"})
+
+		out := test.CatchStdOut(t, func() {
+			err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, []string{"1", "2"}, pages)
+		assert.Contains(t, out, "i-page2a")
+		assert.NotContains(t, out, "i-page2b")
+	})
+
+	t.Run("PaginateWithQuery_FiltersEachPage", func(t *testing.T) {
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			w.Write([]byte(`{"data":[{"id":"i-page` + page + `a","name":"web"},{"id":"i-page` + page + `b","name":"db"}],"meta":{"pagination":{"total_pages":2}}}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+		cmd.Flags().String("query", "", "")
+		cmd.ParseFlags([]string{"--paginate", "--query", `name == "web"`})
+
+		out := test.CatchStdOut(t, func() {
+			err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances"})
+			assert.Nil(t, err)
+		})
+
+		assert.Contains(t, out, "i-page1a")
+		assert.Contains(t, out, "i-page2a")
+		assert.NotContains(t, out, "i-page1b")
+		assert.NotContains(t, out, "i-page2b")
+	})
+
+	t.Run("ErrorStatusCode_ReturnsError", func(t *testing.T) {
+		server, f := newTestAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"title":"Not found","detail":"Resource not found","status":404}]}`))
+		})
+		defer server.Close()
+
+		cmd := APICmd(f, afero.NewMemMapFs())
+
+		err := api(f, afero.NewMemMapFs(), cmd, []string{"/ecloud/v2/instances/i-abcdef12"})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unexpected status code (404)")
+		assert.Contains(t, err.Error(), "Resource not found")
+	})
+
+	t.Run("InvalidField_ReturnsError", func(t *testing.T) {
+		cmd := APICmd(nil, nil)
+		cmd.ParseFlags([]string{"-F", "invalid"})
+
+		err := api(nil, nil, cmd, []string{"/ecloud/v2/instances"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Invalid field [invalid], expected format 'key=value'", err.Error())
+	})
+
+	t.Run("ConflictingMethod_ReturnsError", func(t *testing.T) {
+		cmd := APICmd(nil, nil)
+		cmd.ParseFlags([]string{"-X", "POST"})
+
+		err := api(nil, nil, cmd, []string{"GET", "/ecloud/v2/instances"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Method [GET] conflicts with --method [POST]", err.Error())
+	})
+}
+
+func TestParseAPIFieldValue(t *testing.T) {
+	assert.Equal(t, true, parseAPIFieldValue("true"))
+	assert.Equal(t, false, parseAPIFieldValue("false"))
+	assert.Nil(t, parseAPIFieldValue("null"))
+	assert.Equal(t, int64(5), parseAPIFieldValue("5"))
+	assert.Equal(t, 1.5, parseAPIFieldValue("1.5"))
+	assert.Equal(t, "web01", parseAPIFieldValue("web01"))
+}
+
+func TestGetAPIFieldData(t *testing.T) {
+	t.Run("Object_RetainsPropertyOrder", func(t *testing.T) {
+		rows, err := getAPIFieldData([]byte(`{"zeta":"z","alpha":1,"nested":{"a": null},"empty":null}`))
+
+		assert.Nil(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, []string{"zeta", "alpha", "nested", "empty"}, rows[0].Keys())
+		assert.Equal(t, "z", rows[0].Get("zeta").Value)
+		assert.Equal(t, "1", rows[0].Get("alpha").Value)
+		assert.Equal(t, `{"a":null}`, rows[0].Get("nested").Value)
+		assert.Equal(t, "", rows[0].Get("empty").Value)
+	})
+
+	t.Run("ScalarArray_OutputsValueField", func(t *testing.T) {
+		rows, err := getAPIFieldData([]byte(`["a","b"]`))
+
+		assert.Nil(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "b", rows[1].Get("value").Value)
+	})
+}
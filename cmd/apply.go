@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	ddosxcmd "github.com/ukfast/cli/cmd/ddosx"
+	ecloudcmd "github.com/ukfast/cli/cmd/ecloud"
+	loadbalancercmd "github.com/ukfast/cli/cmd/loadbalancer"
+	safednscmd "github.com/ukfast/cli/cmd/safedns"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/cli/internal/pkg/input"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+const manifestLong = "Manifests are YAML files containing a list of resources, each with a kind, name, optional state " +
+	"('present' or 'absent') and spec of API properties. Resources may reference other resources within the manifest " +
+	"by name, e.g. a router spec may set 'vpc_id' to the name of an 'ecloud/vpc' resource.\n\n" +
+	"Supported kinds: ecloud/vpc, ecloud/router, ecloud/network, ecloud/instance, ecloud/firewall-policy, " +
+	"ecloud/firewall-rule, ecloud/firewall-rule-port, safedns/zone, safedns/record, ddosx/domain, ddosx/record, ddosx/waf, ddosx/waf-rule, " +
+	"ddosx/waf-advanced-rule, ddosx/acl-ip-rule, ddosx/acl-geoip-rule, ddosx/cdn, ddosx/cdn-rule, ddosx/hsts, " +
+	"ddosx/hsts-rule, loadbalancer/target-group, loadbalancer/target, loadbalancer/listener.\n\n" +
+	"Manifests can be generated from existing resources with the 'export' sub-commands, e.g. 'ukfast ecloud vpc export'"
+
+func ApplyCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Applies resources defined in YAML manifests",
+		Long: "This command creates, updates and removes resources so they match the given manifests. " +
+			"Resources are created in dependency order, waiting for each resource to become ready before continuing.\n\n" + manifestLong,
+		Example: "ukfast apply --file manifest.yml\nukfast apply --file network.yml --file dns.yml --dry-run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return apply(f, fs, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringArray("file", []string{}, "Specifies the path to a manifest file, can be repeated")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().Bool("dry-run", false, "Specifies the plan should be output without applying any changes")
+
+	return cmd
+}
+
+func apply(f factory.ClientFactory, fs afero.Fs, cmd *cobra.Command, args []string) error {
+	plan, err := getManifestPlan(f, fs, cmd)
+	if err != nil {
+		return err
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		output.Error(plan.Summary())
+		return outputManifestPlan(cmd, plan)
+	}
+
+	err = plan.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = confirmManifestPlanDeletes(cmd, plan)
+	if err != nil {
+		return err
+	}
+
+	err = plan.Apply(func(step *manifest.Step) {
+		output.Errorf("%s %s", strings.Title(string(step.Action)), step.Resource)
+	})
+	if err != nil {
+		return err
+	}
+
+	output.Errorf("Apply complete: %s", plan.Summary())
+	return nil
+}
+
+// confirmManifestPlanDeletes requests confirmation via input.CommandConfirm if plan removes any
+// resources
+func confirmManifestPlanDeletes(cmd *cobra.Command, plan *manifest.Plan) error {
+	var resources []string
+	for _, step := range plan.Steps {
+		if step.Action != manifest.ActionDelete {
+			continue
+		}
+
+		resources = append(resources, fmt.Sprintf("%s (%v)", step.Resource, step.ID))
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	return input.CommandConfirm(cmd, "The following resources will be removed", func() []string {
+		return resources
+	})
+}
+
+func DiffCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Short:   "Shows differences between YAML manifests and current resources",
+		Long:    "This command shows the changes which would be made by applying the given manifests.\n\n" + manifestLong,
+		Example: "ukfast diff --file manifest.yml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diff(f, fs, cmd, args)
+		},
+	}
+
+	cmd.Flags().StringArray("file", []string{}, "Specifies the path to a manifest file, can be repeated")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().Bool("exit-code", false, "Specifies the command should exit with code 1 if there are differences")
+
+	return cmd
+}
+
+func diff(f factory.ClientFactory, fs afero.Fs, cmd *cobra.Command, args []string) error {
+	plan, err := getManifestPlan(f, fs, cmd)
+	if err != nil {
+		return err
+	}
+
+	if exitCode, _ := cmd.Flags().GetBool("exit-code"); exitCode && plan.HasChanges() {
+		output.OutputWithErrorLevel(plan.Summary())
+	} else {
+		output.Error(plan.Summary())
+	}
+
+	return outputManifestPlan(cmd, plan)
+}
+
+func getManifestPlan(f factory.ClientFactory, fs afero.Fs, cmd *cobra.Command) (*manifest.Plan, error) {
+	files, _ := cmd.Flags().GetStringArray("file")
+	m, err := manifest.ReadFiles(fs, files)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := f.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	registry := manifest.NewRegistry()
+	registry.RegisterAll(ecloudcmd.ManifestHandlers(c.ECloudService()))
+	registry.RegisterAll(safednscmd.ManifestHandlers(c.SafeDNSService()))
+	registry.RegisterAll(ddosxcmd.ManifestHandlers(c.DDoSXService()))
+	registry.RegisterAll(loadbalancercmd.ManifestHandlers(c.LoadBalancerService()))
+
+	return manifest.NewPlan(registry, m)
+}
+
+func outputManifestPlan(cmd *cobra.Command, plan *manifest.Plan) error {
+	return output.CommandOutput(cmd, output.NewGenericOutputHandlerDataProvider(
+		output.WithData(plan.Steps),
+		output.WithFieldDataFunc(func() ([]*output.OrderedFields, error) {
+			var data []*output.OrderedFields
+			for _, step := range plan.Steps {
+				var changes []string
+				for _, change := range step.Changes {
+					changes = append(changes, change.String())
+				}
+
+				id := ""
+				if step.ID != nil {
+					id = fmt.Sprint(step.ID)
+				}
+
+				fields := output.NewOrderedFields()
+				fields.Set("action", output.NewFieldValue(string(step.Action), true))
+				fields.Set("kind", output.NewFieldValue(step.Resource.Kind, true))
+				fields.Set("name", output.NewFieldValue(step.Resource.Name, true))
+				fields.Set("id", output.NewFieldValue(id, true))
+				fields.Set("changes", output.NewFieldValue(strings.Join(changes, "\n"), true))
+				data = append(data, fields)
+			}
+
+			return data, nil
+		}),
+	))
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+)
+
+func Test_confirmManifestPlanDeletes(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("yes", false, "")
+		cmd.Flags().Bool("no-input", false, "")
+		cmd.ParseFlags(args)
+		return cmd
+	}
+
+	deletePlan := &manifest.Plan{Steps: []*manifest.Step{
+		{Action: manifest.ActionCreate, Resource: &manifest.Resource{Kind: "ecloud/vpc", Name: "vpc1"}},
+		{Action: manifest.ActionDelete, Resource: &manifest.Resource{Kind: "ecloud/vpc", Name: "vpc2"}, ID: "vpc-abcdef12"},
+	}}
+
+	t.Run("NoDeletes_NoError", func(t *testing.T) {
+		plan := &manifest.Plan{Steps: deletePlan.Steps[:1]}
+
+		err := confirmManifestPlanDeletes(newCmd("--no-input"), plan)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("DeletesWithNoInput_ReturnsError", func(t *testing.T) {
+		err := confirmManifestPlanDeletes(newCmd("--no-input"), deletePlan)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Confirmation required with --no-input, use --yes to confirm", err.Error())
+	})
+
+	t.Run("DeletesWithYes_NoError", func(t *testing.T) {
+		err := confirmManifestPlanDeletes(newCmd("--yes", "--no-input"), deletePlan)
+
+		assert.Nil(t, err)
+	})
+}
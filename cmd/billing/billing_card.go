@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/billing"
@@ -271,13 +272,19 @@ func billingCardDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			billingCardDelete(c.BillingService(), cmd, args)
-			return nil
+			return billingCardDelete(c.BillingService(), cmd, args)
 		},
 	}
 }
 
-func billingCardDelete(service billing.BillingService, cmd *cobra.Command, args []string) {
+func billingCardDelete(service billing.BillingService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, "The following cards will be removed", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		cardID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -291,4 +298,6 @@ func billingCardDelete(service billing.BillingService, cmd *cobra.Command, args
 			continue
 		}
 	}
+
+	return nil
 }
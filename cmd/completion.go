@@ -14,6 +14,7 @@ func CompletionRootCmd() *cobra.Command {
 
 	// Child commands
 	cmd.AddCommand(completionBashCmd())
+	cmd.AddCommand(completionFishCmd())
 	cmd.AddCommand(completionPowerShellCmd())
 	cmd.AddCommand(completionZshCmd())
 
@@ -42,6 +43,24 @@ echo 'source <(ukfast completion bash)' >> /etc/bash_completion.d/ukfast
 	}
 }
 
+func completionFishCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "fish",
+		Short: "Generates fish completion scripts",
+		Long: `To load completion into current shell:
+
+ukfast completion fish | source
+
+To configure your fish shell to load completions for all sessions, output completion to the completions directory:
+
+ukfast completion fish > ~/.config/fish/completions/ukfast.fish
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rootCmd.GenFishCompletion(os.Stdout, true)
+		},
+	}
+}
+
 func completionPowerShellCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "powershell",
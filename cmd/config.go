@@ -1,11 +1,26 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/credential"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"gopkg.in/yaml.v2"
 )
 
+type configValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
 func ConfigRootCmd(fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
@@ -13,72 +28,374 @@ func ConfigRootCmd(fs afero.Fs) *cobra.Command {
 	}
 
 	// Child commands
+	cmd.AddCommand(configGetCmd(fs))
+	cmd.AddCommand(configListCmd())
 	cmd.AddCommand(configSetCommand(fs))
+	cmd.AddCommand(configUnsetCmd(fs))
+	cmd.AddCommand(configViewCmd(fs))
+	cmd.AddCommand(configValidateCmd(fs))
+	cmd.AddCommand(configUseContextCmd(fs))
+	cmd.AddCommand(configLoginCmd(fs))
+	cmd.AddCommand(configLogoutCmd(fs))
 
 	// Child root commands
+	cmd.AddCommand(configContextRootCmd(fs))
 
 	return cmd
 }
 
+func configGetCmd(fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "get <key>",
+		Short:   "Gets a configuration property",
+		Long:    "This command retrieves the effective value of a configuration property, with secrets masked unless --show-secrets is specified. The api_key property is resolved from api_key, api_key_command or the credentials file",
+		Example: "ukfast config get api_uri\nukfast config get api_key --show-secrets",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing key")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configGet(fs, cmd, args)
+		},
+	}
+
+	cmd.Flags().Bool("show-secrets", false, "Specifies secret values (e.g. api_key) should be output unmasked")
+
+	return cmd
+}
+
+func configGet(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if key == config.CurrentContextKey {
+		fmt.Println(config.GetCurrentContextName())
+		return nil
+	}
+
+	var value string
+	if strings.ToLower(key) == "api_key" {
+		// The API key may be sourced from api_key_command or the credentials file rather than config
+		apiKey, err := credential.GetAPIKey(fs, true)
+		if err != nil {
+			return err
+		}
+		if apiKey == "" {
+			return fmt.Errorf("Config key [%s] not set", key)
+		}
+
+		value = apiKey
+	} else {
+		if !config.IsSet(key) {
+			return fmt.Errorf("Config key [%s] not set", key)
+		}
+
+		value = formatConfigValue(config.Get(key))
+	}
+
+	showSecrets, _ := cmd.Flags().GetBool("show-secrets")
+	if config.IsSecretKey(key) && !showSecrets {
+		value = config.MaskValue(value)
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+func configListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "Lists configuration properties",
+		Long:    "This command lists the effective value of configuration properties, and the source of each value",
+		Example: "ukfast config list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configList(cmd, args)
+		},
+	}
+}
+
+func configList(cmd *cobra.Command, args []string) error {
+	var values []configValue
+
+	contextSource := config.GetCurrentContextSource()
+	if contextSource != "" {
+		values = append(values, configValue{
+			Key:    config.CurrentContextKey,
+			Value:  config.GetCurrentContextName(),
+			Source: contextSource,
+		})
+	}
+
+	for _, definition := range config.Definitions() {
+		source := config.GetSource(definition.Key)
+		if source == "" {
+			continue
+		}
+
+		value := formatConfigValue(config.Get(definition.Key))
+		if config.IsSecretKey(definition.Key) {
+			value = config.MaskValue(value)
+		}
+
+		values = append(values, configValue{
+			Key:    definition.Key,
+			Value:  value,
+			Source: source,
+		})
+	}
+
+	return output.CommandOutput(cmd, output.NewSerializedOutputHandlerDataProvider(values).WithDefaultFields([]string{"key", "value", "source"}))
+}
+
+// formatConfigValue returns a string representation of config value v, with maps formatted as
+// sorted, comma-separated 'key=value' pairs
+func formatConfigValue(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		var pairs []string
+		for key, value := range m {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, value))
+		}
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, ",")
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
 func configSetCommand(fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "set",
 		Short:   "Sets configuration properties",
-		Long:    "This command sets configuration properties",
-		Example: "ukfast config set --api_key \"secretkey\"",
+		Long:    "This command sets configuration properties. Properties are set within the active context, if any. The API key can't be set via this command, use 'config login' to store the API key encrypted instead",
+		Example: "ukfast config set --api_uri \"api.example.com\"\nukfast config set --context prod --api_timeout_seconds 30",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return configSet(fs, cmd, args)
 		},
 	}
 
 	// Setup flags
-	cmd.Flags().String("api_key", "", "Specifies API key")
-	cmd.Flags().Int("api_timeout_seconds", 0, "Specifies API timeout in seconds")
-	cmd.Flags().String("api_uri", "", "Specifies API URI")
-	cmd.Flags().Bool("api_insecure", false, "Specifies API TLS validation should be disabled")
-	cmd.Flags().Bool("api_debug", false, "Specifies API debug logging should be enabled")
-	cmd.Flags().Int("api_pagination_perpage", 0, "Specifies how many items should be retrieved per-page for paginated API requests")
-	cmd.Flags().Int("command_wait_timeout_seconds", 0, "Specifies how long commands supporting 'wait' parameter should wait")
-	cmd.Flags().Int("command_wait_sleep_seconds", 0, "Specifies how often commands supporting 'wait' parameter should poll")
+	addConfigSetFlags(cmd)
 
 	return cmd
 }
 
-func configSet(fs afero.Fs, cmd *cobra.Command, args []string) error {
+func addConfigSetFlags(cmd *cobra.Command) {
+	for _, definition := range config.Definitions() {
+		switch definition.Type {
+		case config.KeyTypeString:
+			cmd.Flags().String(definition.Key, "", definition.Description)
+		case config.KeyTypeInt:
+			cmd.Flags().Int(definition.Key, 0, definition.Description)
+		case config.KeyTypeBool:
+			cmd.Flags().Bool(definition.Key, false, definition.Description)
+		case config.KeyTypeStringMap:
+			cmd.Flags().StringToString(definition.Key, nil, definition.Description)
+		}
+	}
+}
+
+// validateConfigSetFlags returns an error if the api_key flag is specified, as the API key would be
+// written to the config file in plaintext
+func validateConfigSetFlags(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("api_key") {
+		return errors.New("api_key can't be set in the config file, use 'config login' to store the API key encrypted, or set api_key_command")
+	}
+
+	return nil
+}
+
+// applyConfigSetFlags calls set for each config flag which has been changed, returning
+// true if any were changed
+func applyConfigSetFlags(cmd *cobra.Command, set func(name string, value interface{})) bool {
 	updated := false
 
-	set := func(name string, value interface{}) {
-		if cmd.Flags().Changed(name) {
-			viper.Set(name, value)
-			updated = true
+	for _, definition := range config.Definitions() {
+		if !cmd.Flags().Changed(definition.Key) {
+			continue
+		}
+
+		var value interface{}
+		switch definition.Type {
+		case config.KeyTypeString:
+			value, _ = cmd.Flags().GetString(definition.Key)
+		case config.KeyTypeInt:
+			value, _ = cmd.Flags().GetInt(definition.Key)
+		case config.KeyTypeBool:
+			value, _ = cmd.Flags().GetBool(definition.Key)
+		case config.KeyTypeStringMap:
+			value, _ = cmd.Flags().GetStringToString(definition.Key)
 		}
+
+		set(definition.Key, value)
+		updated = true
 	}
 
-	apiKey, _ := cmd.Flags().GetString("api_key")
-	set("api_key", apiKey)
-	apiTimeoutSeconds, _ := cmd.Flags().GetInt("api_timeout_seconds")
-	set("api_timeout_seconds", apiTimeoutSeconds)
-	apiURI, _ := cmd.Flags().GetString("api_uri")
-	set("api_uri", apiURI)
-	apiInsecure, _ := cmd.Flags().GetBool("api_insecure")
-	set("api_insecure", apiInsecure)
-	apiDebug, _ := cmd.Flags().GetBool("api_debug")
-	set("api_debug", apiDebug)
-	apiPaginationPerPage, _ := cmd.Flags().GetInt("api_pagination_perpage")
-	set("api_pagination_perpage", apiPaginationPerPage)
-	commandWaitTimeoutSeconds, _ := cmd.Flags().GetInt("command_wait_timeout_seconds")
-	set("command_wait_timeout_seconds", commandWaitTimeoutSeconds)
-	commandWaitSleepSeconds, _ := cmd.Flags().GetInt("command_wait_sleep_seconds")
-	set("command_wait_sleep_seconds", commandWaitSleepSeconds)
+	return updated
+}
 
+func configSet(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	err := validateConfigSetFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	configFile := getConfigFilePath()
+	v, err := config.ReadFile(fs, configFile)
+	if err != nil {
+		return err
+	}
+
+	context := config.GetCurrentContextName()
+	if context != "" {
+		err := config.ValidateContextName(context)
+		if err != nil {
+			return err
+		}
+	}
+
+	updated := applyConfigSetFlags(cmd, func(name string, value interface{}) {
+		if context != "" {
+			name = config.ContextKey(context, name)
+		}
+		v.Set(name, value)
+	})
+
+	if updated {
+		return v.WriteConfigAs(configFile)
+	}
+
+	return nil
+}
+
+// getConfigFilePath returns the path of the config file in use, or the default config file
+// path if no config file was found
+func getConfigFilePath() string {
 	configFile := viper.GetViper().ConfigFileUsed()
 	if len(configFile) < 1 {
 		configFile = defaultConfigFile
 	}
 
+	return configFile
+}
+
+func configUnsetCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:     "unset <key>...",
+		Short:   "Unsets configuration properties",
+		Long:    "This command removes one or more configuration properties from the config file. Properties are removed from the active context, if any",
+		Example: "ukfast config unset api_timeout_seconds",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing key")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configUnset(fs, cmd, args)
+		},
+	}
+}
+
+func configUnset(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	configFile := getConfigFilePath()
+	v, err := config.ReadFile(fs, configFile)
+	if err != nil {
+		return err
+	}
+
+	context := config.GetCurrentContextName()
+
+	updated := false
+	for _, arg := range args {
+		key := arg
+		if context != "" {
+			key = config.ContextKey(context, arg)
+		}
+
+		if !v.IsSet(key) {
+			output.OutputWithErrorLevelf("Error unsetting key [%s]: Key not set in config file", key)
+			continue
+		}
+		if context != "" && len(v.GetStringMap(config.ContextsKey+"."+context)) == 1 {
+			output.OutputWithErrorLevelf("Error unsetting key [%s]: Cannot unset last property of context [%s], use 'config context delete' instead", key, context)
+			continue
+		}
+
+		v = config.Unset(fs, v, key)
+		updated = true
+	}
+
 	if updated {
-		viper.SetFs(fs)
-		return viper.WriteConfigAs(configFile)
+		return v.WriteConfigAs(configFile)
+	}
+
+	return nil
+}
+
+func configViewCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:     "view",
+		Short:   "Shows the config file",
+		Long:    "This command shows the contents of the config file, with secrets masked",
+		Example: "ukfast config view",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configView(fs, cmd, args)
+		},
+	}
+}
+
+func configView(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	v, err := config.ReadFile(fs, getConfigFilePath())
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(config.MaskSecrets(v.AllSettings()))
+	if err != nil {
+		return fmt.Errorf("Failed to marshal config: %s", err)
+	}
+
+	fmt.Print(string(out))
+	return nil
+}
+
+func configValidateCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:     "validate",
+		Short:   "Validates the config file",
+		Long:    "This command validates the config file, reporting unknown keys, invalid values and missing contexts",
+		Example: "ukfast config validate",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configValidate(fs, cmd, args)
+		},
+	}
+}
+
+func configValidate(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	configFile := getConfigFilePath()
+	exists, err := afero.Exists(fs, configFile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Config file '%s' not found", configFile)
+	}
+
+	v, err := config.ReadFile(fs, configFile)
+	if err != nil {
+		return err
+	}
+
+	errs := config.Validate(v.AllSettings())
+	for _, err := range errs {
+		output.OutputWithErrorLevel(err.Error())
+	}
+
+	if len(errs) == 0 {
+		fmt.Printf("Config file '%s' is valid\n", configFile)
 	}
 
 	return nil
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+type configContext struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+func configContextRootCmd(fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "sub-commands relating to config contexts",
+	}
+
+	// Child commands
+	cmd.AddCommand(configContextListCmd())
+	cmd.AddCommand(configContextShowCmd())
+	cmd.AddCommand(configContextCreateCmd(fs))
+	cmd.AddCommand(configContextUseCmd(fs))
+	cmd.AddCommand(configContextDeleteCmd(fs))
+
+	return cmd
+}
+
+func configContextListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "Lists contexts",
+		Long:    "This command lists config contexts",
+		Example: "ukfast config context list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configContextList(cmd, args)
+		},
+	}
+}
+
+func configContextList(cmd *cobra.Command, args []string) error {
+	currentContext := config.GetCurrentContextName()
+
+	var contexts []configContext
+	for _, name := range config.GetContextNames() {
+		contexts = append(contexts, configContext{
+			Name:    name,
+			Current: strings.EqualFold(name, currentContext),
+		})
+	}
+
+	return output.CommandOutput(cmd, output.NewSerializedOutputHandlerDataProvider(contexts).WithDefaultFields([]string{"name", "current"}))
+}
+
+func configContextShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "show [<context: name>]",
+		Short:   "Shows a context",
+		Long:    "This command shows a config context, defaulting to the active context",
+		Example: "ukfast config context show\nukfast config context show prod",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configContextShow(cmd, args)
+		},
+	}
+}
+
+func configContextShow(cmd *cobra.Command, args []string) error {
+	name := config.GetCurrentContextName()
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if name == "" {
+		return errors.New("No active context")
+	}
+	if !config.ContextExists(name) {
+		return fmt.Errorf("Context [%s] not found", name)
+	}
+
+	settings := viper.GetStringMap(config.ContextsKey + "." + name)
+	var keys []string
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data := map[string]interface{}{}
+	fields := output.NewOrderedFields()
+	fields.Set("name", output.NewFieldValue(name, true))
+	for _, key := range keys {
+		value := settings[key]
+		if config.IsSecretKey(key) {
+			value = config.MaskValue(fmt.Sprintf("%v", value))
+		}
+
+		data[key] = value
+		fields.Set(key, output.NewFieldValue(fmt.Sprintf("%v", value), true))
+	}
+
+	return output.CommandOutput(cmd, output.NewGenericOutputHandlerDataProvider(
+		output.WithData(data),
+		output.WithFieldDataFunc(func() ([]*output.OrderedFields, error) {
+			return []*output.OrderedFields{fields}, nil
+		}),
+	))
+}
+
+func configContextCreateCmd(fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "create <context: name>",
+		Short:   "Creates a context",
+		Long:    "This command creates a config context with given configuration properties",
+		Example: "ukfast config context create prod --api_uri \"api.example.com\"",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing context")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configContextCreate(fs, cmd, args)
+		},
+	}
+
+	// Setup flags
+	addConfigSetFlags(cmd)
+	cmd.Flags().Bool("use", false, "Specifies the new context should be set as the current context")
+
+	return cmd
+}
+
+func configContextCreate(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	name := args[0]
+	err := config.ValidateContextName(name)
+	if err != nil {
+		return err
+	}
+	if config.ContextExists(name) {
+		return fmt.Errorf("Context [%s] already exists", name)
+	}
+	err = validateConfigSetFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	configFile := getConfigFilePath()
+	v, err := config.ReadFile(fs, configFile)
+	if err != nil {
+		return err
+	}
+
+	// Viper omits empty maps when writing config, so contexts must define at least one property
+	updated := applyConfigSetFlags(cmd, func(key string, value interface{}) {
+		v.Set(config.ContextKey(name, key), value)
+	})
+	if !updated {
+		return errors.New("At least one configuration property must be specified")
+	}
+
+	use, _ := cmd.Flags().GetBool("use")
+	if use {
+		v.Set(config.CurrentContextKey, name)
+	}
+
+	return v.WriteConfigAs(configFile)
+}
+
+func configContextUseCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:     "use <context: name>",
+		Short:   "Sets the current context",
+		Long:    "This command sets the current config context",
+		Example: "ukfast config context use prod",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing context")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configContextUse(fs, cmd, args)
+		},
+	}
+}
+
+func configUseContextCmd(fs afero.Fs) *cobra.Command {
+	cmd := configContextUseCmd(fs)
+	cmd.Use = "use-context <context: name>"
+	cmd.Example = "ukfast config use-context prod"
+
+	return cmd
+}
+
+func configContextUse(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !config.ContextExists(name) {
+		return fmt.Errorf("Context [%s] not found", name)
+	}
+
+	configFile := getConfigFilePath()
+	v, err := config.ReadFile(fs, configFile)
+	if err != nil {
+		return err
+	}
+
+	v.Set(config.CurrentContextKey, name)
+
+	return v.WriteConfigAs(configFile)
+}
+
+func configContextDeleteCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <context: name>...",
+		Short:   "Removes a context",
+		Long:    "This command removes one or more config contexts",
+		Example: "ukfast config context delete prod",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing context")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configContextDelete(fs, cmd, args)
+		},
+	}
+}
+
+func configContextDelete(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	configFile := getConfigFilePath()
+	v, err := config.ReadFile(fs, configFile)
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		if !config.ContextExists(arg) {
+			output.OutputWithErrorLevelf("Error removing context [%s]: Context not found", arg)
+			continue
+		}
+
+		v = config.Unset(fs, v, config.ContextsKey+"."+arg)
+		if strings.EqualFold(v.GetString(config.CurrentContextKey), arg) {
+			v = config.Unset(fs, v, config.CurrentContextKey)
+		}
+	}
+
+	return v.WriteConfigAs(configFile)
+}
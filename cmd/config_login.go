@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/credential"
+	"github.com/ukfast/cli/internal/pkg/input"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+func configLoginCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Stores an API key in the credentials file",
+		Long: "This command reads an API key from stdin and stores it encrypted in the credentials file, for the active context if any. " +
+			"The credentials file is protected by a passphrase, or by the key file specified by credential_key_file",
+		Example: "ukfast config login\nukfast config login --context prod\necho \"secretkey\" | UKF_CREDENTIAL_PASSPHRASE=\"passphrase\" ukfast config login",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configLogin(fs, cmd, args)
+		},
+	}
+}
+
+func configLogin(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	context := config.GetCurrentContextName()
+	if context != "" && !config.ContextExists(context) {
+		return fmt.Errorf("Context [%s] not found, create it with 'config context create'", context)
+	}
+
+	store, err := credential.NewFileStoreFromConfig(fs)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := input.ReadSecret("API key")
+	if err != nil {
+		return err
+	}
+	if apiKey == "" {
+		return errors.New("API key cannot be empty")
+	}
+
+	err = store.Set(context, apiKey)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("API key stored in credentials file '%s'\n", store.Path())
+
+	// The api_key config takes precedence over the credentials file, so warn if it's still set
+	v, err := config.ReadFile(fs, getConfigFilePath())
+	if err != nil {
+		return err
+	}
+	key := "api_key"
+	if context != "" {
+		key = config.ContextKey(context, key)
+	}
+	if v.IsSet(key) {
+		output.Error("Warning: api_key is set in the config file and takes precedence, remove it with 'config unset api_key'")
+	}
+
+	return nil
+}
+
+func configLogoutCmd(fs afero.Fs) *cobra.Command {
+	return &cobra.Command{
+		Use:     "logout",
+		Short:   "Removes an API key from the credentials file",
+		Long:    "This command removes the API key stored by 'config login' from the credentials file, for the active context if any",
+		Example: "ukfast config logout\nukfast config logout --context prod",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configLogout(fs, cmd, args)
+		},
+	}
+}
+
+func configLogout(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	store, err := credential.NewFileStoreFromConfig(fs)
+	if err != nil {
+		return err
+	}
+
+	context := config.GetCurrentContextName()
+	err = store.Delete(context)
+	if err == credential.ErrNotFound {
+		if context != "" {
+			return fmt.Errorf("No API key stored for context [%s]", context)
+		}
+
+		return errors.New("No API key stored")
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("API key removed from credentials file '%s'\n", store.Path())
+	return nil
+}
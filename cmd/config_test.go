@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test"
+)
+
+func TestConfigGet(t *testing.T) {
+	t.Run("SecretKey_OutputsMaskedValue", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("api_key", "iqmxgom0kairfnxz")
+
+		out := test.CatchStdOut(t, func() {
+			err := configGet(afero.NewMemMapFs(), configGetCmd(afero.NewMemMapFs()), []string{"api_key"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "************fnxz\n", out)
+	})
+
+	t.Run("SecretKeyWithShowSecrets_OutputsValue", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("api_key", "iqmxgom0kairfnxz")
+
+		cmd := configGetCmd(afero.NewMemMapFs())
+		cmd.ParseFlags([]string{"--show-secrets"})
+
+		out := test.CatchStdOut(t, func() {
+			err := configGet(afero.NewMemMapFs(), cmd, []string{"api_key"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "iqmxgom0kairfnxz\n", out)
+	})
+
+	t.Run("APIKeyCommand_OutputsMaskedCommandOutput", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("api_key_command", "echo iqmxgom0kairfnxz")
+
+		out := test.CatchStdOut(t, func() {
+			err := configGet(afero.NewMemMapFs(), configGetCmd(afero.NewMemMapFs()), []string{"api_key"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "************fnxz\n", out)
+	})
+
+	t.Run("NonSecretKey_OutputsValue", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("api_uri", "api.example.com")
+
+		out := test.CatchStdOut(t, func() {
+			err := configGet(afero.NewMemMapFs(), configGetCmd(afero.NewMemMapFs()), []string{"api_uri"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "api.example.com\n", out)
+	})
+}
+
+func TestConfigSet(t *testing.T) {
+	t.Run("APIKey_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		cmd := configSetCommand(fs)
+		cmd.ParseFlags([]string{"--api_key", "iqmxgom0kairfnxz"})
+
+		err := configSet(fs, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "config login")
+		files, _ := afero.ReadDir(fs, "/")
+		assert.Len(t, files, 0)
+	})
+}
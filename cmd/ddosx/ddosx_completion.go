@@ -0,0 +1,28 @@
+package ddosx
+
+import (
+	"github.com/ukfast/cli/internal/pkg/completion"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+func ddosxDomainCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return completion.ResourceCompletionFunc("ddosx_domains", func() ([]completion.Resource, error) {
+		c, err := f.NewClient()
+		if err != nil {
+			return nil, err
+		}
+
+		domains, err := c.DDoSXService().GetDomains(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, domain := range domains {
+			resources = append(resources, completion.Resource{ID: domain.Name, Name: domain.Status.String()})
+		}
+
+		return resources, nil
+	})
+}
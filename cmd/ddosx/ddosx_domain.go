@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -24,6 +25,7 @@ func ddosxDomainRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd.AddCommand(ddosxDomainCreateCmd(f))
 	cmd.AddCommand(ddosxDomainDeleteCmd(f))
 	cmd.AddCommand(ddosxDomainDeployCmd(f))
+	cmd.AddCommand(ddosxDomainExportCmd(f))
 
 	// Child root commands
 	cmd.AddCommand(ddosxDomainRecordRootCmd(f))
@@ -82,6 +84,7 @@ func ddosxDomainShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: ddosxDomainCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -164,19 +167,26 @@ func ddosxDomainDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: ddosxDomainCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
 				return err
 			}
 
-			ddosxDomainDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, "The following domains will be removed", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteDomain(arg)
 		if err != nil {
@@ -184,6 +194,8 @@ func ddosxDomainDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []st
 			continue
 		}
 	}
+
+	return nil
 }
 
 func ddosxDomainDeployCmd(f factory.ClientFactory) *cobra.Command {
@@ -199,6 +211,7 @@ func ddosxDomainDeployCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: ddosxDomainCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -260,3 +273,43 @@ func DomainStatusWaitFunc(service ddosx.DDoSXService, domainName string, status
 		return false, nil
 	}
 }
+
+func ddosxDomainExportCmd(f factory.ClientFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <domain: name>",
+		Short: "Exports a domain as a manifest",
+		Long: "This command exports a domain along with its records, WAF, ACL, CDN and HSTS configuration " +
+			"as a YAML manifest, which can be applied with 'ukfast apply'",
+		Example: "ukfast ddosx domain export example.com > domain.yml",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing domain")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return ddosxDomainExport(c.DDoSXService(), cmd, args)
+		},
+	}
+}
+
+func ddosxDomainExport(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	m, err := exportDomainManifest(service, args[0])
+	if err != nil {
+		return err
+	}
+
+	content, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("Error exporting domain: %s", err)
+	}
+
+	fmt.Print(string(content))
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -239,13 +240,19 @@ func ddosxDomainACLGeoIPRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainACLGeoIPRuleDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainACLGeoIPRuleDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainACLGeoIPRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainACLGeoIPRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following ACL GeoIP rules will be removed from domain [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainACLGeoIPRule(args[0], arg)
 		if err != nil {
@@ -253,4 +260,6 @@ func ddosxDomainACLGeoIPRuleDelete(service ddosx.DDoSXService, cmd *cobra.Comman
 			continue
 		}
 	}
+
+	return nil
 }
@@ -8,9 +8,11 @@ import (
 	"github.com/ukfast/sdk-go/pkg/ptr"
 
 	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/batch"
 	"github.com/ukfast/cli/internal/pkg/clierrors"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -118,7 +120,7 @@ func ddosxDomainACLIPRuleCreateCmd(f factory.ClientFactory) *cobra.Command {
 		Use:     "create <domain: name>",
 		Short:   "Creates ACL IP rules",
 		Long:    "This command creates domain ACL IP rules",
-		Example: "ukfast ddosx domain acl ip create example.com --ip 1.2.3.4 --mode Deny --uri blog",
+		Example: "ukfast ddosx domain acl ip create example.com --ip 1.2.3.4 --mode Deny --uri blog\nukfast ddosx domain acl ip create example.com --from-file rules.csv --continue-on-error",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("Missing domain")
@@ -142,6 +144,8 @@ func ddosxDomainACLIPRuleCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().String("mode", "", "Mode for IP ACL rule. Valid values: "+ddosx.ACLIPModeEnum.String())
 	cmd.MarkFlagRequired("mode")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -201,6 +205,8 @@ func ddosxDomainACLIPRuleUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().String("uri", "", "URI for IP ACL rule")
 	cmd.Flags().String("mode", "", "Mode for IP ACL rule")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -268,13 +274,19 @@ func ddosxDomainACLIPRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainACLIPRuleDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainACLIPRuleDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainACLIPRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainACLIPRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following ACL IP rules will be removed from domain [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainACLIPRule(args[0], arg)
 		if err != nil {
@@ -282,4 +294,6 @@ func ddosxDomainACLIPRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command,
 			continue
 		}
 	}
+
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/clierrors"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -305,13 +306,19 @@ func ddosxDomainCDNRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainCDNRuleDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainCDNRuleDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainCDNRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainCDNRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following CDN rules will be removed from domain [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainCDNRule(args[0], arg)
 		if err != nil {
@@ -319,4 +326,6 @@ func ddosxDomainCDNRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, ar
 			continue
 		}
 	}
+
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/clierrors"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -265,13 +266,19 @@ func ddosxDomainHSTSRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainHSTSRuleDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainHSTSRuleDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainHSTSRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainHSTSRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following HSTS rules will be removed from domain [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainHSTSRule(args[0], arg)
 		if err != nil {
@@ -279,4 +286,6 @@ func ddosxDomainHSTSRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, a
 			continue
 		}
 	}
+
+	return nil
 }
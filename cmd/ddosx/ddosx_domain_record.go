@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -87,7 +88,13 @@ func ddosxDomainRecordShowCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return ddosxDomainRecordShow(c.DDoSXService(), cmd, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxDomainRecordShow(service, cmd, args)
 		},
 	}
 }
@@ -128,7 +135,13 @@ func ddosxDomainRecordCreateCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return ddosxDomainRecordCreate(c.DDoSXService(), cmd, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxDomainRecordCreate(service, cmd, args)
 		},
 	}
 
@@ -137,7 +150,7 @@ func ddosxDomainRecordCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.MarkFlagRequired("name")
 	cmd.Flags().String("type", "", "Type of record")
 	cmd.Flags().String("content", "", "Content of record")
-	cmd.Flags().String("ssl-id", "", "ID of SSL to use for record")
+	cmd.Flags().String("ssl-id", "", "ID or friendly name of SSL to use for record")
 	cmd.Flags().Int("safedns-record-id", 0, "ID of SafeDNS record")
 
 	return cmd
@@ -193,7 +206,13 @@ func ddosxDomainRecordUpdateCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return ddosxDomainRecordUpdate(c.DDoSXService(), cmd, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxDomainRecordUpdate(service, cmd, args)
 		},
 	}
 
@@ -201,7 +220,7 @@ func ddosxDomainRecordUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().String("name", "", "Name of record")
 	cmd.Flags().String("type", "", "Type of record")
 	cmd.Flags().String("content", "", "Content of record")
-	cmd.Flags().String("ssl-id", "", "ID of SSL to use for record")
+	cmd.Flags().String("ssl-id", "", "ID or friendly name of SSL to use for record")
 	cmd.Flags().Int("safedns-record-id", 0, "ID of SafeDNS record")
 
 	return cmd
@@ -269,13 +288,26 @@ func ddosxDomainRecordDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainRecordDelete(c.DDoSXService(), cmd, args)
-			return nil
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxDomainRecordDelete(service, cmd, args)
 		},
 	}
 }
 
-func ddosxDomainRecordDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainRecordDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, fmt.Sprintf("The following records will be removed from domain [%s]", args[0]), args[1:], func(id string) (string, error) {
+		record, err := service.GetDomainRecord(args[0], id)
+		return fmt.Sprintf("%s %s %s", record.Name, record.Type, record.Content), err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainRecord(args[0], arg)
 		if err != nil {
@@ -283,4 +315,6 @@ func ddosxDomainRecordDelete(service ddosx.DDoSXService, cmd *cobra.Command, arg
 			continue
 		}
 	}
+
+	return nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -221,13 +222,19 @@ func ddosxDomainWAFDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainWAFDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainWAFDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainWAFDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainWAFDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, "The WAF will be removed from the following domains", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteDomainWAF(arg)
 		if err != nil {
@@ -235,4 +242,6 @@ func ddosxDomainWAFDelete(service ddosx.DDoSXService, cmd *cobra.Command, args [
 			continue
 		}
 	}
+
+	return nil
 }
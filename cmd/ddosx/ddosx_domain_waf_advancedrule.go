@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -273,13 +274,19 @@ func ddosxDomainWAFAdvancedRuleDeleteCmd(f factory.ClientFactory) *cobra.Command
 				return err
 			}
 
-			ddosxDomainWAFAdvancedRuleDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainWAFAdvancedRuleDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainWAFAdvancedRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainWAFAdvancedRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following WAF advanced rules will be removed from domain [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainWAFAdvancedRule(args[0], arg)
 		if err != nil {
@@ -287,4 +294,6 @@ func ddosxDomainWAFAdvancedRuleDelete(service ddosx.DDoSXService, cmd *cobra.Com
 			continue
 		}
 	}
+
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -248,13 +249,19 @@ func ddosxDomainWAFRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxDomainWAFRuleDelete(c.DDoSXService(), cmd, args)
-			return nil
+			return ddosxDomainWAFRuleDelete(c.DDoSXService(), cmd, args)
 		},
 	}
 }
 
-func ddosxDomainWAFRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxDomainWAFRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following WAF rules will be removed from domain [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteDomainWAFRule(args[0], arg)
 		if err != nil {
@@ -262,4 +269,6 @@ func ddosxDomainWAFRuleDelete(service ddosx.DDoSXService, cmd *cobra.Command, ar
 			continue
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,52 @@
+package ddosx
+
+import (
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/resource"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ddosx"
+)
+
+var ddosxIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ddosxResolver returns a Resolver which locates DDoSX resources named within the arguments and
+// flags of commands. Domains are identified by name, so aren't located
+func ddosxResolver(service ddosx.DDoSXService) *resource.Resolver {
+	ssl := &resource.Locator{
+		Name: "SSL",
+		IsID: ddosxIDRegexp.MatchString,
+		Provider: resource.NewFilterLocatorProvider(func(p connection.APIRequestParameters) (interface{}, error) {
+			return service.GetSSLs(p)
+		}, "friendly_name"),
+	}
+
+	// Records are located within the domain of the first argument
+	record := func(preceding []string) *resource.Locator {
+		return &resource.Locator{
+			Name: "record",
+			IsID: ddosxIDRegexp.MatchString,
+			Provider: resource.NewFilterLocatorProvider(func(p connection.APIRequestParameters) (interface{}, error) {
+				return service.GetDomainRecords(preceding[0], p)
+			}, "name"),
+		}
+	}
+
+	return &resource.Resolver{
+		Root: "ddosx",
+		Commands: map[string]*resource.CommandLocators{
+			"domain record": {
+				Args:  []resource.ArgLocatorFunc{nil, record},
+				Flags: map[string]*resource.Locator{"ssl-id": ssl},
+			},
+			"ssl": {Args: []resource.ArgLocatorFunc{resource.ArgLocator(ssl)}},
+		},
+	}
+}
+
+// ddosxResolve returns args with DDoSX resource names replaced by their IDs, replacing names within
+// flag values in place
+func ddosxResolve(service ddosx.DDoSXService, cmd *cobra.Command, args []string) ([]string, error) {
+	return ddosxResolver(service).Resolve(cmd, args)
+}
@@ -0,0 +1,62 @@
+package ddosx
+
+import (
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ddosx"
+)
+
+// findDDoSXCmd returns the DDoSX command at path, e.g. 'ssl show'
+func findDDoSXCmd(t *testing.T, path ...string) *cobra.Command {
+	cmd, _, err := DDoSXRootCmd(nil, afero.NewMemMapFs()).Find(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cmd
+}
+
+func Test_ddosxResolve(t *testing.T) {
+	t.Run("SSLArgs_LocatesFriendlyNames", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+		cmd := findDDoSXCmd(t, "ssl", "show")
+
+		params := connection.APIRequestParameters{}
+		params.WithFilter(connection.APIRequestFiltering{Property: "friendly_name", Operator: connection.EQOperator, Value: []string{"example"}})
+		service.EXPECT().GetSSLs(params).Return([]ddosx.SSL{{ID: "00000000-0000-0000-0000-000000000001"}}, nil)
+
+		args, err := ddosxResolve(service, cmd, []string{"example", "00000000-0000-0000-0000-000000000002"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"}, args)
+	})
+
+	t.Run("RecordArgs_LocatedWithinDomain", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+		cmd := findDDoSXCmd(t, "domain", "record", "show")
+
+		params := connection.APIRequestParameters{}
+		params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{"www.example.com"}})
+		service.EXPECT().GetDomainRecords("example.com", params).Return([]ddosx.Record{
+			{ID: "00000000-0000-0000-0000-000000000001", Type: ddosx.RecordTypeA},
+			{ID: "00000000-0000-0000-0000-000000000002", Type: ddosx.RecordTypeAAAA},
+		}, nil)
+
+		_, err := ddosxResolve(service, cmd, []string{"example.com", "www.example.com"})
+
+		assert.Equal(t, "Error locating record [www.example.com]: More than one item found matching [www.example.com] (name), "+
+			"candidates: 00000000-0000-0000-0000-000000000001, 00000000-0000-0000-0000-000000000002", err.Error())
+	})
+}
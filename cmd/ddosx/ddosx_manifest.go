@@ -0,0 +1,637 @@
+package ddosx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ddosx"
+)
+
+// ManifestHandlers returns manifest handlers for DDoSX resources, keyed by kind
+func ManifestHandlers(service ddosx.DDoSXService) map[string]manifest.Handler {
+	return map[string]manifest.Handler{
+		"ddosx/domain":            &domainManifestHandler{service: service},
+		"ddosx/record":            &recordManifestHandler{service: service},
+		"ddosx/waf":               &wafManifestHandler{service: service},
+		"ddosx/cdn":               &cdnManifestHandler{service: service},
+		"ddosx/hsts":              &hstsManifestHandler{service: service},
+		"ddosx/waf-rule":          newWAFRuleManifestHandler(service),
+		"ddosx/waf-advanced-rule": newWAFAdvancedRuleManifestHandler(service),
+		"ddosx/acl-ip-rule":       newACLIPRuleManifestHandler(service),
+		"ddosx/acl-geoip-rule":    newACLGeoIPRuleManifestHandler(service),
+		"ddosx/cdn-rule":          newCDNRuleManifestHandler(service),
+		"ddosx/hsts-rule":         newHSTSRuleManifestHandler(service),
+	}
+}
+
+var domainReferences = map[string]string{"domain": "ddosx/domain"}
+
+type domainManifestHandler struct {
+	service ddosx.DDoSXService
+}
+
+func (h *domainManifestHandler) References() map[string]string {
+	return nil
+}
+
+func (h *domainManifestHandler) UpdatableFields() []string {
+	return nil
+}
+
+func (h *domainManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	domain, err := h.service.GetDomain(spec.String("name"))
+	if err != nil {
+		if _, ok := err.(*ddosx.DomainNotFoundError); ok {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return domain.Name, domain, nil
+}
+
+func (h *domainManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ddosx.CreateDomainRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.Name, h.service.CreateDomain(req)
+}
+
+func (h *domainManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	return fmt.Errorf("Domains cannot be updated")
+}
+
+func (h *domainManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteDomain(id.(string))
+}
+
+// recordManifestHandler manages domain records, which are identified by name and type within the
+// domain specified by spec field 'domain'. Where multiple records exist with the same name and type,
+// records are additionally identified by content
+type recordManifestHandler struct {
+	service ddosx.DDoSXService
+}
+
+func (h *recordManifestHandler) References() map[string]string {
+	return domainReferences
+}
+
+func (h *recordManifestHandler) UpdatableFields() []string {
+	return []string{"content", "ssl_id", "safedns_record_id"}
+}
+
+func (h *recordManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{spec.String("name")}})
+	params.WithFilter(connection.APIRequestFiltering{Property: "type", Operator: connection.EQOperator, Value: []string{spec.String("type")}})
+
+	records, err := h.service.GetDomainRecords(spec.String("domain"), params)
+	if err != nil || len(records) < 1 {
+		return nil, nil, err
+	}
+
+	if len(records) > 1 {
+		var matching []ddosx.Record
+		for _, record := range records {
+			if record.Content == spec.String("content") {
+				matching = append(matching, record)
+			}
+		}
+		if len(matching) != 1 {
+			return nil, nil, fmt.Errorf("%d records found with name [%s] and type [%s]", len(records), spec.String("name"), spec.String("type"))
+		}
+		records = matching
+	}
+
+	return records[0].ID, records[0], nil
+}
+
+func (h *recordManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ddosx.CreateRecordRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.CreateDomainRecord(spec.String("domain"), req)
+}
+
+func (h *recordManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ddosx.PatchRecordRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	return h.service.PatchDomainRecord(spec.String("domain"), id.(string), patch)
+}
+
+func (h *recordManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteDomainRecord(spec.String("domain"), id.(string))
+}
+
+// wafManifestHandler manages the WAF configuration of the domain specified by spec field 'domain'
+type wafManifestHandler struct {
+	service ddosx.DDoSXService
+}
+
+func (h *wafManifestHandler) Unnamed() {}
+
+func (h *wafManifestHandler) References() map[string]string {
+	return domainReferences
+}
+
+func (h *wafManifestHandler) UpdatableFields() []string {
+	return []string{"mode", "paranoia_level"}
+}
+
+func (h *wafManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	waf, err := h.service.GetDomainWAF(spec.String("domain"))
+	if err != nil {
+		if _, ok := err.(*ddosx.DomainWAFNotFoundError); ok {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return spec.String("domain"), waf, nil
+}
+
+func (h *wafManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ddosx.CreateWAFRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return spec.String("domain"), h.service.CreateDomainWAF(spec.String("domain"), req)
+}
+
+func (h *wafManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ddosx.PatchWAFRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	return h.service.PatchDomainWAF(id.(string), patch)
+}
+
+func (h *wafManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteDomainWAF(id.(string))
+}
+
+// cdnManifestHandler manages the CDN configuration of the domain specified by spec field 'domain'
+type cdnManifestHandler struct {
+	service ddosx.DDoSXService
+}
+
+func (h *cdnManifestHandler) Unnamed() {}
+
+func (h *cdnManifestHandler) References() map[string]string {
+	return domainReferences
+}
+
+func (h *cdnManifestHandler) UpdatableFields() []string {
+	return nil
+}
+
+func (h *cdnManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	domain, err := h.service.GetDomain(spec.String("domain"))
+	if err != nil || !domain.CDNActive {
+		return nil, nil, err
+	}
+
+	return domain.Name, struct{}{}, nil
+}
+
+func (h *cdnManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	return spec.String("domain"), h.service.AddDomainCDNConfiguration(spec.String("domain"))
+}
+
+func (h *cdnManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	return nil
+}
+
+func (h *cdnManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteDomainCDNConfiguration(id.(string))
+}
+
+// hstsManifestHandler manages the HSTS configuration of the domain specified by spec field 'domain'
+type hstsManifestHandler struct {
+	service ddosx.DDoSXService
+}
+
+func (h *hstsManifestHandler) Unnamed() {}
+
+func (h *hstsManifestHandler) References() map[string]string {
+	return domainReferences
+}
+
+func (h *hstsManifestHandler) UpdatableFields() []string {
+	return nil
+}
+
+func (h *hstsManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	configuration, err := h.service.GetDomainHSTSConfiguration(spec.String("domain"))
+	if err != nil {
+		if _, ok := err.(*ddosx.DomainHSTSConfigurationNotFoundError); ok {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if !configuration.Enabled {
+		return nil, nil, nil
+	}
+
+	return spec.String("domain"), struct{}{}, nil
+}
+
+func (h *hstsManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	return spec.String("domain"), h.service.AddDomainHSTSConfiguration(spec.String("domain"))
+}
+
+func (h *hstsManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	return nil
+}
+
+func (h *hstsManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteDomainHSTSConfiguration(id.(string))
+}
+
+// domainRule is a rule retrieved by a domainRuleManifestHandler, with model being an SDK model struct
+type domainRule struct {
+	id    string
+	model interface{}
+}
+
+// domainRuleManifestHandler manages rules of the domain specified by spec field 'domain'. Rules
+// aren't named, and are instead identified by the values of identityFields
+type domainRuleManifestHandler struct {
+	identityFields  []string
+	updatableFields []string
+
+	list   func(domainName string) ([]domainRule, error)
+	create func(domainName string, spec manifest.Spec) (string, error)
+	update func(domainName string, ruleID string, spec manifest.Spec) error
+	delete func(domainName string, ruleID string) error
+}
+
+func (h *domainRuleManifestHandler) Unnamed() {}
+
+func (h *domainRuleManifestHandler) References() map[string]string {
+	return domainReferences
+}
+
+func (h *domainRuleManifestHandler) UpdatableFields() []string {
+	return h.updatableFields
+}
+
+func (h *domainRuleManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	rules, err := h.list(spec.String("domain"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matching []domainRule
+	for _, rule := range rules {
+		matches, err := spec.Matches(rule.model, h.identityFields)
+		if err != nil {
+			return nil, nil, err
+		}
+		if matches {
+			matching = append(matching, rule)
+		}
+	}
+
+	if len(matching) < 1 {
+		return nil, nil, nil
+	}
+	if len(matching) > 1 {
+		return nil, nil, fmt.Errorf("%d rules found matching %v", len(matching), h.identityFields)
+	}
+
+	return matching[0].id, matching[0].model, nil
+}
+
+func (h *domainRuleManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	return h.create(spec.String("domain"), spec)
+}
+
+func (h *domainRuleManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	return h.update(spec.String("domain"), id.(string), spec.Subset(fields))
+}
+
+func (h *domainRuleManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.delete(spec.String("domain"), id.(string))
+}
+
+func newWAFRuleManifestHandler(service ddosx.DDoSXService) *domainRuleManifestHandler {
+	return &domainRuleManifestHandler{
+		identityFields: []string{"uri", "ip"},
+		list: func(domainName string) ([]domainRule, error) {
+			rules, err := service.GetDomainWAFRules(domainName, connection.APIRequestParameters{})
+			var domainRules []domainRule
+			for _, rule := range rules {
+				domainRules = append(domainRules, domainRule{id: rule.ID, model: rule})
+			}
+			return domainRules, err
+		},
+		create: func(domainName string, spec manifest.Spec) (string, error) {
+			req := ddosx.CreateWAFRuleRequest{}
+			err := spec.Decode(&req)
+			if err != nil {
+				return "", err
+			}
+			return service.CreateDomainWAFRule(domainName, req)
+		},
+		update: func(domainName string, ruleID string, spec manifest.Spec) error {
+			patch := ddosx.PatchWAFRuleRequest{}
+			err := spec.Decode(&patch)
+			if err != nil {
+				return err
+			}
+			return service.PatchDomainWAFRule(domainName, ruleID, patch)
+		},
+		delete: service.DeleteDomainWAFRule,
+	}
+}
+
+func newWAFAdvancedRuleManifestHandler(service ddosx.DDoSXService) *domainRuleManifestHandler {
+	return &domainRuleManifestHandler{
+		identityFields: []string{"section", "modifier", "phrase", "ip"},
+		list: func(domainName string) ([]domainRule, error) {
+			rules, err := service.GetDomainWAFAdvancedRules(domainName, connection.APIRequestParameters{})
+			var domainRules []domainRule
+			for _, rule := range rules {
+				domainRules = append(domainRules, domainRule{id: rule.ID, model: rule})
+			}
+			return domainRules, err
+		},
+		create: func(domainName string, spec manifest.Spec) (string, error) {
+			req := ddosx.CreateWAFAdvancedRuleRequest{}
+			err := spec.Decode(&req)
+			if err != nil {
+				return "", err
+			}
+			return service.CreateDomainWAFAdvancedRule(domainName, req)
+		},
+		update: func(domainName string, ruleID string, spec manifest.Spec) error {
+			patch := ddosx.PatchWAFAdvancedRuleRequest{}
+			err := spec.Decode(&patch)
+			if err != nil {
+				return err
+			}
+			return service.PatchDomainWAFAdvancedRule(domainName, ruleID, patch)
+		},
+		delete: service.DeleteDomainWAFAdvancedRule,
+	}
+}
+
+func newACLIPRuleManifestHandler(service ddosx.DDoSXService) *domainRuleManifestHandler {
+	return &domainRuleManifestHandler{
+		identityFields:  []string{"ip", "uri"},
+		updatableFields: []string{"mode"},
+		list: func(domainName string) ([]domainRule, error) {
+			rules, err := service.GetDomainACLIPRules(domainName, connection.APIRequestParameters{})
+			var domainRules []domainRule
+			for _, rule := range rules {
+				domainRules = append(domainRules, domainRule{id: rule.ID, model: rule})
+			}
+			return domainRules, err
+		},
+		create: func(domainName string, spec manifest.Spec) (string, error) {
+			req := ddosx.CreateACLIPRuleRequest{}
+			err := spec.Decode(&req)
+			if err != nil {
+				return "", err
+			}
+			return service.CreateDomainACLIPRule(domainName, req)
+		},
+		update: func(domainName string, ruleID string, spec manifest.Spec) error {
+			patch := ddosx.PatchACLIPRuleRequest{}
+			err := spec.Decode(&patch)
+			if err != nil {
+				return err
+			}
+			return service.PatchDomainACLIPRule(domainName, ruleID, patch)
+		},
+		delete: service.DeleteDomainACLIPRule,
+	}
+}
+
+func newACLGeoIPRuleManifestHandler(service ddosx.DDoSXService) *domainRuleManifestHandler {
+	return &domainRuleManifestHandler{
+		identityFields: []string{"code"},
+		list: func(domainName string) ([]domainRule, error) {
+			rules, err := service.GetDomainACLGeoIPRules(domainName, connection.APIRequestParameters{})
+			var domainRules []domainRule
+			for _, rule := range rules {
+				domainRules = append(domainRules, domainRule{id: rule.ID, model: rule})
+			}
+			return domainRules, err
+		},
+		create: func(domainName string, spec manifest.Spec) (string, error) {
+			req := ddosx.CreateACLGeoIPRuleRequest{}
+			err := spec.Decode(&req)
+			if err != nil {
+				return "", err
+			}
+			return service.CreateDomainACLGeoIPRule(domainName, req)
+		},
+		update: func(domainName string, ruleID string, spec manifest.Spec) error {
+			patch := ddosx.PatchACLGeoIPRuleRequest{}
+			err := spec.Decode(&patch)
+			if err != nil {
+				return err
+			}
+			return service.PatchDomainACLGeoIPRule(domainName, ruleID, patch)
+		},
+		delete: service.DeleteDomainACLGeoIPRule,
+	}
+}
+
+func newCDNRuleManifestHandler(service ddosx.DDoSXService) *domainRuleManifestHandler {
+	return &domainRuleManifestHandler{
+		identityFields:  []string{"uri", "type"},
+		updatableFields: []string{"cache_control", "cache_control_duration", "mime_types"},
+		list: func(domainName string) ([]domainRule, error) {
+			rules, err := service.GetDomainCDNRules(domainName, connection.APIRequestParameters{})
+			var domainRules []domainRule
+			for _, rule := range rules {
+				domainRules = append(domainRules, domainRule{id: rule.ID, model: rule})
+			}
+			return domainRules, err
+		},
+		create: func(domainName string, spec manifest.Spec) (string, error) {
+			req := ddosx.CreateCDNRuleRequest{}
+			err := spec.Decode(&req)
+			if err != nil {
+				return "", err
+			}
+			return service.CreateDomainCDNRule(domainName, req)
+		},
+		update: func(domainName string, ruleID string, spec manifest.Spec) error {
+			patch := ddosx.PatchCDNRuleRequest{}
+			err := spec.Decode(&patch)
+			if err != nil {
+				return err
+			}
+			return service.PatchDomainCDNRule(domainName, ruleID, patch)
+		},
+		delete: service.DeleteDomainCDNRule,
+	}
+}
+
+func newHSTSRuleManifestHandler(service ddosx.DDoSXService) *domainRuleManifestHandler {
+	return &domainRuleManifestHandler{
+		identityFields:  []string{"type", "record_name"},
+		updatableFields: []string{"max_age", "preload", "include_subdomains"},
+		list: func(domainName string) ([]domainRule, error) {
+			rules, err := service.GetDomainHSTSRules(domainName, connection.APIRequestParameters{})
+			var domainRules []domainRule
+			for _, rule := range rules {
+				domainRules = append(domainRules, domainRule{id: rule.ID, model: rule})
+			}
+			return domainRules, err
+		},
+		create: func(domainName string, spec manifest.Spec) (string, error) {
+			req := ddosx.CreateHSTSRuleRequest{}
+			err := spec.Decode(&req)
+			if err != nil {
+				return "", err
+			}
+			return service.CreateDomainHSTSRule(domainName, req)
+		},
+		update: func(domainName string, ruleID string, spec manifest.Spec) error {
+			patch := ddosx.PatchHSTSRuleRequest{}
+			err := spec.Decode(&patch)
+			if err != nil {
+				return err
+			}
+			return service.PatchDomainHSTSRule(domainName, ruleID, patch)
+		},
+		delete: service.DeleteDomainHSTSRule,
+	}
+}
+
+// exportDomainManifest returns a manifest containing the domain with given name, along with its
+// records, WAF configuration and rules, ACL rules, CDN configuration and rules, and HSTS configuration
+// and rules. Rules are named by domain, kind and index, as they aren't otherwise named
+func exportDomainManifest(service ddosx.DDoSXService, domainName string) (*manifest.Manifest, error) {
+	domain, err := service.GetDomain(domainName)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving domain: %s", err)
+	}
+
+	m := &manifest.Manifest{}
+	add := func(kind string, name string, model interface{}, fields ...string) error {
+		r, err := manifest.NewResource(kind, name, model, fields...)
+		if err != nil {
+			return err
+		}
+		if kind != "ddosx/domain" {
+			r.Spec["domain"] = domain.Name
+		}
+
+		m.Add(r)
+		return nil
+	}
+	addRules := func(kind string, handler *domainRuleManifestHandler, fields ...string) error {
+		rules, err := handler.list(domain.Name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving %s rules: %s", kind, err)
+		}
+		for i, rule := range rules {
+			err := add(kind, fmt.Sprintf("%s-%s-%d", domain.Name, strings.TrimPrefix(kind, "ddosx/"), i+1), rule.model, fields...)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = add("ddosx/domain", domain.Name, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := service.GetDomainRecords(domain.Name, connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving records: %s", err)
+	}
+	for _, record := range records {
+		err = add("ddosx/record", record.Name, record, "type", "content", "ssl_id", "safedns_record_id")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if domain.WAFActive {
+		waf, err := service.GetDomainWAF(domain.Name)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving WAF: %s", err)
+		}
+		err = add("ddosx/waf", domain.Name+"-waf", waf, "mode", "paranoia_level")
+		if err != nil {
+			return nil, err
+		}
+
+		err = addRules("ddosx/waf-rule", newWAFRuleManifestHandler(service), "uri", "ip")
+		if err != nil {
+			return nil, err
+		}
+
+		err = addRules("ddosx/waf-advanced-rule", newWAFAdvancedRuleManifestHandler(service), "section", "modifier", "phrase", "ip")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = addRules("ddosx/acl-ip-rule", newACLIPRuleManifestHandler(service), "ip", "uri", "mode")
+	if err != nil {
+		return nil, err
+	}
+
+	err = addRules("ddosx/acl-geoip-rule", newACLGeoIPRuleManifestHandler(service), "code")
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.CDNActive {
+		err = add("ddosx/cdn", domain.Name+"-cdn", struct{}{})
+		if err != nil {
+			return nil, err
+		}
+
+		err = addRules("ddosx/cdn-rule", newCDNRuleManifestHandler(service), "uri", "type", "cache_control", "cache_control_duration", "mime_types")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hsts, err := service.GetDomainHSTSConfiguration(domain.Name)
+	if err != nil {
+		if _, ok := err.(*ddosx.DomainHSTSConfigurationNotFoundError); !ok {
+			return nil, fmt.Errorf("Error retrieving HSTS configuration: %s", err)
+		}
+	}
+	if hsts.Enabled {
+		err = add("ddosx/hsts", domain.Name+"-hsts", struct{}{})
+		if err != nil {
+			return nil, err
+		}
+
+		err = addRules("ddosx/hsts-rule", newHSTSRuleManifestHandler(service), "type", "record_name", "max_age", "preload", "include_subdomains")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
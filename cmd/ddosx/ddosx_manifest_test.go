@@ -0,0 +1,120 @@
+package ddosx
+
+import (
+	"errors"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/service/ddosx"
+)
+
+func Test_domainRuleManifestHandler_Find(t *testing.T) {
+	t.Run("MatchingRule_ReturnsRule", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+		handler := ManifestHandlers(service)["ddosx/acl-ip-rule"]
+
+		service.EXPECT().GetDomainACLIPRules("example.com", gomock.Any()).Return([]ddosx.ACLIPRule{
+			{ID: "00000000-0000-0000-0000-000000000001", IP: "1.2.3.4", URI: "/", Mode: ddosx.ACLIPModeAllow},
+			{ID: "00000000-0000-0000-0000-000000000002", IP: "1.2.3.4", URI: "/admin", Mode: ddosx.ACLIPModeAllow},
+		}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"domain": "example.com", "ip": "1.2.3.4", "uri": "/admin", "mode": "Deny"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000002", id)
+	})
+
+	t.Run("NoMatchingRule_ReturnsNilID", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+		handler := ManifestHandlers(service)["ddosx/acl-geoip-rule"]
+
+		service.EXPECT().GetDomainACLGeoIPRules("example.com", gomock.Any()).Return([]ddosx.ACLGeoIPRule{{ID: "00000000-0000-0000-0000-000000000001", Code: "GB"}}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"domain": "example.com", "code": "FR"})
+
+		assert.Nil(t, err)
+		assert.Nil(t, id)
+	})
+
+	t.Run("ListError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+		handler := ManifestHandlers(service)["ddosx/cdn-rule"]
+
+		service.EXPECT().GetDomainCDNRules("example.com", gomock.Any()).Return([]ddosx.CDNRule{}, errors.New("test error"))
+
+		_, _, err := handler.Find(manifest.Spec{"domain": "example.com"})
+
+		assert.Equal(t, "test error", err.Error())
+	})
+}
+
+func Test_wafManifestHandler_Find(t *testing.T) {
+	t.Run("NotFound_ReturnsNilID", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+		handler := ManifestHandlers(service)["ddosx/waf"]
+
+		service.EXPECT().GetDomainWAF("example.com").Return(ddosx.WAF{}, &ddosx.DomainWAFNotFoundError{DomainName: "example.com"})
+
+		id, _, err := handler.Find(manifest.Spec{"domain": "example.com"})
+
+		assert.Nil(t, err)
+		assert.Nil(t, id)
+	})
+}
+
+func Test_exportDomainManifest(t *testing.T) {
+	t.Run("ReturnsDomainResources", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomain("example.com").Return(ddosx.Domain{Name: "example.com", WAFActive: true}, nil)
+		service.EXPECT().GetDomainRecords("example.com", gomock.Any()).Return([]ddosx.Record{{ID: "00000000-0000-0000-0000-000000000001", Name: "www.example.com", Type: ddosx.RecordTypeA, Content: "1.2.3.4"}}, nil)
+		service.EXPECT().GetDomainWAF("example.com").Return(ddosx.WAF{Mode: ddosx.WAFModeOn, ParanoiaLevel: ddosx.WAFParanoiaLevelHigh}, nil)
+		service.EXPECT().GetDomainWAFRules("example.com", gomock.Any()).Return([]ddosx.WAFRule{}, nil)
+		service.EXPECT().GetDomainWAFAdvancedRules("example.com", gomock.Any()).Return([]ddosx.WAFAdvancedRule{}, nil)
+		service.EXPECT().GetDomainACLIPRules("example.com", gomock.Any()).Return([]ddosx.ACLIPRule{{ID: "00000000-0000-0000-0000-000000000002", IP: "1.2.3.4", URI: "/", Mode: ddosx.ACLIPModeDeny}}, nil)
+		service.EXPECT().GetDomainACLGeoIPRules("example.com", gomock.Any()).Return([]ddosx.ACLGeoIPRule{}, nil)
+		service.EXPECT().GetDomainHSTSConfiguration("example.com").Return(ddosx.HSTSConfiguration{}, &ddosx.DomainHSTSConfigurationNotFoundError{DomainName: "example.com"})
+
+		m, err := exportDomainManifest(service, "example.com")
+
+		assert.Nil(t, err)
+		assert.Len(t, m.Resources, 4)
+		assert.Equal(t, "ddosx/domain", m.Resources[0].Kind)
+		assert.Equal(t, map[string]interface{}{"domain": "example.com", "type": "A", "content": "1.2.3.4"}, m.Resources[1].Spec)
+		assert.Equal(t, "example.com-waf", m.Resources[2].Name)
+		assert.Equal(t, map[string]interface{}{"domain": "example.com", "mode": "On", "paranoia_level": "High"}, m.Resources[2].Spec)
+		assert.Equal(t, "example.com-acl-ip-rule-1", m.Resources[3].Name)
+		assert.Equal(t, map[string]interface{}{"domain": "example.com", "ip": "1.2.3.4", "uri": "/", "mode": "Deny"}, m.Resources[3].Spec)
+	})
+
+	t.Run("GetDomainError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomain("example.com").Return(ddosx.Domain{}, errors.New("test error"))
+
+		_, err := exportDomainManifest(service, "example.com")
+
+		assert.Equal(t, "Error retrieving domain: test error", err.Error())
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
@@ -82,7 +83,13 @@ func ddosxSSLShowCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return ddosxSSLShow(c.DDoSXService(), cmd, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxSSLShow(service, cmd, args)
 		},
 	}
 }
@@ -185,7 +192,13 @@ func ddosxSSLUpdateCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 				return err
 			}
 
-			return ddosxSSLUpdate(c.DDoSXService(), cmd, fs, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxSSLUpdate(service, cmd, fs, args)
 		},
 	}
 
@@ -257,13 +270,26 @@ func ddosxSSLDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ddosxSSLDelete(c.DDoSXService(), cmd, args)
-			return nil
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxSSLDelete(service, cmd, args)
 		},
 	}
 }
 
-func ddosxSSLDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) {
+func ddosxSSLDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following SSLs will be removed", args, func(id string) (string, error) {
+		ssl, err := service.GetSSL(id)
+		return ssl.FriendlyName, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteSSL(arg)
 		if err != nil {
@@ -271,4 +297,6 @@ func ddosxSSLDelete(service ddosx.DDoSXService, cmd *cobra.Command, args []strin
 			continue
 		}
 	}
+
+	return nil
 }
@@ -40,7 +40,13 @@ func ddosxSSLContentShowCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return ddosxSSLContentShow(c.DDoSXService(), cmd, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxSSLContentShow(service, cmd, args)
 		},
 	}
 }
@@ -40,7 +40,13 @@ func ddosxSSLPrivateKeyShowCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return ddosxSSLPrivateKeyShow(c.DDoSXService(), cmd, args)
+			service := c.DDoSXService()
+			args, err = ddosxResolve(service, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return ddosxSSLPrivateKeyShow(service, cmd, args)
 		},
 	}
 }
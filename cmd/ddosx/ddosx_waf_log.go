@@ -8,6 +8,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
 	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
 
@@ -44,6 +45,7 @@ func ddosxWAFLogListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("domain", "", "Domain name for filtering")
+	output.AddPaginatedFlags(cmd)
 
 	return cmd
 }
@@ -59,7 +61,11 @@ func ddosxWAFLogList(service ddosx.DDoSXService, cmd *cobra.Command, args []stri
 		return fmt.Errorf("Error retrieving WAF logs: %s", err)
 	}
 
-	return output.CommandOutputPaginated(cmd, OutputDDoSXWAFLogsProvider(paginatedLogs.Items), paginatedLogs)
+	return output.CommandOutputPaginated(cmd, params, paginatedLogs, func(p connection.APIRequestParameters) (connection.Paginated, error) {
+		return service.GetWAFLogsPaginated(p)
+	}, func(page connection.Paginated) output.OutputHandlerDataProvider {
+		return OutputDDoSXWAFLogsProvider(page.(*ddosx.PaginatedWAFLog).Items)
+	})
 }
 
 func ddosxWAFLogShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -8,6 +8,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
 	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/ddosx"
 )
 
@@ -41,6 +42,7 @@ func ddosxWAFLogMatchListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("log", "", "Show matches for specific log")
+	output.AddPaginatedFlags(cmd)
 
 	return cmd
 }
@@ -53,19 +55,25 @@ func ddosxWAFLogMatchList(service ddosx.DDoSXService, cmd *cobra.Command, args [
 		return err
 	}
 
-	var paginatedMatches *ddosx.PaginatedWAFLogMatch
+	getFunc := func(p connection.APIRequestParameters) (connection.Paginated, error) {
+		return service.GetWAFLogMatchesPaginated(p)
+	}
 
 	if cmd.Flags().Changed("log") {
 		log, _ := cmd.Flags().GetString("log")
-		paginatedMatches, err = service.GetWAFLogRequestMatchesPaginated(log, params)
-	} else {
-		paginatedMatches, err = service.GetWAFLogMatchesPaginated(params)
+		getFunc = func(p connection.APIRequestParameters) (connection.Paginated, error) {
+			return service.GetWAFLogRequestMatchesPaginated(log, p)
+		}
 	}
+
+	paginatedMatches, err := getFunc(params)
 	if err != nil {
 		return fmt.Errorf("Error retrieving WAF log matches: %s", err)
 	}
 
-	return output.CommandOutputPaginated(cmd, OutputDDoSXWAFLogMatchesProvider(paginatedMatches.Items), paginatedMatches)
+	return output.CommandOutputPaginated(cmd, params, paginatedMatches, getFunc, func(page connection.Paginated) output.OutputHandlerDataProvider {
+		return OutputDDoSXWAFLogMatchesProvider(page.(*ddosx.PaginatedWAFLogMatch).Items)
+	})
 }
 
 func ddosxWAFLogMatchShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/mockserver"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+func DevRootCmd(fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "sub-commands relating to CLI development tooling",
+	}
+
+	// Child commands
+	cmd.AddCommand(devMockServerCmd(fs))
+
+	return cmd
+}
+
+func devMockServerCmd(fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mock-server",
+		Short: "Starts a local mock API server",
+		Long: "This command starts a local HTTP server emulating a subset of UKFast API endpoints, with state held in-memory " +
+			"and optionally seeded from a YAML fixtures file. The CLI can be pointed at the server by setting api_uri, " +
+			"e.g. UKF_API_URI=http://127.0.0.1:8080",
+		Example: "ukfast dev mock-server --fixtures fixtures.yml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return devMockServer(fs, cmd, args)
+		},
+	}
+
+	cmd.Flags().String("listen", "127.0.0.1:8080", "Specifies the address to listen on")
+	cmd.Flags().String("fixtures", "", "Specifies the path to a YAML fixtures file to seed the server with")
+	cmd.Flags().Int("sync-polls", 2, "Specifies how many times a modified resource/task must be retrieved before its status transitions to 'complete'")
+
+	return cmd
+}
+
+func devMockServer(fs afero.Fs, cmd *cobra.Command, args []string) error {
+	fixtures := mockserver.Fixtures{}
+	if cmd.Flags().Changed("fixtures") {
+		fixturesPath, _ := cmd.Flags().GetString("fixtures")
+
+		var err error
+		fixtures, err = mockserver.LoadFixtures(fs, fixturesPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	syncPolls, _ := cmd.Flags().GetInt("sync-polls")
+	server, err := mockserver.NewServer(mockserver.DefaultResources, fixtures, syncPolls)
+	if err != nil {
+		return err
+	}
+
+	listen, _ := cmd.Flags().GetString("listen")
+	output.Errorf("Mock API server listening on http://%s", listen)
+
+	return fmt.Errorf("Mock API server failed: %s", http.ListenAndServe(listen, server))
+}
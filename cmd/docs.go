@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+func DocsRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Commands for generating CLI documentation",
+	}
+
+	// Child commands
+	cmd.AddCommand(docsManCmd())
+	cmd.AddCommand(docsMarkdownCmd())
+
+	return cmd
+}
+
+func docsManCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "man",
+		Short:   "Generates man pages",
+		Long:    "This command generates a man page for each command, written to the specified directory",
+		Example: "ukfast docs man --dir ./man",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return docsMan(cmd, args)
+		},
+	}
+
+	cmd.Flags().String("dir", "man", "Specifies the directory to write man pages to")
+
+	return cmd
+}
+
+func docsMan(cmd *cobra.Command, args []string) error {
+	dir, err := getDocsDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	header := &doc.GenManHeader{
+		Title:   "UKFAST",
+		Section: "1",
+		Source:  fmt.Sprintf("UKFast CLI %s", appVersion),
+		Manual:  "UKFast CLI Manual",
+	}
+
+	err = doc.GenManTree(cmd.Root(), header, dir)
+	if err != nil {
+		return fmt.Errorf("Error generating man pages: %s", err)
+	}
+
+	return nil
+}
+
+func docsMarkdownCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "markdown",
+		Short:   "Generates a markdown command reference",
+		Long:    "This command generates a markdown file for each command, written to the specified directory",
+		Example: "ukfast docs markdown --dir ./docs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return docsMarkdown(cmd, args)
+		},
+	}
+
+	cmd.Flags().String("dir", "docs", "Specifies the directory to write markdown files to")
+
+	return cmd
+}
+
+func docsMarkdown(cmd *cobra.Command, args []string) error {
+	dir, err := getDocsDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = doc.GenMarkdownTree(cmd.Root(), dir)
+	if err != nil {
+		return fmt.Errorf("Error generating markdown: %s", err)
+	}
+
+	return nil
+}
+
+// getDocsDir returns the directory specified by the 'dir' flag, creating it if required. The
+// auto-generated tag is disabled, so that regenerated documentation only differs when commands
+// have changed
+func getDocsDir(cmd *cobra.Command) (string, error) {
+	dir, _ := cmd.Flags().GetString("dir")
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("Error creating directory [%s]: %s", dir, err)
+	}
+
+	cmd.Root().DisableAutoGenTag = true
+
+	return dir, nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestDocsCmds returns the man and markdown commands, attached to a test command tree
+func newTestDocsCmds() (*cobra.Command, *cobra.Command) {
+	root := &cobra.Command{Use: "ukfast"}
+	root.AddCommand(&cobra.Command{
+		Use:     "test",
+		Short:   "Test command",
+		Example: "ukfast test --flag value",
+		Run:     func(cmd *cobra.Command, args []string) {},
+	})
+
+	manCmd := docsManCmd()
+	markdownCmd := docsMarkdownCmd()
+	docsCmd := &cobra.Command{Use: "docs"}
+	docsCmd.AddCommand(manCmd, markdownCmd)
+	root.AddCommand(docsCmd)
+
+	return manCmd, markdownCmd
+}
+
+func TestDocsMarkdown(t *testing.T) {
+	t.Run("GeneratesMarkdownIncludingExamples", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "ukfast-docs")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dir)
+
+		_, cmd := newTestDocsCmds()
+		cmd.ParseFlags([]string{"--dir", filepath.Join(dir, "markdown")})
+
+		err = docsMarkdown(cmd, []string{})
+
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(filepath.Join(dir, "markdown", "ukfast_test.md"))
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), "ukfast test --flag value")
+		assert.NotContains(t, string(content), "Auto generated")
+	})
+}
+
+func TestDocsMan(t *testing.T) {
+	t.Run("GeneratesManPages", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "ukfast-docs")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dir)
+
+		cmd, _ := newTestDocsCmds()
+		cmd.ParseFlags([]string{"--dir", dir})
+
+		err = docsMan(cmd, []string{})
+
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(filepath.Join(dir, "ukfast-test.1"))
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), "ukfast test --flag value")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/draas"
@@ -130,8 +131,7 @@ func draasSolutionFailoverPlanStartCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			draasSolutionFailoverPlanStart(c.DRaaSService(), cmd, args)
-			return nil
+			return draasSolutionFailoverPlanStart(c.DRaaSService(), cmd, args)
 		},
 	}
 
@@ -140,7 +140,15 @@ func draasSolutionFailoverPlanStartCmd(f factory.ClientFactory) *cobra.Command {
 	return cmd
 }
 
-func draasSolutionFailoverPlanStart(service draas.DRaaSService, cmd *cobra.Command, args []string) {
+func draasSolutionFailoverPlanStart(service draas.DRaaSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, fmt.Sprintf("The following failover plans will be started for solution [%s]", args[0]), args[1:], func(id string) (string, error) {
+		plan, err := service.GetSolutionFailoverPlan(args[0], id)
+		return plan.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	req := draas.StartFailoverPlanRequest{}
 
 	if cmd.Flags().Changed("date") {
@@ -155,6 +163,8 @@ func draasSolutionFailoverPlanStart(service draas.DRaaSService, cmd *cobra.Comma
 			continue
 		}
 	}
+
+	return nil
 }
 
 func draasSolutionFailoverPlanStopCmd(f factory.ClientFactory) *cobra.Command {
@@ -185,6 +185,8 @@ func TaskStatusWaitFunc(service ecloud.ECloudService, taskID string, expectedSta
 
 type ecloudServiceCobraRunEFunc func(service ecloud.ECloudService, cmd *cobra.Command, args []string) error
 
+// ecloudCobraRunEFunc returns a cobra RunE func which invokes rf with an eCloud service, once
+// resources named within args and flags have been located
 func ecloudCobraRunEFunc(f factory.ClientFactory, rf ecloudServiceCobraRunEFunc) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		c, err := f.NewClient()
@@ -192,6 +194,12 @@ func ecloudCobraRunEFunc(f factory.ClientFactory, rf ecloudServiceCobraRunEFunc)
 			return err
 		}
 
-		return rf(c.ECloudService(), cmd, args)
+		service := c.ECloudService()
+		args, err = ecloudResolver(service).Resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		return rf(service, cmd, args)
 	}
 }
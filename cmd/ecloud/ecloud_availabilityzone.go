@@ -37,7 +37,7 @@ func ecloudAvailabilityZoneListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Availability zone name for filtering")
-	cmd.Flags().String("region", "", "Region ID for filtering")
+	cmd.Flags().String("region", "", "Region ID or name for filtering")
 
 	return cmd
 }
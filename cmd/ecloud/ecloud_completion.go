@@ -0,0 +1,117 @@
+package ecloud
+
+import (
+	"github.com/ukfast/cli/internal/pkg/completion"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ecloud"
+)
+
+// ecloudCompletionFunc returns a completion func for resources returned by list, retrieved using
+// an eCloud service from factory f
+func ecloudCompletionFunc(f factory.ClientFactory, key string, list func(service ecloud.ECloudService) ([]completion.Resource, error)) completion.CobraCompletionFunc {
+	return completion.ResourceCompletionFunc(key, func() ([]completion.Resource, error) {
+		c, err := f.NewClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return list(c.ECloudService())
+	})
+}
+
+func ecloudInstanceCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return ecloudCompletionFunc(f, "ecloud_instances", func(service ecloud.ECloudService) ([]completion.Resource, error) {
+		instances, err := service.GetInstances(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, instance := range instances {
+			resources = append(resources, completion.Resource{ID: instance.ID, Name: instance.Name})
+		}
+
+		return resources, nil
+	})
+}
+
+func ecloudVPCCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return ecloudCompletionFunc(f, "ecloud_vpcs", func(service ecloud.ECloudService) ([]completion.Resource, error) {
+		vpcs, err := service.GetVPCs(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, vpc := range vpcs {
+			resources = append(resources, completion.Resource{ID: vpc.ID, Name: vpc.Name})
+		}
+
+		return resources, nil
+	})
+}
+
+func ecloudImageCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return ecloudCompletionFunc(f, "ecloud_images", func(service ecloud.ECloudService) ([]completion.Resource, error) {
+		images, err := service.GetImages(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, image := range images {
+			resources = append(resources, completion.Resource{ID: image.ID, Name: image.Name})
+		}
+
+		return resources, nil
+	})
+}
+
+func ecloudNetworkCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return ecloudCompletionFunc(f, "ecloud_networks", func(service ecloud.ECloudService) ([]completion.Resource, error) {
+		networks, err := service.GetNetworks(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, network := range networks {
+			resources = append(resources, completion.Resource{ID: network.ID, Name: network.Name})
+		}
+
+		return resources, nil
+	})
+}
+
+func ecloudFirewallPolicyCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return ecloudCompletionFunc(f, "ecloud_firewallpolicies", func(service ecloud.ECloudService) ([]completion.Resource, error) {
+		policies, err := service.GetFirewallPolicies(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, policy := range policies {
+			resources = append(resources, completion.Resource{ID: policy.ID, Name: policy.Name})
+		}
+
+		return resources, nil
+	})
+}
+
+func ecloudNetworkPolicyCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return ecloudCompletionFunc(f, "ecloud_networkpolicies", func(service ecloud.ECloudService) ([]completion.Resource, error) {
+		policies, err := service.GetNetworkPolicies(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, policy := range policies {
+			resources = append(resources, completion.Resource{ID: policy.ID, Name: policy.Name})
+		}
+
+		return resources, nil
+	})
+}
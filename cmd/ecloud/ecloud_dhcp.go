@@ -34,7 +34,8 @@ func ecloudDHCPListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "DHCP name for filtering")
-	cmd.Flags().String("vpc", "", "VPC ID for filtering")
+	cmd.Flags().String("vpc", "", "VPC ID or name for filtering")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
 
 	return cmd
 }
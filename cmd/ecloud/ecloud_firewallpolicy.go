@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -41,7 +42,7 @@ func ecloudFirewallPolicyListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Firewall policy name for filtering")
-	cmd.Flags().String("router", "", "Firewall policy router ID for filtering")
+	cmd.Flags().String("router", "", "Firewall policy router ID or name for filtering")
 
 	return cmd
 }
@@ -76,7 +77,8 @@ func ecloudFirewallPolicyShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudFirewallPolicyShow),
+		ValidArgsFunction: ecloudFirewallPolicyCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudFirewallPolicyShow),
 	}
 }
 
@@ -105,7 +107,7 @@ func ecloudFirewallPolicyCreateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	// Setup flags
-	cmd.Flags().String("router", "", "ID of router")
+	cmd.Flags().String("router", "", "ID or name of router")
 	cmd.MarkFlagRequired("router")
 	cmd.Flags().Int("sequence", 0, "Sequence for policy")
 	cmd.MarkFlagRequired("sequence")
@@ -134,7 +136,7 @@ func ecloudFirewallPolicyCreate(service ecloud.ECloudService, cmd *cobra.Command
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for firewall policy task to complete: %s", err)
+			return fmt.Errorf("Error waiting for firewall policy task to complete: %w", err)
 		}
 	}
 
@@ -159,7 +161,8 @@ func ecloudFirewallPolicyUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudFirewallPolicyUpdate),
+		ValidArgsFunction: ecloudFirewallPolicyCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudFirewallPolicyUpdate),
 	}
 
 	cmd.Flags().String("name", "", "Name of policy")
@@ -217,7 +220,8 @@ func ecloudFirewallPolicyDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudFirewallPolicyDelete),
+		ValidArgsFunction: ecloudFirewallPolicyCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudFirewallPolicyDelete),
 	}
 
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the firewall policy has been completely removed")
@@ -226,6 +230,14 @@ func ecloudFirewallPolicyDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudFirewallPolicyDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following firewall policies will be removed", args, func(id string) (string, error) {
+		firewallPolicy, err := service.GetFirewallPolicy(id)
+		return firewallPolicy.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteFirewallPolicy(arg)
 		if err != nil {
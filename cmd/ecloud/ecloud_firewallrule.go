@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/batch"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
@@ -40,7 +42,8 @@ func ecloudFirewallRuleListCmd(f factory.ClientFactory) *cobra.Command {
 		RunE:    ecloudCobraRunEFunc(f, ecloudFirewallRuleList),
 	}
 
-	cmd.Flags().String("policy", "", "Firewall policy ID for filtering")
+	cmd.Flags().String("policy", "", "Firewall policy ID or name for filtering")
+	cmd.RegisterFlagCompletionFunc("policy", ecloudFirewallPolicyCompletionFunc(f))
 
 	return cmd
 }
@@ -99,13 +102,14 @@ func ecloudFirewallRuleCreateCmd(f factory.ClientFactory) *cobra.Command {
 		Use:     "create",
 		Short:   "Creates a firewall rule",
 		Long:    "This command creates a firewall rule",
-		Example: "ukfast ecloud firewallrule create --policy fwp-abcdef12",
+		Example: "ukfast ecloud firewallrule create --policy fwp-abcdef12\nukfast ecloud firewallrule create --policy fwp-abcdef12 --from-file rules.json --resume-file remaining.json",
 		RunE:    ecloudCobraRunEFunc(f, ecloudFirewallRuleCreate),
 	}
 
 	// Setup flags
-	cmd.Flags().String("policy", "", "ID of firewall policy")
+	cmd.Flags().String("policy", "", "ID or name of firewall policy")
 	cmd.MarkFlagRequired("policy")
+	cmd.RegisterFlagCompletionFunc("policy", ecloudFirewallPolicyCompletionFunc(f))
 	cmd.Flags().String("source", "", "Source of rule. IP range/subnet or ANY")
 	cmd.MarkFlagRequired("source")
 	cmd.Flags().String("destination", "", "Destination of rule. IP range/subnet or ANY")
@@ -119,6 +123,8 @@ func ecloudFirewallRuleCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().Bool("enabled", false, "Specifies whether rule is enabled")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the firewall rule has been completely created")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -159,7 +165,7 @@ func ecloudFirewallRuleCreate(service ecloud.ECloudService, cmd *cobra.Command,
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for firewall rule task to complete: %s", err)
+			return fmt.Errorf("Error waiting for firewall rule task to complete: %w", err)
 		}
 	}
 
@@ -196,6 +202,8 @@ func ecloudFirewallRuleUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().Bool("enabled", false, "Specifies whether rule is enabled")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the firewall rule has been completely updated")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -293,6 +301,14 @@ func ecloudFirewallRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudFirewallRuleDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following firewall rules will be removed", args, func(id string) (string, error) {
+		firewallRule, err := service.GetFirewallRule(id)
+		return firewallRule.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteFirewallRule(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -36,7 +37,7 @@ func ecloudFirewallRulePortListCmd(f factory.ClientFactory) *cobra.Command {
 		RunE:    ecloudCobraRunEFunc(f, ecloudFirewallRulePortList),
 	}
 
-	cmd.Flags().String("rule", "", "Firewall rule ID for filtering")
+	cmd.Flags().String("rule", "", "Firewall rule ID or name for filtering")
 
 	return cmd
 }
@@ -100,7 +101,7 @@ func ecloudFirewallRulePortCreateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	// Setup flags
-	cmd.Flags().String("rule", "", "ID of firewall rule")
+	cmd.Flags().String("rule", "", "ID or name of firewall rule")
 	cmd.MarkFlagRequired("rule")
 	cmd.Flags().String("source", "", "Source port. Single port, port range, or ANY")
 	cmd.Flags().String("destination", "", "Destination port. Single port, port range, or ANY")
@@ -138,7 +139,7 @@ func ecloudFirewallRulePortCreate(service ecloud.ECloudService, cmd *cobra.Comma
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for firewall rule port task to complete: %s", err)
+			return fmt.Errorf("Error waiting for firewall rule port task to complete: %w", err)
 		}
 	}
 
@@ -251,6 +252,14 @@ func ecloudFirewallRulePortDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudFirewallRulePortDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following firewall rule ports will be removed", args, func(id string) (string, error) {
+		firewallRulePort, err := service.GetFirewallRulePort(id)
+		return firewallRulePort.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteFirewallRulePort(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -96,8 +97,9 @@ func ecloudFloatingIPCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of floating IP")
-	cmd.Flags().String("vpc", "", "ID of VPC")
+	cmd.Flags().String("vpc", "", "ID or name of VPC")
 	cmd.MarkFlagRequired("vpc")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the floating IP has been completely created")
 
 	return cmd
@@ -119,7 +121,7 @@ func ecloudFloatingIPCreate(service ecloud.ECloudService, cmd *cobra.Command, ar
 	if waitFlag {
 		err := helper.WaitForCommand(FloatingIPResourceSyncStatusWaitFunc(service, fipID, ecloud.SyncStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for floating IP sync: %s", err)
+			return fmt.Errorf("Error waiting for floating IP sync: %w", err)
 		}
 	}
 
@@ -211,6 +213,14 @@ func ecloudFloatingIPDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudFloatingIPDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following floating IPs will be removed", args, func(id string) (string, error) {
+		fip, err := service.GetFloatingIP(id)
+		return fip.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteFloatingIP(arg)
 		if err != nil {
@@ -269,7 +279,7 @@ func ecloudFloatingIPAssign(service ecloud.ECloudService, cmd *cobra.Command, ar
 	if waitFlag {
 		err := helper.WaitForCommand(FloatingIPResourceSyncStatusWaitFunc(service, fipID, ecloud.SyncStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for floating IP sync: %s", err)
+			return fmt.Errorf("Error waiting for floating IP sync: %w", err)
 		}
 	}
 
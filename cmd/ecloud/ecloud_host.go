@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -100,7 +101,7 @@ func ecloudHostCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of host")
-	cmd.Flags().String("host-group", "", "ID of host group")
+	cmd.Flags().String("host-group", "", "ID or name of host group")
 	cmd.MarkFlagRequired("host-group")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the host has been completely created")
 
@@ -121,7 +122,7 @@ func ecloudHostCreate(service ecloud.ECloudService, cmd *cobra.Command, args []s
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for host task to complete: %s", err)
+			return fmt.Errorf("Error waiting for host task to complete: %w", err)
 		}
 	}
 
@@ -213,6 +214,14 @@ func ecloudHostDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudHostDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following hosts will be removed", args, func(id string) (string, error) {
+		host, err := service.GetHost(id)
+		return host.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteHost(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -100,10 +101,11 @@ func ecloudHostGroupCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of host group")
-	cmd.Flags().String("vpc", "", "ID of VPC")
+	cmd.Flags().String("vpc", "", "ID or name of VPC")
 	cmd.MarkFlagRequired("vpc")
-	cmd.Flags().String("availability-zone", "", "ID of availability zone")
-	cmd.Flags().String("host-spec", "", "ID of host specification")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
+	cmd.Flags().String("availability-zone", "", "ID or name of availability zone")
+	cmd.Flags().String("host-spec", "", "ID or name of host specification")
 	cmd.MarkFlagRequired("host-spec")
 	cmd.Flags().Bool("windows-enabled", false, "Specifies Windows OS should be enabled for instances")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the host group has been completely created")
@@ -128,7 +130,7 @@ func ecloudHostGroupCreate(service ecloud.ECloudService, cmd *cobra.Command, arg
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for host group task to complete: %s", err)
+			return fmt.Errorf("Error waiting for host group task to complete: %w", err)
 		}
 	}
 
@@ -220,6 +222,14 @@ func ecloudHostGroupDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudHostGroupDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following host groups will be removed", args, func(id string) (string, error) {
+		hostGroup, err := service.GetHostGroup(id)
+		return hostGroup.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteHostGroup(arg)
 		if err != nil {
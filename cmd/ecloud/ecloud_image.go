@@ -65,7 +65,8 @@ func ecloudImageShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudImageShow),
+		ValidArgsFunction: ecloudImageCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudImageShow),
 	}
 }
 
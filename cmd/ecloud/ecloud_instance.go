@@ -3,13 +3,12 @@ package ecloud
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
-	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
 
@@ -70,7 +69,7 @@ func ecloudInstanceList(service ecloud.ECloudService, cmd *cobra.Command, args [
 }
 
 func ecloudInstanceShowCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "show <instance: id>...",
 		Short:   "Shows a instance",
 		Long:    "This command shows one or more instances",
@@ -82,20 +81,28 @@ func ecloudInstanceShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceShow),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceShow),
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func ecloudInstanceShow(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
 	var instances []ecloud.Instance
-	for _, arg := range args {
+	results := helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		instance, err := service.GetInstance(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving instance [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving instance [%s]: %s", arg, err)
 		}
 
-		instances = append(instances, instance)
+		return instance, nil
+	})
+
+	for _, result := range results {
+		instances = append(instances, result.(ecloud.Instance))
 	}
 
 	return output.CommandOutput(cmd, OutputECloudInstancesProvider(instances))
@@ -112,8 +119,9 @@ func ecloudInstanceCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of instance")
-	cmd.Flags().String("vpc", "", "ID of VPC")
+	cmd.Flags().String("vpc", "", "ID or name of VPC")
 	cmd.MarkFlagRequired("vpc")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
 	cmd.Flags().Int("vcpu", 0, "Number of vCPU cores to allocate")
 	cmd.MarkFlagRequired("vcpu")
 	cmd.Flags().Int("ram", 0, "Amount of RAM (in MB) to allocate")
@@ -122,7 +130,8 @@ func ecloudInstanceCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.MarkFlagRequired("volume")
 	cmd.Flags().String("image", "", "ID or name of image to deploy from")
 	cmd.MarkFlagRequired("image")
-	cmd.Flags().StringSlice("ssh-key-pair", []string{}, "ID of SSH key pair, can be repeated")
+	cmd.RegisterFlagCompletionFunc("image", ecloudImageCompletionFunc(f))
+	cmd.Flags().StringSlice("ssh-key-pair", []string{}, "ID or name of SSH key pair, can be repeated")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the instance has been completely created")
 
 	return cmd
@@ -142,32 +151,12 @@ func ecloudInstanceCreate(service ecloud.ECloudService, cmd *cobra.Command, args
 		createRequest.SSHKeyPairIDs, _ = cmd.Flags().GetStringSlice("ssh-key-pair")
 	}
 
-	getImage := func(imageName string) (string, error) {
-		images, err := service.GetImages(connection.APIRequestParameters{})
-		if err != nil {
-			return "", fmt.Errorf("Error retrieving images: %s", err)
-		}
-
-		// Server-side filtering currently not possible, iterate slice for now
-		for _, image := range images {
-			if strings.ToLower(image.Name) == strings.ToLower(imageName) {
-				return image.ID, nil
-			}
-		}
-		return "", fmt.Errorf("Image not found with name '%s'", imageName)
-	}
-
 	imageFlag, _ := cmd.Flags().GetString("image")
-
-	if strings.HasPrefix(imageFlag, "img-") {
-		createRequest.ImageID = imageFlag
-	} else {
-		var err error
-		createRequest.ImageID, err = getImage(imageFlag)
-		if err != nil {
-			return err
-		}
+	imageID, err := ecloudImageLocator(service).LocateID(imageFlag)
+	if err != nil {
+		return err
 	}
+	createRequest.ImageID = imageID
 
 	instanceID, err := service.CreateInstance(createRequest)
 	if err != nil {
@@ -178,7 +167,7 @@ func ecloudInstanceCreate(service ecloud.ECloudService, cmd *cobra.Command, args
 	if waitFlag {
 		err := helper.WaitForCommand(InstanceResourceSyncStatusWaitFunc(service, instanceID, ecloud.SyncStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for instance sync: %s", err)
+			return fmt.Errorf("Error waiting for instance sync: %w", err)
 		}
 	}
 
@@ -203,13 +192,15 @@ func ecloudInstanceUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceUpdate),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceUpdate),
 	}
 
 	cmd.Flags().String("name", "", "Name of instance")
 	cmd.Flags().Int("vcpu", 0, "Number of vCPU cores to allocate")
 	cmd.Flags().Int("ram", 0, "Amount of RAM (in MB) to allocate")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the instance has been completely updated")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -232,29 +223,30 @@ func ecloudInstanceUpdate(service ecloud.ECloudService, cmd *cobra.Command, args
 	}
 
 	var instances []ecloud.Instance
-	for _, arg := range args {
+	results := helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		err := service.PatchInstance(arg, patchRequest)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error updating instance [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error updating instance [%s]: %s", arg, err)
 		}
 
 		waitFlag, _ := cmd.Flags().GetBool("wait")
 		if waitFlag {
 			err := helper.WaitForCommand(InstanceResourceSyncStatusWaitFunc(service, arg, ecloud.SyncStatusComplete))
 			if err != nil {
-				output.OutputWithErrorLevelf("Error waiting for instance [%s] sync: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error waiting for instance [%s] sync: %s", arg, err)
 			}
 		}
 
 		instance, err := service.GetInstance(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving updated instance [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving updated instance [%s]: %s", arg, err)
 		}
 
-		instances = append(instances, instance)
+		return instance, nil
+	})
+
+	for _, result := range results {
+		instances = append(instances, result.(ecloud.Instance))
 	}
 
 	return output.CommandOutput(cmd, OutputECloudInstancesProvider(instances))
@@ -273,36 +265,46 @@ func ecloudInstanceDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceDelete),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceDelete),
 	}
 
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the instance has been completely removed")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
 
 func ecloudInstanceDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
-	for _, arg := range args {
+	err := input.CommandConfirmResources(cmd, "The following instances will be removed", args, func(id string) (string, error) {
+		instance, err := service.GetInstance(id)
+		return instance.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		err := service.DeleteInstance(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error removing instance [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error removing instance [%s]: %s", arg, err)
 		}
 
 		waitFlag, _ := cmd.Flags().GetBool("wait")
 		if waitFlag {
 			err := helper.WaitForCommand(InstanceNotFoundWaitFunc(service, arg))
 			if err != nil {
-				output.OutputWithErrorLevelf("Error waiting for removal of instance [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error waiting for removal of instance [%s]: %s", arg, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
 	return nil
 }
 
 func ecloudInstanceLockCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "lock <instance: id>...",
 		Short:   "Locks an instance",
 		Long:    "This command locks one or more instances",
@@ -314,22 +316,29 @@ func ecloudInstanceLockCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceLock),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceLock),
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func ecloudInstanceLock(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		err := service.LockInstance(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error locking instance [%s]: %s", arg, err)
+			return nil, output.NewCommandErrorf("Error locking instance [%s]: %s", arg, err)
 		}
-	}
+
+		return nil, nil
+	})
 	return nil
 }
 
 func ecloudInstanceUnlockCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "unlock <instance: id>...",
 		Short:   "Unlocks an instance",
 		Long:    "This command unlocks one or more instances",
@@ -341,17 +350,24 @@ func ecloudInstanceUnlockCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceUnlock),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceUnlock),
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func ecloudInstanceUnlock(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		err := service.UnlockInstance(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error unlocking instance [%s]: %s", arg, err)
+			return nil, output.NewCommandErrorf("Error unlocking instance [%s]: %s", arg, err)
 		}
-	}
+
+		return nil, nil
+	})
 	return nil
 }
 
@@ -368,31 +384,33 @@ func ecloudInstanceStartCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceStart),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceStart),
 	}
 
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the instance power on task has been completed")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
 
 func ecloudInstanceStart(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		taskID, err := service.PowerOnInstance(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error starting instance [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error starting instance [%s]: %s", arg, err)
 		}
 
 		waitFlag, _ := cmd.Flags().GetBool("wait")
 		if waitFlag {
 			err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskID, ecloud.TaskStatusComplete))
 			if err != nil {
-				output.OutputWithErrorLevelf("Error waiting for task to complete for instance [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error waiting for task to complete for instance [%s]: %s", arg, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
 	return nil
 }
 
@@ -409,11 +427,13 @@ func ecloudInstanceStopCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceStop),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceStop),
 	}
 
 	cmd.Flags().Bool("force", false, "Specifies that instance should be forcefully powered off")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the instance power off task has been completed")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -421,20 +441,18 @@ func ecloudInstanceStopCmd(f factory.ClientFactory) *cobra.Command {
 func ecloudInstanceStop(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		var taskID string
 		var err error
 		if force {
 			taskID, err = service.PowerOffInstance(arg)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error stopping instance [%s] (forced): %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error stopping instance [%s] (forced): %s", arg, err)
 			}
 		} else {
 			taskID, err = service.PowerShutdownInstance(arg)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error stopping instance [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error stopping instance [%s]: %s", arg, err)
 			}
 		}
 
@@ -442,11 +460,12 @@ func ecloudInstanceStop(service ecloud.ECloudService, cmd *cobra.Command, args [
 		if waitFlag {
 			err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskID, ecloud.TaskStatusComplete))
 			if err != nil {
-				output.OutputWithErrorLevelf("Error waiting for task to complete for instance [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error waiting for task to complete for instance [%s]: %s", arg, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
 	return nil
 }
 
@@ -463,11 +482,13 @@ func ecloudInstanceRestartCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudInstanceRestart),
+		ValidArgsFunction: ecloudInstanceCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudInstanceRestart),
 	}
 
 	cmd.Flags().Bool("force", false, "Specifies that instance should be forcefully reset")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the instance restart task has been completed")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -475,20 +496,18 @@ func ecloudInstanceRestartCmd(f factory.ClientFactory) *cobra.Command {
 func ecloudInstanceRestart(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		var taskID string
 		var err error
 		if force {
 			taskID, err = service.PowerResetInstance(arg)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error restarting instance [%s] (forced): %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error restarting instance [%s] (forced): %s", arg, err)
 			}
 		} else {
 			taskID, err = service.PowerRestartInstance(arg)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error restarting instance [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error restarting instance [%s]: %s", arg, err)
 			}
 		}
 
@@ -496,11 +515,12 @@ func ecloudInstanceRestart(service ecloud.ECloudService, cmd *cobra.Command, arg
 		if waitFlag {
 			err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskID, ecloud.TaskStatusComplete))
 			if err != nil {
-				output.OutputWithErrorLevelf("Error waiting for task to complete for instance [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error waiting for task to complete for instance [%s]: %s", arg, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
 	return nil
 }
 
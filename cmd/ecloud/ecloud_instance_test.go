@@ -2,12 +2,17 @@ package ecloud
 
 import (
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/internal/pkg/input"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/cli/test"
 	"github.com/ukfast/cli/test/mocks"
 	"github.com/ukfast/cli/test/test_output"
 	"github.com/ukfast/sdk-go/pkg/connection"
@@ -107,6 +112,37 @@ func Test_ecloudInstanceShow(t *testing.T) {
 			ecloudInstanceShow(service, &cobra.Command{}, []string{"i-abcdef12"})
 		})
 	})
+
+	t.Run("NamedInstancesGetInstanceErrorForOne_ExitsWithPartialFailure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := findECloudCmd(t, "instance", "show")
+		code := 0
+		oldOutputExit := output.SetOutputExit(func(c int) { code = c })
+		defer output.SetOutputExit(oldOutputExit)
+		output.SetErrorLevel(0)
+		defer output.SetErrorLevel(0)
+
+		gomock.InOrder(
+			service.EXPECT().GetInstances(nameFilter("web-01")).Return([]ecloud.Instance{{ID: "i-abcdef56"}}, nil),
+			service.EXPECT().GetInstances(nameFilter("web-02")).Return([]ecloud.Instance{{ID: "i-abcdef78"}}, nil),
+			service.EXPECT().GetInstance("i-abcdef56").Return(ecloud.Instance{}, nil),
+			service.EXPECT().GetInstance("i-abcdef78").Return(ecloud.Instance{}, &ecloud.InstanceNotFoundError{ID: "i-abcdef78"}),
+		)
+
+		args := []string{"web-01", "web-02"}
+		resolved, err := ecloudResolver(service).Resolve(cmd, args)
+		assert.Nil(t, err)
+
+		test.CatchStdOutStdErr(t, func() {
+			ecloudInstanceShow(service, cmd, resolved)
+		})
+		output.ExitWithErrorLevel(args...)
+
+		assert.Equal(t, clierrors.ExitCodePartialFailure, code)
+	})
 }
 
 func Test_ecloudInstanceCreate(t *testing.T) {
@@ -163,7 +199,22 @@ func Test_ecloudInstanceCreate(t *testing.T) {
 
 		err := ecloudInstanceCreate(service, cmd, []string{})
 		assert.NotNil(t, err)
-		assert.Equal(t, "Image not found with name 'unknown'", err.Error())
+		assert.Equal(t, "Error locating image [unknown]: No items found matching [unknown]", err.Error())
+	})
+
+	t.Run("AmbiguousImageName_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceCreateCmd(nil)
+		cmd.ParseFlags([]string{"--name=testinstance", "--image=test"})
+
+		service.EXPECT().GetImages(connection.APIRequestParameters{}).Return([]ecloud.Image{{Name: "test", ID: "img-abcdef12"}, {Name: "TEST", ID: "img-abcdef34"}}, nil)
+
+		err := ecloudInstanceCreate(service, cmd, []string{})
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error locating image [test]: More than one item found matching [test] (name), candidates: img-abcdef12, img-abcdef34", err.Error())
 	})
 
 	t.Run("CreateInstanceError_ReturnsError", func(t *testing.T) {
@@ -338,6 +389,26 @@ func Test_ecloudInstanceDelete(t *testing.T) {
 			ecloudInstanceDelete(service, &cobra.Command{}, []string{"i-abcdef12"})
 		})
 	})
+
+	t.Run("ConfirmationDeclined_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		oldIsTerminal, oldReader := input.IsTerminal, input.InputReader
+		defer func() { input.IsTerminal, input.InputReader = oldIsTerminal, oldReader }()
+		input.IsTerminal = func() bool { return true }
+		input.InputReader = func() io.Reader { return strings.NewReader("n\n") }
+
+		service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{Name: "testinstance"}, nil).Times(1)
+
+		test_output.AssertErrorOutput(t, "The following instances will be removed:\n  - i-abcdef12 (testinstance)\nContinue? [y/N]: ", func() {
+			err := ecloudInstanceDelete(service, &cobra.Command{}, []string{"i-abcdef12"})
+
+			assert.Equal(t, input.ErrConfirmationDeclined, err)
+		})
+	})
 }
 
 func Test_ecloudInstanceLockCmd_Args(t *testing.T) {
@@ -76,7 +76,7 @@ func ecloudInstanceVolumeAttachCmd(f factory.ClientFactory) *cobra.Command {
 		RunE: ecloudCobraRunEFunc(f, ecloudInstanceVolumeAttach),
 	}
 
-	cmd.Flags().String("volume", "", "ID of volume to attach")
+	cmd.Flags().String("volume", "", "ID or name of volume to attach")
 	cmd.MarkFlagRequired("volume")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until volume has been attached")
 
@@ -96,7 +96,7 @@ func ecloudInstanceVolumeAttach(service ecloud.ECloudService, cmd *cobra.Command
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for task: %s", err)
+			return fmt.Errorf("Error waiting for task: %w", err)
 		}
 	}
 
@@ -119,7 +119,7 @@ func ecloudInstanceVolumeDetachCmd(f factory.ClientFactory) *cobra.Command {
 		RunE: ecloudCobraRunEFunc(f, ecloudInstanceVolumeDetach),
 	}
 
-	cmd.Flags().String("volume", "", "ID of volume to detach")
+	cmd.Flags().String("volume", "", "ID or name of volume to detach")
 	cmd.MarkFlagRequired("volume")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until volume has been detached")
 
@@ -139,7 +139,7 @@ func ecloudInstanceVolumeDetach(service ecloud.ECloudService, cmd *cobra.Command
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for task: %s", err)
+			return fmt.Errorf("Error waiting for task: %w", err)
 		}
 	}
 
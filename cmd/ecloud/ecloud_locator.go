@@ -0,0 +1,186 @@
+package ecloud
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ukfast/cli/internal/pkg/resource"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ecloud"
+)
+
+type ecloudListFunc func(parameters connection.APIRequestParameters) (interface{}, error)
+
+// ecloudLocator returns a Locator for resources with IDs prefixed with prefix, e.g. 'i' for
+// instances, locating resources by name via list
+func ecloudLocator(name string, prefix string, list ecloudListFunc) *resource.Locator {
+	return &resource.Locator{
+		Name:     name,
+		IsID:     ecloudIDFunc(prefix),
+		Provider: resource.NewFilterLocatorProvider(list, "name"),
+	}
+}
+
+// ecloudIDFunc returns a func which returns true for eCloud IDs with given prefix, e.g.
+// 'i-abcdef12'
+func ecloudIDFunc(prefix string) func(value string) bool {
+	r := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + "-[0-9a-f]{8}$")
+	return r.MatchString
+}
+
+// ECloudImageLocatorProvider locates images by name. Images can't be filtered by name server-side,
+// so are matched case-insensitively
+type ECloudImageLocatorProvider struct {
+	service ecloud.ECloudService
+}
+
+func NewECloudImageLocatorProvider(service ecloud.ECloudService) *ECloudImageLocatorProvider {
+	return &ECloudImageLocatorProvider{service: service}
+}
+
+func (p *ECloudImageLocatorProvider) SupportedProperties() []string {
+	return []string{"name"}
+}
+
+func (p *ECloudImageLocatorProvider) Locate(property string, value string) (interface{}, error) {
+	images, err := p.service.GetImages(connection.APIRequestParameters{})
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []ecloud.Image
+	for _, image := range images {
+		if strings.EqualFold(image.Name, value) {
+			matched = append(matched, image)
+		}
+	}
+
+	return matched, nil
+}
+
+func ecloudImageLocator(service ecloud.ECloudService) *resource.Locator {
+	return &resource.Locator{
+		Name:     "image",
+		IsID:     ecloudIDFunc("img"),
+		Provider: NewECloudImageLocatorProvider(service),
+	}
+}
+
+// ecloudResolver returns a Resolver which locates eCloud resources named within the arguments and
+// flags of commands, for each resource which has a name
+func ecloudResolver(service ecloud.ECloudService) *resource.Resolver {
+	availabilityZone := ecloudLocator("availability zone", "az", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetAvailabilityZones(p)
+	})
+	firewallPolicy := ecloudLocator("firewall policy", "fwp", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetFirewallPolicies(p)
+	})
+	firewallRule := ecloudLocator("firewall rule", "fwr", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetFirewallRules(p)
+	})
+	firewallRulePort := ecloudLocator("firewall rule port", "fwrp", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetFirewallRulePorts(p)
+	})
+	floatingIP := ecloudLocator("floating IP", "fip", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetFloatingIPs(p)
+	})
+	host := ecloudLocator("host", "h", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetHosts(p)
+	})
+	hostGroup := ecloudLocator("host group", "hg", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetHostGroups(p)
+	})
+	hostSpec := ecloudLocator("host spec", "hs", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetHostSpecs(p)
+	})
+	image := ecloudImageLocator(service)
+	instance := ecloudLocator("instance", "i", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetInstances(p)
+	})
+	network := ecloudLocator("network", "net", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetNetworks(p)
+	})
+	networkPolicy := ecloudLocator("network policy", "np", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetNetworkPolicies(p)
+	})
+	networkRule := ecloudLocator("network rule", "nr", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetNetworkRules(p)
+	})
+	networkRulePort := ecloudLocator("network rule port", "nrp", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetNetworkRulePorts(p)
+	})
+	region := ecloudLocator("region", "reg", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetRegions(p)
+	})
+	router := ecloudLocator("router", "rtr", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetRouters(p)
+	})
+	routerThroughput := ecloudLocator("router throughput", "rtp", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetRouterThroughputs(p)
+	})
+	sshKeyPair := ecloudLocator("SSH key pair", "ssh", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetSSHKeyPairs(p)
+	})
+	volume := ecloudLocator("volume", "vol", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetVolumes(p)
+	})
+	vpc := ecloudLocator("VPC", "vpc", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetVPCs(p)
+	})
+
+	return &resource.Resolver{
+		Root: "ecloud",
+		Commands: map[string]*resource.CommandLocators{
+			"availabilityzone": {Args: []resource.ArgLocatorFunc{resource.ArgLocator(availabilityZone)}},
+			"dhcp":             {},
+			"firewallpolicy":   {Args: []resource.ArgLocatorFunc{resource.ArgLocator(firewallPolicy)}},
+			"firewallrule": {
+				Args:  []resource.ArgLocatorFunc{resource.ArgLocator(firewallRule)},
+				Flags: map[string]*resource.Locator{"policy": firewallPolicy},
+			},
+			"firewallruleport": {
+				Args:  []resource.ArgLocatorFunc{resource.ArgLocator(firewallRulePort)},
+				Flags: map[string]*resource.Locator{"rule": firewallRule},
+			},
+			"floatingip": {Args: []resource.ArgLocatorFunc{resource.ArgLocator(floatingIP)}},
+			"host":       {Args: []resource.ArgLocatorFunc{resource.ArgLocator(host)}},
+			"hostgroup":  {Args: []resource.ArgLocatorFunc{resource.ArgLocator(hostGroup)}},
+			"hostspec":   {Args: []resource.ArgLocatorFunc{resource.ArgLocator(hostSpec)}},
+			"image":      {Args: []resource.ArgLocatorFunc{resource.ArgLocator(image)}},
+			"instance": {
+				Args:  []resource.ArgLocatorFunc{resource.ArgLocator(instance)},
+				Flags: map[string]*resource.Locator{"volume": volume},
+			},
+			"network":       {Args: []resource.ArgLocatorFunc{resource.ArgLocator(network)}},
+			"networkpolicy": {Args: []resource.ArgLocatorFunc{resource.ArgLocator(networkPolicy)}},
+			"networkrule": {
+				Args:  []resource.ArgLocatorFunc{resource.ArgLocator(networkRule)},
+				Flags: map[string]*resource.Locator{"policy": networkPolicy},
+			},
+			"networkruleport": {
+				Args:  []resource.ArgLocatorFunc{resource.ArgLocator(networkRulePort)},
+				Flags: map[string]*resource.Locator{"rule": networkRule},
+			},
+			"nic":              {},
+			"region":           {Args: []resource.ArgLocatorFunc{resource.ArgLocator(region)}},
+			"router":           {Args: []resource.ArgLocatorFunc{resource.ArgLocator(router)}},
+			"routerthroughput": {Args: []resource.ArgLocatorFunc{resource.ArgLocator(routerThroughput)}},
+			"sshkeypair":       {Args: []resource.ArgLocatorFunc{resource.ArgLocator(sshKeyPair)}},
+			"task":             {},
+			"volume":           {Args: []resource.ArgLocatorFunc{resource.ArgLocator(volume)}},
+			"vpc":              {Args: []resource.ArgLocatorFunc{resource.ArgLocator(vpc)}},
+		},
+		Flags: map[string]*resource.Locator{
+			"availability-zone": availabilityZone,
+			"az":                availabilityZone,
+			"host-group":        hostGroup,
+			"host-spec":         hostSpec,
+			"network":           network,
+			"region":            region,
+			"router":            router,
+			"ssh-key-pair":      sshKeyPair,
+			"throughput":        routerThroughput,
+			"vpc":               vpc,
+		},
+	}
+}
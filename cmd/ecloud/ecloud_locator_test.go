@@ -0,0 +1,113 @@
+package ecloud
+
+import (
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ecloud"
+)
+
+// findECloudCmd returns the eCloud command at path, e.g. 'instance stop'
+func findECloudCmd(t *testing.T, path ...string) *cobra.Command {
+	cmd, _, err := ECloudRootCmd(nil, afero.NewMemMapFs()).Find(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cmd
+}
+
+func nameFilter(name string) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{name}})
+	return params
+}
+
+func Test_ecloudResolver(t *testing.T) {
+	t.Run("InstanceArgs_LocatesNames", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := findECloudCmd(t, "instance", "stop")
+
+		service.EXPECT().GetInstances(nameFilter("web-01")).Return([]ecloud.Instance{{ID: "i-abcdef12", Name: "web-01"}}, nil)
+
+		args, err := ecloudResolver(service).Resolve(cmd, []string{"web-01", "i-abcdef34"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"i-abcdef12", "i-abcdef34"}, args)
+	})
+
+	t.Run("SubResourceArgs_LocatesParent", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := findECloudCmd(t, "instance", "volume", "list")
+
+		service.EXPECT().GetInstances(nameFilter("web-01")).Return([]ecloud.Instance{{ID: "i-abcdef12"}}, nil)
+
+		args, err := ecloudResolver(service).Resolve(cmd, []string{"web-01"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"i-abcdef12"}, args)
+	})
+
+	t.Run("Flags_LocatesNames", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := findECloudCmd(t, "instance", "create")
+		cmd.ParseFlags([]string{"--vpc=prod", "--ssh-key-pair=ssh-abcdef12", "--ssh-key-pair=deploy", "--volume=20"})
+
+		service.EXPECT().GetVPCs(nameFilter("prod")).Return([]ecloud.VPC{{ID: "vpc-abcdef12"}}, nil)
+		service.EXPECT().GetSSHKeyPairs(nameFilter("deploy")).Return([]ecloud.SSHKeyPair{{ID: "ssh-abcdef34"}}, nil)
+
+		_, err := ecloudResolver(service).Resolve(cmd, []string{})
+
+		assert.Nil(t, err)
+		vpc, _ := cmd.Flags().GetString("vpc")
+		sshKeyPairs, _ := cmd.Flags().GetStringSlice("ssh-key-pair")
+		assert.Equal(t, "vpc-abcdef12", vpc)
+		assert.Equal(t, []string{"ssh-abcdef12", "ssh-abcdef34"}, sshKeyPairs)
+	})
+
+	t.Run("PolicyFlag_LocatesResourceForCommand", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := findECloudCmd(t, "networkrule", "create")
+		cmd.ParseFlags([]string{"--policy=default"})
+
+		service.EXPECT().GetNetworkPolicies(nameFilter("default")).Return([]ecloud.NetworkPolicy{{ID: "np-abcdef12"}}, nil)
+
+		_, err := ecloudResolver(service).Resolve(cmd, []string{})
+
+		assert.Nil(t, err)
+		policy, _ := cmd.Flags().GetString("policy")
+		assert.Equal(t, "np-abcdef12", policy)
+	})
+
+	t.Run("AmbiguousName_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := findECloudCmd(t, "vpc", "show")
+
+		service.EXPECT().GetVPCs(nameFilter("prod")).Return([]ecloud.VPC{{ID: "vpc-abcdef12"}, {ID: "vpc-abcdef34"}}, nil)
+
+		_, err := ecloudResolver(service).Resolve(cmd, []string{"prod"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error locating VPC [prod]: More than one item found matching [prod] (name), candidates: vpc-abcdef12, vpc-abcdef34", err.Error())
+	})
+}
@@ -0,0 +1,579 @@
+package ecloud
+
+import (
+	"fmt"
+
+	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ecloud"
+)
+
+// ManifestHandlers returns manifest handlers for eCloud resources, keyed by kind
+func ManifestHandlers(service ecloud.ECloudService) map[string]manifest.Handler {
+	return map[string]manifest.Handler{
+		"ecloud/vpc":                &vpcManifestHandler{service: service},
+		"ecloud/router":             &routerManifestHandler{service: service},
+		"ecloud/network":            &networkManifestHandler{service: service},
+		"ecloud/instance":           &instanceManifestHandler{service: service},
+		"ecloud/firewall-policy":    &firewallPolicyManifestHandler{service: service},
+		"ecloud/firewall-rule":      &firewallRuleManifestHandler{service: service},
+		"ecloud/firewall-rule-port": &firewallRulePortManifestHandler{service: service},
+	}
+}
+
+// getManifestFindParameters returns parameters filtering by the name of the resource, and by
+// parent field if specified
+func getManifestFindParameters(spec manifest.Spec, parentField string) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{spec.String("name")}})
+	if parentField != "" && spec.String(parentField) != "" {
+		params.WithFilter(connection.APIRequestFiltering{Property: parentField, Operator: connection.EQOperator, Value: []string{spec.String(parentField)}})
+	}
+
+	return params
+}
+
+func ambiguousManifestResourceError(count int, spec manifest.Spec) error {
+	return fmt.Errorf("%d resources found with name [%s]", count, spec.String("name"))
+}
+
+type vpcManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *vpcManifestHandler) References() map[string]string {
+	return nil
+}
+
+func (h *vpcManifestHandler) UpdatableFields() []string {
+	return nil
+}
+
+func (h *vpcManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	vpcs, err := h.service.GetVPCs(getManifestFindParameters(spec, ""))
+	if err != nil || len(vpcs) < 1 {
+		return nil, nil, err
+	}
+	if len(vpcs) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(vpcs), spec)
+	}
+
+	return vpcs[0].ID, vpcs[0], nil
+}
+
+func (h *vpcManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateVPCRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcID, err := h.service.CreateVPC(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return vpcID, helper.WaitForCommand(VPCResourceSyncStatusWaitFunc(h.service, vpcID, ecloud.SyncStatusComplete))
+}
+
+func (h *vpcManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	return fmt.Errorf("VPCs cannot be updated")
+}
+
+func (h *vpcManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	err := h.service.DeleteVPC(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(VPCNotFoundWaitFunc(h.service, id.(string)))
+}
+
+type routerManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *routerManifestHandler) References() map[string]string {
+	return map[string]string{"vpc_id": "ecloud/vpc"}
+}
+
+func (h *routerManifestHandler) UpdatableFields() []string {
+	return []string{"router_throughput_id"}
+}
+
+func (h *routerManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	routers, err := h.service.GetRouters(getManifestFindParameters(spec, "vpc_id"))
+	if err != nil || len(routers) < 1 {
+		return nil, nil, err
+	}
+	if len(routers) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(routers), spec)
+	}
+
+	return routers[0].ID, routers[0], nil
+}
+
+func (h *routerManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateRouterRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	routerID, err := h.service.CreateRouter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return routerID, helper.WaitForCommand(RouterResourceSyncStatusWaitFunc(h.service, routerID, ecloud.SyncStatusComplete))
+}
+
+func (h *routerManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ecloud.PatchRouterRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.PatchRouter(id.(string), patch)
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(RouterResourceSyncStatusWaitFunc(h.service, id.(string), ecloud.SyncStatusComplete))
+}
+
+func (h *routerManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	err := h.service.DeleteRouter(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(RouterNotFoundWaitFunc(h.service, id.(string)))
+}
+
+type networkManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *networkManifestHandler) References() map[string]string {
+	return map[string]string{"router_id": "ecloud/router"}
+}
+
+func (h *networkManifestHandler) UpdatableFields() []string {
+	return nil
+}
+
+func (h *networkManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	networks, err := h.service.GetNetworks(getManifestFindParameters(spec, "router_id"))
+	if err != nil || len(networks) < 1 {
+		return nil, nil, err
+	}
+	if len(networks) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(networks), spec)
+	}
+
+	return networks[0].ID, networks[0], nil
+}
+
+func (h *networkManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateNetworkRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	networkID, err := h.service.CreateNetwork(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkID, helper.WaitForCommand(NetworkResourceSyncStatusWaitFunc(h.service, networkID, ecloud.SyncStatusComplete))
+}
+
+func (h *networkManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	return fmt.Errorf("networks cannot be updated")
+}
+
+func (h *networkManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	err := h.service.DeleteNetwork(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(NetworkNotFoundWaitFunc(h.service, id.(string)))
+}
+
+type instanceManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *instanceManifestHandler) References() map[string]string {
+	return map[string]string{"vpc_id": "ecloud/vpc", "network_id": "ecloud/network"}
+}
+
+func (h *instanceManifestHandler) UpdatableFields() []string {
+	return []string{"vcpu_cores", "ram_capacity"}
+}
+
+func (h *instanceManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	instances, err := h.service.GetInstances(getManifestFindParameters(spec, "vpc_id"))
+	if err != nil || len(instances) < 1 {
+		return nil, nil, err
+	}
+	if len(instances) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(instances), spec)
+	}
+
+	return instances[0].ID, instances[0], nil
+}
+
+func (h *instanceManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateInstanceRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceID, err := h.service.CreateInstance(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceID, helper.WaitForCommand(InstanceResourceSyncStatusWaitFunc(h.service, instanceID, ecloud.SyncStatusComplete))
+}
+
+func (h *instanceManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ecloud.PatchInstanceRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.PatchInstance(id.(string), patch)
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(InstanceResourceSyncStatusWaitFunc(h.service, id.(string), ecloud.SyncStatusComplete))
+}
+
+func (h *instanceManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	err := h.service.DeleteInstance(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(InstanceNotFoundWaitFunc(h.service, id.(string)))
+}
+
+type firewallPolicyManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *firewallPolicyManifestHandler) References() map[string]string {
+	return map[string]string{"router_id": "ecloud/router"}
+}
+
+func (h *firewallPolicyManifestHandler) UpdatableFields() []string {
+	return []string{"sequence"}
+}
+
+func (h *firewallPolicyManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	policies, err := h.service.GetFirewallPolicies(getManifestFindParameters(spec, "router_id"))
+	if err != nil || len(policies) < 1 {
+		return nil, nil, err
+	}
+	if len(policies) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(policies), spec)
+	}
+
+	return policies[0].ID, policies[0], nil
+}
+
+func (h *firewallPolicyManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateFirewallPolicyRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	taskRef, err := h.service.CreateFirewallPolicy(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskRef.ResourceID, helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskRef.TaskID, ecloud.TaskStatusComplete))
+}
+
+func (h *firewallPolicyManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ecloud.PatchFirewallPolicyRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	taskRef, err := h.service.PatchFirewallPolicy(id.(string), patch)
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskRef.TaskID, ecloud.TaskStatusComplete))
+}
+
+func (h *firewallPolicyManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	taskID, err := h.service.DeleteFirewallPolicy(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskID, ecloud.TaskStatusComplete))
+}
+
+type firewallRuleManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *firewallRuleManifestHandler) References() map[string]string {
+	return map[string]string{"firewall_policy_id": "ecloud/firewall-policy"}
+}
+
+func (h *firewallRuleManifestHandler) UpdatableFields() []string {
+	return []string{"sequence", "source", "destination", "action", "direction", "enabled"}
+}
+
+func (h *firewallRuleManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	rules, err := h.service.GetFirewallRules(getManifestFindParameters(spec, "firewall_policy_id"))
+	if err != nil || len(rules) < 1 {
+		return nil, nil, err
+	}
+	if len(rules) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(rules), spec)
+	}
+
+	return rules[0].ID, rules[0], nil
+}
+
+func (h *firewallRuleManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateFirewallRuleRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	taskRef, err := h.service.CreateFirewallRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskRef.ResourceID, helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskRef.TaskID, ecloud.TaskStatusComplete))
+}
+
+func (h *firewallRuleManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ecloud.PatchFirewallRuleRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	taskRef, err := h.service.PatchFirewallRule(id.(string), patch)
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskRef.TaskID, ecloud.TaskStatusComplete))
+}
+
+func (h *firewallRuleManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	taskID, err := h.service.DeleteFirewallRule(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskID, ecloud.TaskStatusComplete))
+}
+
+type firewallRulePortManifestHandler struct {
+	service ecloud.ECloudService
+}
+
+func (h *firewallRulePortManifestHandler) References() map[string]string {
+	return map[string]string{"firewall_rule_id": "ecloud/firewall-rule"}
+}
+
+func (h *firewallRulePortManifestHandler) UpdatableFields() []string {
+	return []string{"protocol", "source", "destination"}
+}
+
+func (h *firewallRulePortManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	ports, err := h.service.GetFirewallRulePorts(getManifestFindParameters(spec, "firewall_rule_id"))
+	if err != nil || len(ports) < 1 {
+		return nil, nil, err
+	}
+	if len(ports) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(ports), spec)
+	}
+
+	return ports[0].ID, ports[0], nil
+}
+
+func (h *firewallRulePortManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := ecloud.CreateFirewallRulePortRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	taskRef, err := h.service.CreateFirewallRulePort(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return taskRef.ResourceID, helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskRef.TaskID, ecloud.TaskStatusComplete))
+}
+
+func (h *firewallRulePortManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := ecloud.PatchFirewallRulePortRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	taskRef, err := h.service.PatchFirewallRulePort(id.(string), patch)
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskRef.TaskID, ecloud.TaskStatusComplete))
+}
+
+func (h *firewallRulePortManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	taskID, err := h.service.DeleteFirewallRulePort(id.(string))
+	if err != nil {
+		return err
+	}
+
+	return helper.WaitForCommand(TaskStatusWaitFunc(h.service, taskID, ecloud.TaskStatusComplete))
+}
+
+// exportVPCManifest returns a manifest containing the VPC with given ID, along with its routers,
+// networks, firewall policies, firewall rules, firewall rule ports and instances
+func exportVPCManifest(service ecloud.ECloudService, vpcID string) (*manifest.Manifest, error) {
+	m := &manifest.Manifest{}
+	names := make(map[string]string)
+
+	add := func(kind string, id string, name string, model interface{}, references map[string]string, fields ...string) error {
+		if name == "" {
+			name = id
+		}
+
+		r, err := manifest.NewResource(kind, name, model, fields...)
+		if err != nil {
+			return err
+		}
+		for field, referencedID := range references {
+			r.Spec[field] = referencedID
+			if referencedName, ok := names[referencedID]; ok {
+				r.Spec[field] = referencedName
+			}
+		}
+
+		m.Add(r)
+		names[id] = r.Name
+		return nil
+	}
+
+	vpc, err := service.GetVPC(vpcID)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving VPC: %s", err)
+	}
+	err = add("ecloud/vpc", vpc.ID, vpc.Name, vpc, nil, "region_id")
+	if err != nil {
+		return nil, err
+	}
+
+	routerParams := connection.APIRequestParameters{}
+	routerParams.WithFilter(connection.APIRequestFiltering{Property: "vpc_id", Operator: connection.EQOperator, Value: []string{vpc.ID}})
+
+	routers, err := service.GetRouters(routerParams)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving routers: %s", err)
+	}
+	for _, router := range routers {
+		err = add("ecloud/router", router.ID, router.Name, router, map[string]string{"vpc_id": router.VPCID}, "router_throughput_id")
+		if err != nil {
+			return nil, err
+		}
+
+		networks, err := service.GetRouterNetworks(router.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving networks for router [%s]: %s", router.ID, err)
+		}
+		for _, network := range networks {
+			err = add("ecloud/network", network.ID, network.Name, network, map[string]string{"router_id": network.RouterID}, "subnet")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		policies, err := service.GetRouterFirewallPolicies(router.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving firewall policies for router [%s]: %s", router.ID, err)
+		}
+		for _, policy := range policies {
+			err = add("ecloud/firewall-policy", policy.ID, policy.Name, policy, map[string]string{"router_id": policy.RouterID}, "sequence")
+			if err != nil {
+				return nil, err
+			}
+
+			rules, err := service.GetFirewallPolicyFirewallRules(policy.ID, connection.APIRequestParameters{})
+			if err != nil {
+				return nil, fmt.Errorf("Error retrieving firewall rules for policy [%s]: %s", policy.ID, err)
+			}
+			for _, rule := range rules {
+				err = add("ecloud/firewall-rule", rule.ID, rule.Name, rule, map[string]string{"firewall_policy_id": rule.FirewallPolicyID},
+					"sequence", "source", "destination", "action", "direction", "enabled")
+				if err != nil {
+					return nil, err
+				}
+
+				ports, err := service.GetFirewallRuleFirewallRulePorts(rule.ID, connection.APIRequestParameters{})
+				if err != nil {
+					return nil, fmt.Errorf("Error retrieving ports for firewall rule [%s]: %s", rule.ID, err)
+				}
+				for _, port := range ports {
+					err = add("ecloud/firewall-rule-port", port.ID, port.Name, port, map[string]string{"firewall_rule_id": port.FirewallRuleID},
+						"protocol", "source", "destination")
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	instances, err := service.GetVPCInstances(vpc.ID, connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving instances: %s", err)
+	}
+	for _, instance := range instances {
+		references := map[string]string{"vpc_id": instance.VPCID}
+
+		nics, err := service.GetInstanceNICs(instance.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving NICs for instance [%s]: %s", instance.ID, err)
+		}
+		if len(nics) > 0 {
+			references["network_id"] = nics[0].NetworkID
+		}
+
+		err = add("ecloud/instance", instance.ID, instance.Name, instance, references,
+			"image_id", "vcpu_cores", "ram_capacity", "volume_capacity", "locked", "backup_enabled")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,213 @@
+package ecloud
+
+import (
+	"errors"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/ecloud"
+)
+
+func Test_routerManifestHandler_Find(t *testing.T) {
+	t.Run("Exists_FiltersByNameAndVPC", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		handler := ManifestHandlers(service)["ecloud/router"]
+
+		expectedParams := connection.APIRequestParameters{
+			Filtering: []connection.APIRequestFiltering{
+				{Property: "name", Operator: connection.EQOperator, Value: []string{"router1"}},
+				{Property: "vpc_id", Operator: connection.EQOperator, Value: []string{"vpc-abcdef12"}},
+			},
+		}
+
+		service.EXPECT().GetRouters(gomock.Eq(expectedParams)).Return([]ecloud.Router{{ID: "rtr-abcdef12"}}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"name": "router1", "vpc_id": "vpc-abcdef12"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "rtr-abcdef12", id)
+	})
+
+	t.Run("NotFound_ReturnsNilID", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		handler := ManifestHandlers(service)["ecloud/router"]
+
+		service.EXPECT().GetRouters(gomock.Any()).Return([]ecloud.Router{}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"name": "router1"})
+
+		assert.Nil(t, err)
+		assert.Nil(t, id)
+	})
+
+	t.Run("Multiple_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		handler := ManifestHandlers(service)["ecloud/router"]
+
+		service.EXPECT().GetRouters(gomock.Any()).Return([]ecloud.Router{{ID: "rtr-abcdef12"}, {ID: "rtr-abcdef23"}}, nil)
+
+		_, _, err := handler.Find(manifest.Spec{"name": "router1"})
+
+		assert.Equal(t, "2 resources found with name [router1]", err.Error())
+	})
+}
+
+func Test_firewallRulePortManifestHandler_Find(t *testing.T) {
+	t.Run("Exists_FiltersByNameAndFirewallRule", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		handler := ManifestHandlers(service)["ecloud/firewall-rule-port"]
+
+		expectedParams := connection.APIRequestParameters{
+			Filtering: []connection.APIRequestFiltering{
+				{Property: "name", Operator: connection.EQOperator, Value: []string{"https"}},
+				{Property: "firewall_rule_id", Operator: connection.EQOperator, Value: []string{"fwr-abcdef12"}},
+			},
+		}
+
+		service.EXPECT().GetFirewallRulePorts(gomock.Eq(expectedParams)).Return([]ecloud.FirewallRulePort{{ID: "fwrp-abcdef12"}}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"name": "https", "firewall_rule_id": "fwr-abcdef12"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "fwrp-abcdef12", id)
+	})
+}
+
+func Test_vpcManifestHandler_Create(t *testing.T) {
+	t.Run("Valid_CreatesAndWaitsForSync", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		handler := ManifestHandlers(service)["ecloud/vpc"]
+
+		gomock.InOrder(
+			service.EXPECT().CreateVPC(ecloud.CreateVPCRequest{Name: "vpc1", RegionID: "reg-abcdef12"}).Return("vpc-abcdef12", nil),
+			service.EXPECT().GetVPC("vpc-abcdef12").Return(ecloud.VPC{Sync: ecloud.ResourceSync{Status: ecloud.SyncStatusComplete}}, nil),
+		)
+
+		id, err := handler.Create(manifest.Spec{"name": "vpc1", "region_id": "reg-abcdef12"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "vpc-abcdef12", id)
+	})
+
+	t.Run("CreateVPCError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		handler := ManifestHandlers(service)["ecloud/vpc"]
+
+		service.EXPECT().CreateVPC(gomock.Any()).Return("", errors.New("test error"))
+
+		_, err := handler.Create(manifest.Spec{"name": "vpc1"})
+
+		assert.Equal(t, "test error", err.Error())
+	})
+}
+
+func Test_exportVPCManifest(t *testing.T) {
+	t.Run("ReturnsVPCResourcesWithReferences", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetVPC("vpc-abcdef12").Return(ecloud.VPC{ID: "vpc-abcdef12", Name: "vpc1", RegionID: "reg-abcdef12"}, nil)
+		service.EXPECT().GetRouters(gomock.Any()).Return([]ecloud.Router{{ID: "rtr-abcdef12", Name: "router1", VPCID: "vpc-abcdef12"}}, nil)
+		service.EXPECT().GetRouterNetworks("rtr-abcdef12", gomock.Any()).Return([]ecloud.Network{{ID: "net-abcdef12", RouterID: "rtr-abcdef12", Subnet: "10.0.0.0/24"}}, nil)
+		service.EXPECT().GetRouterFirewallPolicies("rtr-abcdef12", gomock.Any()).Return([]ecloud.FirewallPolicy{}, nil)
+		service.EXPECT().GetVPCInstances("vpc-abcdef12", gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12", Name: "instance1", VPCID: "vpc-abcdef12", ImageID: "img-abcdef12", VCPUCores: 2}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{NetworkID: "net-abcdef12"}}, nil)
+
+		m, err := exportVPCManifest(service, "vpc-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Len(t, m.Resources, 4)
+		assert.Equal(t, map[string]interface{}{"region_id": "reg-abcdef12"}, m.Resources[0].Spec)
+		assert.Equal(t, "vpc1", m.Resources[1].Spec["vpc_id"])
+		assert.Equal(t, "net-abcdef12", m.Resources[2].Name)
+		assert.Equal(t, "router1", m.Resources[2].Spec["router_id"])
+		assert.Equal(t, "vpc1", m.Resources[3].Spec["vpc_id"])
+		assert.Equal(t, "net-abcdef12", m.Resources[3].Spec["network_id"])
+		assert.Equal(t, float64(2), m.Resources[3].Spec["vcpu_cores"])
+	})
+
+	t.Run("PlanAgainstExportedState_NoChanges", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		vpc := ecloud.VPC{ID: "vpc-abcdef12", Name: "vpc1", RegionID: "reg-abcdef12"}
+		router := ecloud.Router{ID: "rtr-abcdef12", Name: "router1", VPCID: "vpc-abcdef12"}
+		network := ecloud.Network{ID: "net-abcdef12", Name: "network1", RouterID: "rtr-abcdef12", Subnet: "10.0.0.0/24"}
+		policy := ecloud.FirewallPolicy{ID: "fwp-abcdef12", Name: "policy1", RouterID: "rtr-abcdef12", Sequence: 1}
+		rule := ecloud.FirewallRule{ID: "fwr-abcdef12", Name: "web", FirewallPolicyID: "fwp-abcdef12", Sequence: 1, Source: "ANY", Destination: "10.0.0.10", Action: ecloud.FirewallRuleActionAllow, Direction: ecloud.FirewallRuleDirectionIn, Enabled: true}
+		port := ecloud.FirewallRulePort{ID: "fwrp-abcdef12", Name: "https", FirewallRuleID: "fwr-abcdef12", Protocol: ecloud.FirewallRulePortProtocolTCP, Source: "ANY", Destination: "443"}
+		instance := ecloud.Instance{ID: "i-abcdef12", Name: "instance1", VPCID: "vpc-abcdef12", ImageID: "img-abcdef12", VCPUCores: 2, RAMCapacity: 2048}
+
+		service.EXPECT().GetVPC("vpc-abcdef12").Return(vpc, nil)
+		service.EXPECT().GetVPCs(gomock.Any()).Return([]ecloud.VPC{vpc}, nil)
+		service.EXPECT().GetRouters(gomock.Any()).Return([]ecloud.Router{router}, nil).Times(2)
+		service.EXPECT().GetRouterNetworks("rtr-abcdef12", gomock.Any()).Return([]ecloud.Network{network}, nil)
+		service.EXPECT().GetNetworks(gomock.Any()).Return([]ecloud.Network{network}, nil)
+		service.EXPECT().GetRouterFirewallPolicies("rtr-abcdef12", gomock.Any()).Return([]ecloud.FirewallPolicy{policy}, nil)
+		service.EXPECT().GetFirewallPolicies(gomock.Any()).Return([]ecloud.FirewallPolicy{policy}, nil)
+		service.EXPECT().GetFirewallPolicyFirewallRules("fwp-abcdef12", gomock.Any()).Return([]ecloud.FirewallRule{rule}, nil)
+		service.EXPECT().GetFirewallRules(gomock.Any()).Return([]ecloud.FirewallRule{rule}, nil)
+		service.EXPECT().GetFirewallRuleFirewallRulePorts("fwr-abcdef12", gomock.Any()).Return([]ecloud.FirewallRulePort{port}, nil)
+		service.EXPECT().GetFirewallRulePorts(gomock.Any()).Return([]ecloud.FirewallRulePort{port}, nil)
+		service.EXPECT().GetVPCInstances("vpc-abcdef12", gomock.Any()).Return([]ecloud.Instance{instance}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{NetworkID: "net-abcdef12"}}, nil)
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{instance}, nil)
+
+		exported, err := exportVPCManifest(service, "vpc-abcdef12")
+		assert.Nil(t, err)
+		assert.Equal(t, "ecloud/firewall-rule-port", exported.Resources[5].Kind)
+		assert.Equal(t, "web", exported.Resources[5].Spec["firewall_rule_id"])
+
+		content, err := exported.Marshal()
+		assert.Nil(t, err)
+		m, err := manifest.Parse(content)
+		assert.Nil(t, err)
+
+		registry := manifest.NewRegistry()
+		registry.RegisterAll(ManifestHandlers(service))
+		plan, err := manifest.NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.Len(t, plan.Steps, 7)
+		assert.False(t, plan.HasChanges(), plan.Summary())
+	})
+
+	t.Run("GetVPCError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetVPC("vpc-abcdef12").Return(ecloud.VPC{}, errors.New("test error"))
+
+		_, err := exportVPCManifest(service, "vpc-abcdef12")
+
+		assert.Equal(t, "Error retrieving VPC: test error", err.Error())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -40,7 +41,7 @@ func ecloudNetworkListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Network name for filtering")
-	cmd.Flags().String("router", "", "Router ID for filtering")
+	cmd.Flags().String("router", "", "Router ID or name for filtering")
 
 	return cmd
 }
@@ -75,7 +76,8 @@ func ecloudNetworkShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudNetworkShow),
+		ValidArgsFunction: ecloudNetworkCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudNetworkShow),
 	}
 }
 
@@ -105,7 +107,7 @@ func ecloudNetworkCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of network")
-	cmd.Flags().String("router", "", "ID of router")
+	cmd.Flags().String("router", "", "ID or name of router")
 	cmd.MarkFlagRequired("router")
 	cmd.Flags().String("subnet", "", "Subnet for network, e.g. 10.0.0.0/24")
 	cmd.MarkFlagRequired("subnet")
@@ -131,7 +133,7 @@ func ecloudNetworkCreate(service ecloud.ECloudService, cmd *cobra.Command, args
 	if waitFlag {
 		err := helper.WaitForCommand(NetworkResourceSyncStatusWaitFunc(service, networkID, ecloud.SyncStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for network sync: %s", err)
+			return fmt.Errorf("Error waiting for network sync: %w", err)
 		}
 	}
 
@@ -156,7 +158,8 @@ func ecloudNetworkUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudNetworkUpdate),
+		ValidArgsFunction: ecloudNetworkCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudNetworkUpdate),
 	}
 
 	cmd.Flags().String("name", "", "Name of network")
@@ -214,7 +217,8 @@ func ecloudNetworkDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudNetworkDelete),
+		ValidArgsFunction: ecloudNetworkCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudNetworkDelete),
 	}
 
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the network has been completely removed")
@@ -223,6 +227,14 @@ func ecloudNetworkDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudNetworkDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following networks will be removed", args, func(id string) (string, error) {
+		network, err := service.GetNetwork(id)
+		return network.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteNetwork(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -41,7 +42,8 @@ func ecloudNetworkPolicyListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Network policy name for filtering")
-	cmd.Flags().String("network", "", "Network policy network ID for filtering")
+	cmd.Flags().String("network", "", "Network policy network ID or name for filtering")
+	cmd.RegisterFlagCompletionFunc("network", ecloudNetworkCompletionFunc(f))
 
 	return cmd
 }
@@ -76,7 +78,8 @@ func ecloudNetworkPolicyShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudNetworkPolicyShow),
+		ValidArgsFunction: ecloudNetworkPolicyCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudNetworkPolicyShow),
 	}
 }
 
@@ -105,8 +108,9 @@ func ecloudNetworkPolicyCreateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	// Setup flags
-	cmd.Flags().String("network", "", "ID of network")
+	cmd.Flags().String("network", "", "ID or name of network")
 	cmd.MarkFlagRequired("network")
+	cmd.RegisterFlagCompletionFunc("network", ecloudNetworkCompletionFunc(f))
 	cmd.Flags().String("name", "", "Name of policy")
 	cmd.Flags().String("catchall-rule-action", "", "Action of catchall rule. One of: ALLOW/DROP/REJECT")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the network policy has been completely created")
@@ -138,7 +142,7 @@ func ecloudNetworkPolicyCreate(service ecloud.ECloudService, cmd *cobra.Command,
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for network policy task to complete: %s", err)
+			return fmt.Errorf("Error waiting for network policy task to complete: %w", err)
 		}
 	}
 
@@ -163,7 +167,8 @@ func ecloudNetworkPolicyUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudNetworkPolicyUpdate),
+		ValidArgsFunction: ecloudNetworkPolicyCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudNetworkPolicyUpdate),
 	}
 
 	cmd.Flags().String("name", "", "Name of policy")
@@ -230,7 +235,8 @@ func ecloudNetworkPolicyDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudNetworkPolicyDelete),
+		ValidArgsFunction: ecloudNetworkPolicyCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudNetworkPolicyDelete),
 	}
 
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the network policy has been completely removed")
@@ -239,6 +245,14 @@ func ecloudNetworkPolicyDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudNetworkPolicyDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following network policies will be removed", args, func(id string) (string, error) {
+		networkPolicy, err := service.GetNetworkPolicy(id)
+		return networkPolicy.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteNetworkPolicy(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
@@ -40,7 +41,8 @@ func ecloudNetworkRuleListCmd(f factory.ClientFactory) *cobra.Command {
 		RunE:    ecloudCobraRunEFunc(f, ecloudNetworkRuleList),
 	}
 
-	cmd.Flags().String("policy", "", "Network policy ID for filtering")
+	cmd.Flags().String("policy", "", "Network policy ID or name for filtering")
+	cmd.RegisterFlagCompletionFunc("policy", ecloudNetworkPolicyCompletionFunc(f))
 
 	return cmd
 }
@@ -104,8 +106,9 @@ func ecloudNetworkRuleCreateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	// Setup flags
-	cmd.Flags().String("policy", "", "ID of network policy")
+	cmd.Flags().String("policy", "", "ID or name of network policy")
 	cmd.MarkFlagRequired("policy")
+	cmd.RegisterFlagCompletionFunc("policy", ecloudNetworkPolicyCompletionFunc(f))
 	cmd.Flags().String("source", "", "Source of rule. IP range/subnet or ANY")
 	cmd.MarkFlagRequired("source")
 	cmd.Flags().String("destination", "", "Destination of rule. IP range/subnet or ANY")
@@ -159,7 +162,7 @@ func ecloudNetworkRuleCreate(service ecloud.ECloudService, cmd *cobra.Command, a
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for network rule task to complete: %s", err)
+			return fmt.Errorf("Error waiting for network rule task to complete: %w", err)
 		}
 	}
 
@@ -293,6 +296,14 @@ func ecloudNetworkRuleDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudNetworkRuleDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following network rules will be removed", args, func(id string) (string, error) {
+		networkRule, err := service.GetNetworkRule(id)
+		return networkRule.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteNetworkRule(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -36,7 +37,7 @@ func ecloudNetworkRulePortListCmd(f factory.ClientFactory) *cobra.Command {
 		RunE:    ecloudCobraRunEFunc(f, ecloudNetworkRulePortList),
 	}
 
-	cmd.Flags().String("rule", "", "Network rule ID for filtering")
+	cmd.Flags().String("rule", "", "Network rule ID or name for filtering")
 
 	return cmd
 }
@@ -100,7 +101,7 @@ func ecloudNetworkRulePortCreateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	// Setup flags
-	cmd.Flags().String("rule", "", "ID of network rule")
+	cmd.Flags().String("rule", "", "ID or name of network rule")
 	cmd.MarkFlagRequired("rule")
 	cmd.Flags().String("source", "", "Source port. Single port, port range, or ANY")
 	cmd.Flags().String("destination", "", "Destination port. Single port, port range, or ANY")
@@ -138,7 +139,7 @@ func ecloudNetworkRulePortCreate(service ecloud.ECloudService, cmd *cobra.Comman
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for network rule port task to complete: %s", err)
+			return fmt.Errorf("Error waiting for network rule port task to complete: %w", err)
 		}
 	}
 
@@ -251,6 +252,14 @@ func ecloudNetworkRulePortDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudNetworkRulePortDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following network rule ports will be removed", args, func(id string) (string, error) {
+		networkRulePort, err := service.GetNetworkRulePort(id)
+		return networkRulePort.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteNetworkRulePort(arg)
 		if err != nil {
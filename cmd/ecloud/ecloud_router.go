@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -43,7 +44,8 @@ func ecloudRouterListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Router name for filtering")
-	cmd.Flags().String("vpc", "", "VPC ID for filtering")
+	cmd.Flags().String("vpc", "", "VPC ID or name for filtering")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
 
 	return cmd
 }
@@ -108,9 +110,10 @@ func ecloudRouterCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of router")
-	cmd.Flags().String("vpc", "", "ID of VPC")
+	cmd.Flags().String("vpc", "", "ID or name of VPC")
 	cmd.MarkFlagRequired("vpc")
-	cmd.Flags().String("throughput", "", "ID of router throughput to assign")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
+	cmd.Flags().String("throughput", "", "ID or name of router throughput to assign")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the router has been completely created")
 
 	return cmd
@@ -137,7 +140,7 @@ func ecloudRouterCreate(service ecloud.ECloudService, cmd *cobra.Command, args [
 	if waitFlag {
 		err := helper.WaitForCommand(RouterResourceSyncStatusWaitFunc(service, routerID, ecloud.SyncStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for router sync: %s", err)
+			return fmt.Errorf("Error waiting for router sync: %w", err)
 		}
 	}
 
@@ -166,7 +169,7 @@ func ecloudRouterUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Name of router")
-	cmd.Flags().String("throughput", "", "ID of router throughput to assign")
+	cmd.Flags().String("throughput", "", "ID or name of router throughput to assign")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the router has been completely updated")
 
 	return cmd
@@ -234,6 +237,14 @@ func ecloudRouterDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudRouterDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following routers will be removed", args, func(id string) (string, error) {
+		router, err := service.GetRouter(id)
+		return router.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteRouter(arg)
 		if err != nil {
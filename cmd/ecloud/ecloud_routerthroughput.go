@@ -34,7 +34,7 @@ func ecloudRouterThroughputListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Router throughput name for filtering")
-	cmd.Flags().String("az", "", "Availability zone ID for filtering")
+	cmd.Flags().String("az", "", "Availability zone ID or name for filtering")
 
 	return cmd
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -223,6 +224,14 @@ func ecloudSSHKeyPairDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudSSHKeyPairDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following SSH key pairs will be removed", args, func(id string) (string, error) {
+		keypair, err := service.GetSSHKeyPair(id)
+		return keypair.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteSSHKeyPair(arg)
 		if err != nil {
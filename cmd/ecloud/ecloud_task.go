@@ -8,6 +8,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
 	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
 
@@ -35,6 +36,7 @@ func ecloudTaskListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Task name for filtering")
+	output.AddPaginatedFlags(cmd)
 
 	return cmd
 }
@@ -52,7 +54,11 @@ func ecloudTaskList(service ecloud.ECloudService, cmd *cobra.Command, args []str
 		return fmt.Errorf("Error retrieving tasks: %s", err)
 	}
 
-	return output.CommandOutputPaginated(cmd, OutputECloudTasksProvider(paginatedTasks.Items), paginatedTasks)
+	return output.CommandOutputPaginated(cmd, params, paginatedTasks, func(p connection.APIRequestParameters) (connection.Paginated, error) {
+		return service.GetTasksPaginated(p)
+	}, func(page connection.Paginated) output.OutputHandlerDataProvider {
+		return OutputECloudTasksProvider(page.(*ecloud.PaginatedTask).Items)
+	})
 }
 
 func ecloudTaskShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -170,7 +171,7 @@ func ecloudPodTemplateUpdate(service ecloud.ECloudService, cmd *cobra.Command, a
 
 		err := helper.WaitForCommand(PodTemplateExistsWaitFunc(service, podID, name, true))
 		if err != nil {
-			return fmt.Errorf("Error waiting for pod template update: %s", err)
+			return fmt.Errorf("Error waiting for pod template update: %w", err)
 		}
 
 		templateName = name
@@ -221,6 +222,13 @@ func ecloudPodTemplateDelete(service ecloud.ECloudService, cmd *cobra.Command, a
 		return fmt.Errorf("Invalid pod ID [%s]", args[0])
 	}
 
+	err = input.CommandConfirm(cmd, fmt.Sprintf("The following templates will be removed from pod [%d]", podID), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err = service.DeletePodTemplate(podID, arg)
 		if err != nil {
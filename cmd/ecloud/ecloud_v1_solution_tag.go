@@ -7,6 +7,7 @@ import (
 
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 
@@ -276,6 +277,13 @@ func ecloudSolutionTagDelete(service ecloud.ECloudService, cmd *cobra.Command, a
 		return fmt.Errorf("Invalid solution ID [%s]", args[0])
 	}
 
+	err = input.CommandConfirm(cmd, fmt.Sprintf("The following tags will be removed from solution [%d]", solutionID), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err = service.DeleteSolutionTag(solutionID, arg)
 		if err != nil {
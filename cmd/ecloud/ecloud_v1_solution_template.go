@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -170,7 +171,7 @@ func ecloudSolutionTemplateUpdate(service ecloud.ECloudService, cmd *cobra.Comma
 
 		err := helper.WaitForCommand(SolutionTemplateExistsWaitFunc(service, solutionID, name, true))
 		if err != nil {
-			return fmt.Errorf("Error waiting for solution template update: %s", err)
+			return fmt.Errorf("Error waiting for solution template update: %w", err)
 		}
 
 		templateName = name
@@ -221,6 +222,13 @@ func ecloudSolutionTemplateDelete(service ecloud.ECloudService, cmd *cobra.Comma
 		return fmt.Errorf("Invalid solution ID [%s]", args[0])
 	}
 
+	err = input.CommandConfirm(cmd, fmt.Sprintf("The following templates will be removed from solution [%d]", solutionID), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err = service.DeleteSolutionTemplate(solutionID, arg)
 		if err != nil {
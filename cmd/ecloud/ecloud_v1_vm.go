@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
@@ -74,7 +75,7 @@ func ecloudVirtualMachineList(service ecloud.ECloudService, cmd *cobra.Command,
 }
 
 func ecloudVirtualMachineShowCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "show <virtualmachine: id>...",
 		Short:   "Shows a virtual machine",
 		Long:    "This command shows one or more virtual machines",
@@ -95,24 +96,30 @@ func ecloudVirtualMachineShowCmd(f factory.ClientFactory) *cobra.Command {
 			return ecloudVirtualMachineShow(c.ECloudService(), cmd, args)
 		},
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func ecloudVirtualMachineShow(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
 	var vms []ecloud.VirtualMachine
-	for _, arg := range args {
+	results := helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		vmID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid virtual machine ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid virtual machine ID [%s]", arg)
 		}
 
 		vm, err := service.GetVirtualMachine(vmID)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving virtual machine [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving virtual machine [%s]: %s", arg, err)
 		}
 
-		vms = append(vms, vm)
+		return vm, nil
+	})
+
+	for _, result := range results {
+		vms = append(vms, result.(ecloud.VirtualMachine))
 	}
 
 	return output.CommandOutput(cmd, OutputECloudVirtualMachinesProvider(vms))
@@ -270,6 +277,7 @@ func ecloudVirtualMachineUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().Int("cpu", 0, "Amount of CPU cores for virtual machine")
 	cmd.Flags().Int("ram", 0, "Amount of RAM (GB) for virtual machine")
 	cmd.Flags().String("name", "", "Name of virtual machine")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -293,39 +301,39 @@ func ecloudVirtualMachineUpdate(service ecloud.ECloudService, cmd *cobra.Command
 
 	var vms []ecloud.VirtualMachine
 
-	for _, arg := range args {
+	results := helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		vmID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid virtual machine ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid virtual machine ID [%s]", arg)
 		}
 
 		err = service.PatchVirtualMachine(vmID, patchRequest)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error updating virtual machine [%d]: %s", vmID, err.Error())
-			continue
+			return nil, output.NewCommandErrorf("Error updating virtual machine [%d]: %s", vmID, err)
 		}
 
 		err = helper.WaitForCommand(VirtualMachineStatusWaitFunc(service, vmID, ecloud.VirtualMachineStatusComplete))
 		if err != nil {
-			output.OutputWithErrorLevelf("Error updating virtual machine [%d]: %s", vmID, err.Error())
-			continue
+			return nil, output.NewCommandErrorf("Error updating virtual machine [%d]: %s", vmID, err)
 		}
 
 		vm, err := service.GetVirtualMachine(vmID)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving updated virtual machine [%d]: %s", vmID, err.Error())
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving updated virtual machine [%d]: %s", vmID, err)
 		}
 
-		vms = append(vms, vm)
+		return vm, nil
+	})
+
+	for _, result := range results {
+		vms = append(vms, result.(ecloud.VirtualMachine))
 	}
 
 	return output.CommandOutput(cmd, OutputECloudVirtualMachinesProvider(vms))
 }
 
 func ecloudVirtualMachineStartCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "start <virtualmachine: id>...",
 		Short:   "Starts a virtual machine",
 		Long:    "This command starts one or more virtual machines",
@@ -347,22 +355,26 @@ func ecloudVirtualMachineStartCmd(f factory.ClientFactory) *cobra.Command {
 			return nil
 		},
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func ecloudVirtualMachineStart(service ecloud.ECloudService, cmd *cobra.Command, args []string) {
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		vmID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid virtual machine ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid virtual machine ID [%s]", arg)
 		}
 
 		err = service.PowerOnVirtualMachine(vmID)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error powering on virtual machine [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error powering on virtual machine [%s]: %s", arg, err)
 		}
-	}
+
+		return nil, nil
+	})
 }
 
 func ecloudVirtualMachineStopCmd(f factory.ClientFactory) *cobra.Command {
@@ -390,6 +402,7 @@ func ecloudVirtualMachineStopCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().Bool("force", false, "Specifies that VM should be forcefully powered off")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -397,27 +410,26 @@ func ecloudVirtualMachineStopCmd(f factory.ClientFactory) *cobra.Command {
 func ecloudVirtualMachineStop(service ecloud.ECloudService, cmd *cobra.Command, args []string) {
 	force, _ := cmd.Flags().GetBool("force")
 
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		vmID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid virtual machine ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid virtual machine ID [%s]", arg)
 		}
 
 		if force {
 			err = service.PowerOffVirtualMachine(vmID)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error powering off (forced) virtual machine [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error powering off (forced) virtual machine [%s]: %s", arg, err)
 			}
 		} else {
 			err = service.PowerShutdownVirtualMachine(vmID)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error powering off virtual machine [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error powering off virtual machine [%s]: %s", arg, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
 }
 
 func ecloudVirtualMachineRestartCmd(f factory.ClientFactory) *cobra.Command {
@@ -445,6 +457,7 @@ func ecloudVirtualMachineRestartCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().Bool("force", false, "Specifies that VM should be forcefully powered off")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -452,27 +465,26 @@ func ecloudVirtualMachineRestartCmd(f factory.ClientFactory) *cobra.Command {
 func ecloudVirtualMachineRestart(service ecloud.ECloudService, cmd *cobra.Command, args []string) {
 	force, _ := cmd.Flags().GetBool("force")
 
-	for _, arg := range args {
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		vmID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid virtual machine ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid virtual machine ID [%s]", arg)
 		}
 
 		if force {
 			err = service.PowerResetVirtualMachine(vmID)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error restarting (forced) virtual machine [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error restarting (forced) virtual machine [%s]: %s", arg, err)
 			}
 		} else {
 			err = service.PowerRestartVirtualMachine(vmID)
 			if err != nil {
-				output.OutputWithErrorLevelf("Error restarting virtual machine [%s]: %s", arg, err)
-				continue
+				return nil, output.NewCommandErrorf("Error restarting virtual machine [%s]: %s", arg, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
 }
 
 func ecloudVirtualMachineDeleteCmd(f factory.ClientFactory) *cobra.Command {
@@ -494,39 +506,53 @@ func ecloudVirtualMachineDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			ecloudVirtualMachineDelete(c.ECloudService(), cmd, args)
-			return nil
+			return ecloudVirtualMachineDelete(c.ECloudService(), cmd, args)
 		},
 	}
 
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the VM has been completely deleted before continuing on")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
 
-func ecloudVirtualMachineDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) {
-	for _, arg := range args {
+func ecloudVirtualMachineDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following virtual machines will be removed", args, func(id string) (string, error) {
+		vmID, err := strconv.Atoi(id)
+		if err != nil {
+			return "", err
+		}
+
+		vm, err := service.GetVirtualMachine(vmID)
+		return vm.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		vmID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid virtual machine ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid virtual machine ID [%s]", arg)
 		}
 
 		err = service.DeleteVirtualMachine(vmID)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error removing virtual machine [%d]: %s", vmID, err)
-			continue
+			return nil, output.NewCommandErrorf("Error removing virtual machine [%d]: %s", vmID, err)
 		}
 
 		waitFlag, _ := cmd.Flags().GetBool("wait")
 		if waitFlag {
 			err := helper.WaitForCommand(VirtualMachineNotFoundWaitFunc(service, vmID))
 			if err != nil {
-				output.OutputWithErrorLevelf("Error removing virtual machine [%d]: %s", vmID, err)
-				continue
+				return nil, output.NewCommandErrorf("Error removing virtual machine [%d]: %s", vmID, err)
 			}
 		}
-	}
+
+		return nil, nil
+	})
+
+	return nil
 }
 
 func VirtualMachineNotFoundWaitFunc(service ecloud.ECloudService, vmID int) helper.WaitFunc {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 
@@ -276,6 +277,13 @@ func ecloudVirtualMachineTagDelete(service ecloud.ECloudService, cmd *cobra.Comm
 		return fmt.Errorf("Invalid virtual machine ID [%s]", args[0])
 	}
 
+	err = input.CommandConfirm(cmd, fmt.Sprintf("The following tags will be removed from virtual machine [%d]", vmID), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err = service.DeleteVirtualMachineTag(vmID, arg)
 		if err != nil {
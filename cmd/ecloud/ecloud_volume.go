@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -41,7 +42,8 @@ func ecloudVolumeListCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("name", "", "Volume name for filtering")
-	cmd.Flags().String("vpc", "", "VPC ID for filtering")
+	cmd.Flags().String("vpc", "", "VPC ID or name for filtering")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
 
 	return cmd
 }
@@ -106,8 +108,9 @@ func ecloudVolumeCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of volume")
-	cmd.Flags().String("vpc", "", "ID of VPC")
+	cmd.Flags().String("vpc", "", "ID or name of VPC")
 	cmd.MarkFlagRequired("vpc")
+	cmd.RegisterFlagCompletionFunc("vpc", ecloudVPCCompletionFunc(f))
 	cmd.Flags().Int("capacity", 0, "Capacity of volume in GiB")
 	cmd.MarkFlagRequired("capacity")
 	cmd.Flags().Int("iops", 0, "IOPS for volume")
@@ -134,7 +137,7 @@ func ecloudVolumeCreate(service ecloud.ECloudService, cmd *cobra.Command, args [
 	if waitFlag {
 		err := helper.WaitForCommand(TaskStatusWaitFunc(service, taskRef.TaskID, ecloud.TaskStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for volume task to complete: %s", err)
+			return fmt.Errorf("Error waiting for volume task to complete: %w", err)
 		}
 	}
 
@@ -226,6 +229,14 @@ func ecloudVolumeDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func ecloudVolumeDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following volumes will be removed", args, func(id string) (string, error) {
+		volume, err := service.GetVolume(id)
+		return volume.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		taskID, err := service.DeleteVolume(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ecloud"
 )
@@ -24,6 +25,7 @@ func ecloudVPCRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(ecloudVPCUpdateCmd(f))
 	cmd.AddCommand(ecloudVPCDeleteCmd(f))
 	cmd.AddCommand(ecloudVPCDeployDefaultsCmd(f))
+	cmd.AddCommand(ecloudVPCExportCmd(f))
 
 	// Child root commands
 	cmd.AddCommand(ecloudVPCVolumeRootCmd(f))
@@ -74,7 +76,8 @@ func ecloudVPCShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudVPCShow),
+		ValidArgsFunction: ecloudVPCCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudVPCShow),
 	}
 }
 
@@ -104,7 +107,7 @@ func ecloudVPCCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Setup flags
 	cmd.Flags().String("name", "", "Name of VPC")
-	cmd.Flags().String("region", "", "ID of region")
+	cmd.Flags().String("region", "", "ID or name of region")
 	cmd.MarkFlagRequired("region")
 	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until the VPC has been completely created")
 
@@ -130,7 +133,7 @@ func ecloudVPCCreate(service ecloud.ECloudService, cmd *cobra.Command, args []st
 	if waitFlag {
 		err := helper.WaitForCommand(VPCResourceSyncStatusWaitFunc(service, vpcID, ecloud.SyncStatusComplete))
 		if err != nil {
-			return fmt.Errorf("Error waiting for VPC sync: %s", err)
+			return fmt.Errorf("Error waiting for VPC sync: %w", err)
 		}
 	}
 
@@ -155,7 +158,8 @@ func ecloudVPCUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudVPCUpdate),
+		ValidArgsFunction: ecloudVPCCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudVPCUpdate),
 	}
 
 	cmd.Flags().String("name", "", "Name of VPC")
@@ -214,14 +218,14 @@ func ecloudVPCDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: ecloudVPCCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
 				return err
 			}
 
-			ecloudVPCDelete(c.ECloudService(), cmd, args)
-			return nil
+			return ecloudVPCDelete(c.ECloudService(), cmd, args)
 		},
 	}
 
@@ -230,7 +234,15 @@ func ecloudVPCDeleteCmd(f factory.ClientFactory) *cobra.Command {
 	return cmd
 }
 
-func ecloudVPCDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) {
+func ecloudVPCDelete(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following VPCs will be removed", args, func(id string) (string, error) {
+		vpc, err := service.GetVPC(id)
+		return vpc.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteVPC(arg)
 		if err != nil {
@@ -247,6 +259,8 @@ func ecloudVPCDelete(service ecloud.ECloudService, cmd *cobra.Command, args []st
 			}
 		}
 	}
+
+	return nil
 }
 
 func ecloudVPCDeployDefaultsCmd(f factory.ClientFactory) *cobra.Command {
@@ -262,7 +276,8 @@ func ecloudVPCDeployDefaultsCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: ecloudCobraRunEFunc(f, ecloudVPCDeployDefaults),
+		ValidArgsFunction: ecloudVPCCompletionFunc(f),
+		RunE:              ecloudCobraRunEFunc(f, ecloudVPCDeployDefaults),
 	}
 }
 
@@ -278,6 +293,39 @@ func ecloudVPCDeployDefaults(service ecloud.ECloudService, cmd *cobra.Command, a
 	return nil
 }
 
+func ecloudVPCExportCmd(f factory.ClientFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <vpc: id>",
+		Short: "Exports a VPC as a manifest",
+		Long: "This command exports a VPC along with its routers, networks, firewall policies, firewall rules, firewall rule ports and instances " +
+			"as a YAML manifest, which can be applied with 'ukfast apply'",
+		Example: "ukfast ecloud vpc export vpc-abcdef12 > vpc.yml",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing vpc")
+			}
+
+			return nil
+		},
+		RunE: ecloudCobraRunEFunc(f, ecloudVPCExport),
+	}
+}
+
+func ecloudVPCExport(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	m, err := exportVPCManifest(service, args[0])
+	if err != nil {
+		return err
+	}
+
+	content, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("Error exporting VPC: %s", err)
+	}
+
+	fmt.Print(string(content))
+	return nil
+}
+
 func VPCResourceSyncStatusWaitFunc(service ecloud.ECloudService, vpcID string, status ecloud.SyncStatus) helper.WaitFunc {
 	return ResourceSyncStatusWaitFunc(func() (ecloud.SyncStatus, error) {
 		vpc, err := service.GetVPC(vpcID)
@@ -1,6 +1,8 @@
 package loadbalancer
 
 import (
+	"strconv"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
@@ -33,6 +35,25 @@ func loadbalancerCobraRunEFunc(f factory.ClientFactory, rf loadbalancerServiceCo
 			return err
 		}
 
-		return rf(c.LoadBalancerService(), cmd, args)
+		service := c.LoadBalancerService()
+		args, err = loadbalancerResolver(service).Resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		return rf(service, cmd, args)
+	}
+}
+
+// loadbalancerNameFunc returns a func for use with input.DescribeResources, which parses resource
+// IDs as integers before retrieving resource names with nameFunc
+func loadbalancerNameFunc(nameFunc func(id int) (string, error)) func(id string) (string, error) {
+	return func(id string) (string, error) {
+		resourceID, err := strconv.Atoi(id)
+		if err != nil {
+			return "", err
+		}
+
+		return nameFunc(resourceID)
 	}
 }
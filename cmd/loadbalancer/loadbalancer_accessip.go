@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
@@ -135,6 +136,14 @@ func loadbalancerAccessIPDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func loadbalancerAccessIPDelete(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following access IPs will be removed", args, loadbalancerNameFunc(func(id int) (string, error) {
+		accessIP, err := service.GetAccessIP(id)
+		return accessIP.IP.String(), err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		accessipID, err := strconv.Atoi(arg)
 		if err != nil {
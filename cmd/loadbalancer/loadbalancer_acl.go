@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
 )
@@ -76,8 +77,8 @@ func loadbalancerACLCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	cmd.Flags().String("name", "", "Name of ACL")
 	cmd.MarkFlagRequired("name")
-	cmd.Flags().Int("listener", 0, "ID of listener")
-	cmd.Flags().Int("target-group", 0, "ID of target group")
+	cmd.Flags().String("listener", "", "ID or name of listener")
+	cmd.Flags().String("target-group", "", "ID or name of target group")
 	cmd.Flags().StringArray("condition", []string{}, "Name and arguments of condition. Can be repeated. Example: --condition \"header_matches:host=ukfast.co.uk,accept=application/json\"")
 	cmd.Flags().StringArray("action", []string{}, "Name and arguments of action. Can be repeated. Example: --action \"redirect:location=developers.ukfast.io,status=302\"")
 	cmd.MarkFlagRequired("action")
@@ -88,8 +89,16 @@ func loadbalancerACLCreateCmd(f factory.ClientFactory) *cobra.Command {
 func loadbalancerACLCreate(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
 	createRequest := loadbalancer.CreateACLRequest{}
 	createRequest.Name, _ = cmd.Flags().GetString("name")
-	createRequest.ListenerID, _ = cmd.Flags().GetInt("listener")
-	createRequest.TargetGroupID, _ = cmd.Flags().GetInt("target-group")
+
+	var err error
+	createRequest.ListenerID, err = loadbalancerIDFlag(cmd, "listener")
+	if err != nil {
+		return err
+	}
+	createRequest.TargetGroupID, err = loadbalancerIDFlag(cmd, "target-group")
+	if err != nil {
+		return err
+	}
 
 	if cmd.Flags().Changed("condition") {
 		conditionsFlag, _ := cmd.Flags().GetStringArray("condition")
@@ -211,6 +220,14 @@ func loadbalancerACLDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func loadbalancerACLDelete(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following ACLs will be removed", args, loadbalancerNameFunc(func(id int) (string, error) {
+		acl, err := service.GetACL(id)
+		return acl.Name, err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		aclID, err := strconv.Atoi(arg)
 		if err != nil {
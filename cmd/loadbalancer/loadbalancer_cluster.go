@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
 )
@@ -24,6 +25,7 @@ func loadbalancerClusterRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(loadbalancerClusterUpdateCmd(f))
 	cmd.AddCommand(loadbalancerClusterDeployCmd(f))
 	cmd.AddCommand(loadbalancerClusterValidateCmd(f))
+	cmd.AddCommand(loadbalancerClusterExportCmd(f))
 
 	// Child root commands
 	cmd.AddCommand(loadbalancerClusterACLTemplateRootCmd(f))
@@ -68,7 +70,8 @@ func loadbalancerClusterShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterShow),
+		ValidArgsFunction: loadbalancerClusterCompletionFunc(f),
+		RunE:              loadbalancerCobraRunEFunc(f, loadbalancerClusterShow),
 	}
 }
 
@@ -106,7 +109,8 @@ func loadbalancerClusterUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterUpdate),
+		ValidArgsFunction: loadbalancerClusterCompletionFunc(f),
+		RunE:              loadbalancerCobraRunEFunc(f, loadbalancerClusterUpdate),
 	}
 
 	cmd.Flags().String("name", "", "Name of cluster")
@@ -160,11 +164,20 @@ func loadbalancerClusterDeployCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterDeploy),
+		ValidArgsFunction: loadbalancerClusterCompletionFunc(f),
+		RunE:              loadbalancerCobraRunEFunc(f, loadbalancerClusterDeploy),
 	}
 }
 
 func loadbalancerClusterDeploy(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following clusters will be deployed", args, loadbalancerNameFunc(func(id int) (string, error) {
+		cluster, err := service.GetCluster(id)
+		return cluster.Name, err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		clusterID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -195,7 +208,8 @@ func loadbalancerClusterValidateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
-		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterValidate),
+		ValidArgsFunction: loadbalancerClusterCompletionFunc(f),
+		RunE:              loadbalancerCobraRunEFunc(f, loadbalancerClusterValidate),
 	}
 }
 
@@ -216,3 +230,41 @@ func loadbalancerClusterValidate(service loadbalancer.LoadBalancerService, cmd *
 
 	return nil
 }
+
+func loadbalancerClusterExportCmd(f factory.ClientFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <cluster: id>",
+		Short: "Exports a cluster as a manifest",
+		Long: "This command exports the target groups, targets and listeners of a cluster as a YAML manifest, " +
+			"which can be applied with 'ukfast apply'",
+		Example: "ukfast loadbalancer cluster export 123 > cluster.yml",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing cluster")
+			}
+
+			return nil
+		},
+		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterExport),
+	}
+}
+
+func loadbalancerClusterExport(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	clusterID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("Invalid cluster ID [%s]", args[0])
+	}
+
+	m, err := exportClusterManifest(service, clusterID)
+	if err != nil {
+		return err
+	}
+
+	content, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("Error exporting cluster: %s", err)
+	}
+
+	fmt.Print(string(content))
+	return nil
+}
@@ -0,0 +1,30 @@
+package loadbalancer
+
+import (
+	"strconv"
+
+	"github.com/ukfast/cli/internal/pkg/completion"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+func loadbalancerClusterCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return completion.ResourceCompletionFunc("loadbalancer_clusters", func() ([]completion.Resource, error) {
+		c, err := f.NewClient()
+		if err != nil {
+			return nil, err
+		}
+
+		clusters, err := c.LoadBalancerService().GetClusters(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, cluster := range clusters {
+			resources = append(resources, completion.Resource{ID: strconv.Itoa(cluster.ID), Name: cluster.Name})
+		}
+
+		return resources, nil
+	})
+}
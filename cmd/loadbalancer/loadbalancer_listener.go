@@ -10,6 +10,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/clierrors"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
 )
@@ -108,11 +109,11 @@ func loadbalancerListenerCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	cmd.Flags().String("name", "", "Name of listener")
 	cmd.MarkFlagRequired("name")
-	cmd.Flags().Int("cluster", 0, "ID of cluster")
+	cmd.Flags().String("cluster", "", "ID or name of cluster")
 	cmd.MarkFlagRequired("cluster")
 	cmd.Flags().String("mode", "", "Specifies mode for listener")
 	cmd.MarkFlagRequired("mode")
-	cmd.Flags().Int("default-target-group", 0, "ID of default target group")
+	cmd.Flags().String("default-target-group", "", "ID or name of default target group")
 	cmd.MarkFlagRequired("default-target-group")
 	cmd.Flags().Bool("hsts-enabled", false, "Specifies HSTS should be enabled for listener")
 	cmd.Flags().Int("hsts-max-age", 0, "Specifies HSTS maximum age for listener")
@@ -131,12 +132,10 @@ func loadbalancerListenerCreateCmd(f factory.ClientFactory) *cobra.Command {
 func loadbalancerListenerCreate(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
 	createRequest := loadbalancer.CreateListenerRequest{}
 	createRequest.Name, _ = cmd.Flags().GetString("name")
-	createRequest.ClusterID, _ = cmd.Flags().GetInt("cluster")
 	createRequest.HSTSEnabled, _ = cmd.Flags().GetBool("hsts-enabled")
 	createRequest.HSTSMaxAge, _ = cmd.Flags().GetInt("hsts-max-age")
 	createRequest.Close, _ = cmd.Flags().GetBool("close")
 	createRequest.RedirectHTTPS, _ = cmd.Flags().GetBool("redirect-https")
-	createRequest.DefaultTargetGroupID, _ = cmd.Flags().GetInt("default-target-group")
 	createRequest.AccessIsAllowList, _ = cmd.Flags().GetBool("access-is-allow-list")
 	createRequest.AllowTLSV1, _ = cmd.Flags().GetBool("allow-tlsv1")
 	createRequest.AllowTLSV11, _ = cmd.Flags().GetBool("allow-tlsv11")
@@ -144,6 +143,16 @@ func loadbalancerListenerCreate(service loadbalancer.LoadBalancerService, cmd *c
 	createRequest.DisableHTTP2, _ = cmd.Flags().GetBool("disable-http2")
 	createRequest.CustomCiphers, _ = cmd.Flags().GetString("custom-ciphers")
 
+	var err error
+	createRequest.ClusterID, err = loadbalancerIDFlag(cmd, "cluster")
+	if err != nil {
+		return err
+	}
+	createRequest.DefaultTargetGroupID, err = loadbalancerIDFlag(cmd, "default-target-group")
+	if err != nil {
+		return err
+	}
+
 	mode, _ := cmd.Flags().GetString("mode")
 	parsedMode, err := loadbalancer.ParseMode(mode)
 	if err != nil {
@@ -186,7 +195,7 @@ func loadbalancerListenerUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().Int("hsts-max-age", 0, "Specifies HSTS maximum age for listener")
 	cmd.Flags().Bool("close", false, "Specifies close should be enabled for listener")
 	cmd.Flags().Bool("redirect-https", false, "Specifies HTTPS redirection should be enabled")
-	cmd.Flags().Int("default-target-group", 0, "ID of default target group")
+	cmd.Flags().String("default-target-group", "", "ID or name of default target group")
 	cmd.Flags().Bool("access-is-allow-list", false, "Specifies access IP behaviour should be allow rather than block")
 	cmd.Flags().Bool("allow-tlsv1", false, "Specifies TLSv1 should be allowed")
 	cmd.Flags().Bool("allow-tlsv11", false, "Specifies TLSv1.1 should be allowed")
@@ -204,7 +213,6 @@ func loadbalancerListenerUpdate(service loadbalancer.LoadBalancerService, cmd *c
 	patchRequest.HSTSMaxAge, _ = cmd.Flags().GetInt("hsts-max-age")
 	patchRequest.Close = helper.GetBoolPtrFlagIfChanged(cmd, "close")
 	patchRequest.RedirectHTTPS = helper.GetBoolPtrFlagIfChanged(cmd, "redirect-https")
-	patchRequest.DefaultTargetGroupID, _ = cmd.Flags().GetInt("default-target-group")
 	patchRequest.AccessIsAllowList = helper.GetBoolPtrFlagIfChanged(cmd, "access-is-allow-list")
 	patchRequest.AllowTLSV1 = helper.GetBoolPtrFlagIfChanged(cmd, "allow-tlsv1")
 	patchRequest.AllowTLSV11 = helper.GetBoolPtrFlagIfChanged(cmd, "allow-tlsv11")
@@ -212,6 +220,12 @@ func loadbalancerListenerUpdate(service loadbalancer.LoadBalancerService, cmd *c
 	patchRequest.DisableHTTP2 = helper.GetBoolPtrFlagIfChanged(cmd, "disable-http2")
 	patchRequest.CustomCiphers, _ = cmd.Flags().GetString("custom-ciphers")
 
+	var err error
+	patchRequest.DefaultTargetGroupID, err = loadbalancerIDFlag(cmd, "default-target-group")
+	if err != nil {
+		return err
+	}
+
 	var listeners []loadbalancer.Listener
 	for _, arg := range args {
 		listenerID, err := strconv.Atoi(arg)
@@ -256,6 +270,14 @@ func loadbalancerListenerDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func loadbalancerListenerDelete(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following listeners will be removed", args, loadbalancerNameFunc(func(id int) (string, error) {
+		listener, err := service.GetListener(id)
+		return listener.Name, err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		listenerID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
 )
@@ -245,6 +246,13 @@ func loadbalancerListenerBindDelete(service loadbalancer.LoadBalancerService, cm
 		return fmt.Errorf("Invalid listener ID")
 	}
 
+	err = input.CommandConfirm(cmd, fmt.Sprintf("The following binds will be removed from listener [%d]", listenerID), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		bindID, err := strconv.Atoi(arg)
 		if err != nil {
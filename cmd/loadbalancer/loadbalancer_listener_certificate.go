@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
 )
@@ -131,7 +132,13 @@ func loadbalancerListenerCertificateCreateCmd(f factory.ClientFactory, fs afero.
 				return err
 			}
 
-			return loadbalancerListenerCertificateCreate(c.LoadBalancerService(), cmd, fs, args)
+			service := c.LoadBalancerService()
+			args, err = loadbalancerResolver(service).Resolve(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return loadbalancerListenerCertificateCreate(service, cmd, fs, args)
 		},
 	}
 
@@ -202,7 +209,13 @@ func loadbalancerListenerCertificateUpdateCmd(f factory.ClientFactory, fs afero.
 				return err
 			}
 
-			return loadbalancerListenerCertificateUpdate(c.LoadBalancerService(), cmd, fs, args)
+			service := c.LoadBalancerService()
+			args, err = loadbalancerResolver(service).Resolve(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			return loadbalancerListenerCertificateUpdate(service, cmd, fs, args)
 		},
 	}
 
@@ -290,6 +303,14 @@ func loadbalancerListenerCertificateDelete(service loadbalancer.LoadBalancerServ
 		return fmt.Errorf("Invalid listener ID")
 	}
 
+	err = input.CommandConfirmResources(cmd, fmt.Sprintf("The following certificates will be removed from listener [%d]", listenerID), args[1:], loadbalancerNameFunc(func(id int) (string, error) {
+		certificate, err := service.GetListenerCertificate(listenerID, id)
+		return certificate.Name, err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		certificateID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -0,0 +1,104 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/internal/pkg/resource"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
+)
+
+type loadbalancerListFunc func(parameters connection.APIRequestParameters) (interface{}, error)
+
+// loadbalancerLocator returns a Locator for resources with integer IDs, locating resources by name
+// via list
+func loadbalancerLocator(name string, list loadbalancerListFunc) *resource.Locator {
+	return &resource.Locator{
+		Name:     name,
+		IsID:     loadbalancerIsID,
+		Provider: resource.NewFilterLocatorProvider(list, "name"),
+	}
+}
+
+func loadbalancerIsID(value string) bool {
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+// loadbalancerResolver returns a Resolver which locates load balancer resources named within the
+// arguments and flags of commands
+func loadbalancerResolver(service loadbalancer.LoadBalancerService) *resource.Resolver {
+	acl := loadbalancerLocator("ACL", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetACLs(p)
+	})
+	cluster := loadbalancerLocator("cluster", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetClusters(p)
+	})
+	listener := loadbalancerLocator("listener", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetListeners(p)
+	})
+	targetGroup := loadbalancerLocator("target group", func(p connection.APIRequestParameters) (interface{}, error) {
+		return service.GetTargetGroups(p)
+	})
+
+	// Certificates and targets are located within the listener/target group located by the first
+	// argument
+	certificate := func(preceding []string) *resource.Locator {
+		listenerID, err := strconv.Atoi(preceding[0])
+		if err != nil {
+			return nil
+		}
+
+		return loadbalancerLocator("certificate", func(p connection.APIRequestParameters) (interface{}, error) {
+			return service.GetListenerCertificates(listenerID, p)
+		})
+	}
+	target := func(preceding []string) *resource.Locator {
+		targetGroupID, err := strconv.Atoi(preceding[0])
+		if err != nil {
+			return nil
+		}
+
+		return loadbalancerLocator("target", func(p connection.APIRequestParameters) (interface{}, error) {
+			return service.GetTargetGroupTargets(targetGroupID, p)
+		})
+	}
+
+	return &resource.Resolver{
+		Root: "loadbalancer",
+		Commands: map[string]*resource.CommandLocators{
+			"accessip":             {},
+			"acl":                  {Args: []resource.ArgLocatorFunc{resource.ArgLocator(acl)}},
+			"bind":                 {},
+			"cluster":              {Args: []resource.ArgLocatorFunc{resource.ArgLocator(cluster)}},
+			"listener":             {Args: []resource.ArgLocatorFunc{resource.ArgLocator(listener)}},
+			"listener certificate": {Args: []resource.ArgLocatorFunc{resource.ArgLocator(listener), certificate}},
+			"targetgroup":          {Args: []resource.ArgLocatorFunc{resource.ArgLocator(targetGroup)}},
+			"targetgroup target":   {Args: []resource.ArgLocatorFunc{resource.ArgLocator(targetGroup), target}},
+		},
+		Flags: map[string]*resource.Locator{
+			"cluster":              cluster,
+			"default-target-group": targetGroup,
+			"listener":             listener,
+			"target-group":         targetGroup,
+		},
+	}
+}
+
+// loadbalancerIDFlag returns the value of ID flag name as an integer, or 0 if not specified
+func loadbalancerIDFlag(cmd *cobra.Command, name string) (int, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, clierrors.NewErrValidation(fmt.Errorf("Invalid %s ID [%s]", name, value))
+	}
+
+	return id, nil
+}
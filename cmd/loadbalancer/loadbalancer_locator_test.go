@@ -0,0 +1,107 @@
+package loadbalancer
+
+import (
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
+)
+
+// findLoadBalancerCmd returns the load balancer command at path, e.g. 'targetgroup show'
+func findLoadBalancerCmd(t *testing.T, path ...string) *cobra.Command {
+	cmd, _, err := LoadBalancerRootCmd(nil, afero.NewMemMapFs()).Find(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cmd
+}
+
+func nameFilter(name string) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{name}})
+	return params
+}
+
+func Test_loadbalancerResolver(t *testing.T) {
+	t.Run("TargetArgs_LocatedWithinTargetGroup", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := findLoadBalancerCmd(t, "targetgroup", "target", "show")
+
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroups(nameFilter("web")).Return([]loadbalancer.TargetGroup{{ID: 123}}, nil),
+			service.EXPECT().GetTargetGroupTargets(123, nameFilter("web-01")).Return([]loadbalancer.Target{{ID: 456}}, nil),
+		)
+
+		args, err := loadbalancerResolver(service).Resolve(cmd, []string{"web", "web-01", "789"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"123", "456", "789"}, args)
+	})
+
+	t.Run("CertificateArgs_LocatedWithinListener", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := findLoadBalancerCmd(t, "listener", "certificate", "show")
+
+		service.EXPECT().GetListenerCertificates(123, nameFilter("example.com")).Return([]loadbalancer.Certificate{{ID: 456}}, nil)
+
+		args, err := loadbalancerResolver(service).Resolve(cmd, []string{"123", "example.com"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"123", "456"}, args)
+	})
+
+	t.Run("Flags_LocatesNames", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := findLoadBalancerCmd(t, "listener", "create")
+		cmd.ParseFlags([]string{"--cluster=prod", "--default-target-group=456"})
+
+		service.EXPECT().GetClusters(nameFilter("prod")).Return([]loadbalancer.Cluster{{ID: 123}}, nil)
+
+		_, err := loadbalancerResolver(service).Resolve(cmd, []string{})
+
+		assert.Nil(t, err)
+		clusterID, _ := loadbalancerIDFlag(cmd, "cluster")
+		targetGroupID, _ := loadbalancerIDFlag(cmd, "default-target-group")
+		assert.Equal(t, 123, clusterID)
+		assert.Equal(t, 456, targetGroupID)
+	})
+}
+
+func Test_loadbalancerIDFlag(t *testing.T) {
+	t.Run("InvalidID_ReturnsValidationError", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("cluster", "", "")
+		cmd.ParseFlags([]string{"--cluster=prod"})
+
+		_, err := loadbalancerIDFlag(cmd, "cluster")
+
+		assert.Equal(t, "Invalid cluster ID [prod]", err.Error())
+		assert.Equal(t, clierrors.ExitCodeValidation, clierrors.ExitCode(err))
+	})
+
+	t.Run("NotSpecified_ReturnsZero", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("cluster", "", "")
+
+		id, err := loadbalancerIDFlag(cmd, "cluster")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, id)
+	})
+}
@@ -0,0 +1,288 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
+)
+
+// ManifestHandlers returns manifest handlers for load balancer resources, keyed by kind
+func ManifestHandlers(service loadbalancer.LoadBalancerService) map[string]manifest.Handler {
+	return map[string]manifest.Handler{
+		"loadbalancer/target-group": &targetGroupManifestHandler{service: service},
+		"loadbalancer/target":       &targetManifestHandler{service: service},
+		"loadbalancer/listener":     &listenerManifestHandler{service: service},
+	}
+}
+
+// getManifestFindParameters returns parameters filtering by the name and cluster of the resource
+func getManifestFindParameters(spec manifest.Spec) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{spec.String("name")}})
+	if spec.String("cluster_id") != "" {
+		params.WithFilter(connection.APIRequestFiltering{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{spec.String("cluster_id")}})
+	}
+
+	return params
+}
+
+func ambiguousManifestResourceError(count int, spec manifest.Spec) error {
+	return fmt.Errorf("%d resources found with name [%s]", count, spec.String("name"))
+}
+
+var targetGroupUpdatableFields = []string{
+	"balance", "mode", "close", "sticky", "cookie_opts", "source", "timeouts_connect", "timeouts_server",
+	"custom_options", "monitor_url", "monitor_method", "monitor_host", "monitor_http_version", "monitor_expect",
+	"monitor_tcp_monitoring", "check_port", "send_proxy", "send_proxy_v2", "ssl", "ssl_verify", "sni",
+}
+
+type targetGroupManifestHandler struct {
+	service loadbalancer.LoadBalancerService
+}
+
+func (h *targetGroupManifestHandler) References() map[string]string {
+	return nil
+}
+
+func (h *targetGroupManifestHandler) UpdatableFields() []string {
+	return targetGroupUpdatableFields
+}
+
+func (h *targetGroupManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	groups, err := h.service.GetTargetGroups(getManifestFindParameters(spec))
+	if err != nil || len(groups) < 1 {
+		return nil, nil, err
+	}
+	if len(groups) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(groups), spec)
+	}
+
+	return groups[0].ID, groups[0], nil
+}
+
+func (h *targetGroupManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := loadbalancer.CreateTargetGroupRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.CreateTargetGroup(req)
+}
+
+func (h *targetGroupManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := loadbalancer.PatchTargetGroupRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	return h.service.PatchTargetGroup(id.(int), patch)
+}
+
+func (h *targetGroupManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteTargetGroup(id.(int))
+}
+
+// targetManifestHandler manages targets, which are identified by name within the target group
+// specified by spec field 'target_group_id'
+var targetUpdatableFields = []string{
+	"ip", "port", "weight", "backup", "check_interval", "check_ssl", "check_rise", "check_fall",
+	"disable_http2", "http2_only", "active",
+}
+
+type targetManifestHandler struct {
+	service loadbalancer.LoadBalancerService
+}
+
+func (h *targetManifestHandler) References() map[string]string {
+	return map[string]string{"target_group_id": "loadbalancer/target-group"}
+}
+
+func (h *targetManifestHandler) UpdatableFields() []string {
+	return targetUpdatableFields
+}
+
+func (h *targetManifestHandler) targetGroupID(spec manifest.Spec) (int, error) {
+	targetGroupID, err := strconv.Atoi(spec.String("target_group_id"))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid target group ID [%s]", spec.String("target_group_id"))
+	}
+
+	return targetGroupID, nil
+}
+
+func (h *targetManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	targetGroupID, err := h.targetGroupID(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{spec.String("name")}})
+
+	targets, err := h.service.GetTargetGroupTargets(targetGroupID, params)
+	if err != nil || len(targets) < 1 {
+		return nil, nil, err
+	}
+	if len(targets) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(targets), spec)
+	}
+
+	return targets[0].ID, targets[0], nil
+}
+
+func (h *targetManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	targetGroupID, err := h.targetGroupID(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	req := loadbalancer.CreateTargetRequest{}
+	err = spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.CreateTargetGroupTarget(targetGroupID, req)
+}
+
+func (h *targetManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	targetGroupID, err := h.targetGroupID(spec)
+	if err != nil {
+		return err
+	}
+
+	patch := loadbalancer.PatchTargetRequest{}
+	err = spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	return h.service.PatchTargetGroupTarget(targetGroupID, id.(int), patch)
+}
+
+func (h *targetManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	targetGroupID, err := h.targetGroupID(spec)
+	if err != nil {
+		return err
+	}
+
+	return h.service.DeleteTargetGroupTarget(targetGroupID, id.(int))
+}
+
+var listenerUpdatableFields = []string{
+	"hsts_enabled", "mode", "hsts_maxage", "close", "redirect_https", "default_target_group_id", "access_is_allow_list",
+	"allow_tlsv1", "allow_tlsv11", "disable_tlsv12", "disable_http2", "http2_only", "custom_ciphers",
+}
+
+type listenerManifestHandler struct {
+	service loadbalancer.LoadBalancerService
+}
+
+func (h *listenerManifestHandler) References() map[string]string {
+	return map[string]string{"default_target_group_id": "loadbalancer/target-group"}
+}
+
+func (h *listenerManifestHandler) UpdatableFields() []string {
+	return listenerUpdatableFields
+}
+
+func (h *listenerManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	listeners, err := h.service.GetListeners(getManifestFindParameters(spec))
+	if err != nil || len(listeners) < 1 {
+		return nil, nil, err
+	}
+	if len(listeners) > 1 {
+		return nil, nil, ambiguousManifestResourceError(len(listeners), spec)
+	}
+
+	return listeners[0].ID, listeners[0], nil
+}
+
+func (h *listenerManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := loadbalancer.CreateListenerRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.CreateListener(req)
+}
+
+func (h *listenerManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := loadbalancer.PatchListenerRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	return h.service.PatchListener(id.(int), patch)
+}
+
+func (h *listenerManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteListener(id.(int))
+}
+
+// exportClusterManifest returns a manifest containing the target groups, targets and listeners of
+// the cluster with given ID
+func exportClusterManifest(service loadbalancer.LoadBalancerService, clusterID int) (*manifest.Manifest, error) {
+	cluster, err := service.GetCluster(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving cluster: %s", err)
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{strconv.Itoa(cluster.ID)}})
+
+	m := &manifest.Manifest{}
+	targetGroupNames := make(map[int]string)
+
+	targetGroups, err := service.GetTargetGroups(params)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving target groups: %s", err)
+	}
+	for _, targetGroup := range targetGroups {
+		r, err := manifest.NewResource("loadbalancer/target-group", targetGroup.Name, targetGroup,
+			append([]string{"cluster_id"}, targetGroupUpdatableFields...)...)
+		if err != nil {
+			return nil, err
+		}
+		m.Add(r)
+		targetGroupNames[targetGroup.ID] = r.Name
+
+		targets, err := service.GetTargetGroupTargets(targetGroup.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving targets for target group [%d]: %s", targetGroup.ID, err)
+		}
+		for _, target := range targets {
+			r, err := manifest.NewResource("loadbalancer/target", target.Name, target, targetUpdatableFields...)
+			if err != nil {
+				return nil, err
+			}
+			r.Spec["target_group_id"] = targetGroupNames[targetGroup.ID]
+			m.Add(r)
+		}
+	}
+
+	listeners, err := service.GetListeners(params)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving listeners: %s", err)
+	}
+	for _, listener := range listeners {
+		r, err := manifest.NewResource("loadbalancer/listener", listener.Name, listener,
+			append([]string{"cluster_id"}, listenerUpdatableFields...)...)
+		if err != nil {
+			return nil, err
+		}
+		if name, ok := targetGroupNames[listener.DefaultTargetGroupID]; ok {
+			r.Spec["default_target_group_id"] = name
+		}
+		m.Add(r)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,78 @@
+package loadbalancer
+
+import (
+	"errors"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
+)
+
+func Test_targetManifestHandler_Find(t *testing.T) {
+	t.Run("Exists_ReturnsTarget", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		handler := ManifestHandlers(service)["loadbalancer/target"]
+
+		service.EXPECT().GetTargetGroupTargets(123, gomock.Any()).Return([]loadbalancer.Target{{ID: 456}}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"name": "web1", "target_group_id": 123})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 456, id)
+	})
+
+	t.Run("InvalidTargetGroupID_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := ManifestHandlers(mocks.NewMockLoadBalancerService(mockCtrl))["loadbalancer/target"]
+
+		_, _, err := handler.Find(manifest.Spec{"name": "web1", "target_group_id": "abc"})
+
+		assert.Equal(t, "Invalid target group ID [abc]", err.Error())
+	})
+}
+
+func Test_exportClusterManifest(t *testing.T) {
+	t.Run("ReturnsClusterResources", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		service.EXPECT().GetCluster(123).Return(loadbalancer.Cluster{ID: 123}, nil)
+		service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 1, ClusterID: 123, Name: "web"}}, nil)
+		service.EXPECT().GetTargetGroupTargets(1, gomock.Any()).Return([]loadbalancer.Target{{ID: 2, TargetGroupID: 1, Name: "web1", IP: "10.0.0.1", Port: 80}}, nil)
+		service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{{ID: 3, ClusterID: 123, Name: "http", DefaultTargetGroupID: 1}}, nil)
+
+		m, err := exportClusterManifest(service, 123)
+
+		assert.Nil(t, err)
+		assert.Len(t, m.Resources, 3)
+		assert.Equal(t, "loadbalancer/target-group", m.Resources[0].Kind)
+		assert.Equal(t, float64(123), m.Resources[0].Spec["cluster_id"])
+		assert.Nil(t, m.Resources[0].Spec["id"])
+		assert.Equal(t, "web", m.Resources[1].Spec["target_group_id"])
+		assert.Equal(t, "10.0.0.1", m.Resources[1].Spec["ip"])
+		assert.Equal(t, "web", m.Resources[2].Spec["default_target_group_id"])
+	})
+
+	t.Run("GetClusterError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		service.EXPECT().GetCluster(123).Return(loadbalancer.Cluster{}, errors.New("test error"))
+
+		_, err := exportClusterManifest(service, 123)
+
+		assert.Equal(t, "Error retrieving cluster: test error", err.Error())
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/clierrors"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
 )
@@ -106,7 +107,7 @@ func loadbalancerTargetGroupCreateCmd(f factory.ClientFactory) *cobra.Command {
 
 	cmd.Flags().String("name", "", "Name of target group")
 	cmd.MarkFlagRequired("name")
-	cmd.Flags().Int("cluster", 0, "ID of cluster")
+	cmd.Flags().String("cluster", "", "ID or name of cluster")
 	cmd.MarkFlagRequired("cluster")
 	cmd.Flags().String("balance", "", "Balance configuration for target group")
 	cmd.MarkFlagRequired("balance")
@@ -138,7 +139,6 @@ func loadbalancerTargetGroupCreateCmd(f factory.ClientFactory) *cobra.Command {
 func loadbalancerTargetGroupCreate(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
 	createRequest := loadbalancer.CreateTargetGroupRequest{}
 	createRequest.Name, _ = cmd.Flags().GetString("name")
-	createRequest.ClusterID, _ = cmd.Flags().GetInt("cluster")
 	createRequest.Close, _ = cmd.Flags().GetBool("close")
 	createRequest.Sticky, _ = cmd.Flags().GetBool("sticky")
 	createRequest.CookieOpts, _ = cmd.Flags().GetString("cookie-opts")
@@ -158,6 +158,12 @@ func loadbalancerTargetGroupCreate(service loadbalancer.LoadBalancerService, cmd
 	createRequest.SSLVerify, _ = cmd.Flags().GetBool("ssl-verify")
 	createRequest.SNI, _ = cmd.Flags().GetBool("sni")
 
+	var err error
+	createRequest.ClusterID, err = loadbalancerIDFlag(cmd, "cluster")
+	if err != nil {
+		return err
+	}
+
 	balance, _ := cmd.Flags().GetString("balance")
 	parsedBalance, err := loadbalancer.ParseTargetGroupBalance(balance)
 	if err != nil {
@@ -328,6 +334,14 @@ func loadbalancerTargetGroupDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func loadbalancerTargetGroupDelete(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following target groups will be removed", args, loadbalancerNameFunc(func(id int) (string, error) {
+		group, err := service.GetTargetGroup(id)
+		return group.Name, err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		groupID, err := strconv.Atoi(arg)
 		if err != nil {
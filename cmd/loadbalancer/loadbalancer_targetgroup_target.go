@@ -6,8 +6,10 @@ import (
 	"strconv"
 
 	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/batch"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/loadbalancer"
@@ -117,7 +119,7 @@ func loadbalancerTargetGroupTargetCreateCmd(f factory.ClientFactory) *cobra.Comm
 		Use:     "create <targetgroup: id>",
 		Short:   "Creates a target",
 		Long:    "This command creates a target",
-		Example: "ukfast loadbalancer targetgroup target create 123 --ip 1.2.3.4 --port 443",
+		Example: "ukfast loadbalancer targetgroup target create 123 --ip 1.2.3.4 --port 443\nukfast loadbalancer targetgroup target create 123 --port 443 --from-file targets.csv",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("Missing target group")
@@ -142,6 +144,8 @@ func loadbalancerTargetGroupTargetCreateCmd(f factory.ClientFactory) *cobra.Comm
 	cmd.Flags().Bool("http2-only", false, "Specifies only HTTP2 should be enabled for target")
 	cmd.Flags().Bool("active", true, "Specifies target should be active. Defaults to true")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -210,6 +214,8 @@ func loadbalancerTargetGroupTargetUpdateCmd(f factory.ClientFactory) *cobra.Comm
 	cmd.Flags().Bool("http2-only", false, "Specifies only HTTP2 should be enabled for target")
 	cmd.Flags().Bool("active", true, "Specifies target should be active")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -286,6 +292,14 @@ func loadbalancerTargetGroupTargetDelete(service loadbalancer.LoadBalancerServic
 		return fmt.Errorf("Invalid target group ID")
 	}
 
+	err = input.CommandConfirmResources(cmd, fmt.Sprintf("The following targets will be removed from target group [%d]", targetGroupID), args[1:], loadbalancerNameFunc(func(id int) (string, error) {
+		target, err := service.GetTargetGroupTarget(targetGroupID, id)
+		return target.Name, err
+	}))
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		targetID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/ukfast/cli/internal/pkg/clierrors"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ltaas"
 )
@@ -169,13 +170,19 @@ func loadtestDomainDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			loadtestDomainDelete(c.LTaaSService(), cmd, args)
-			return nil
+			return loadtestDomainDelete(c.LTaaSService(), cmd, args)
 		},
 	}
 }
 
-func loadtestDomainDelete(service ltaas.LTaaSService, cmd *cobra.Command, args []string) {
+func loadtestDomainDelete(service ltaas.LTaaSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, "The following domains will be removed", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteDomain(arg)
 		if err != nil {
@@ -183,4 +190,6 @@ func loadtestDomainDelete(service ltaas.LTaaSService, cmd *cobra.Command, args [
 			continue
 		}
 	}
+
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/connection"
 	"github.com/ukfast/sdk-go/pkg/service/ltaas"
@@ -174,18 +175,22 @@ func loadtestJobDeleteCmd(f factory.ClientFactory) *cobra.Command {
 }
 
 func loadtestJobDelete(service ltaas.LTaaSService, cmd *cobra.Command, args []string) error {
-	var jobs []ltaas.Job
+	err := input.CommandConfirm(cmd, "The following jobs will be removed", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
-		job, err := service.GetJob(arg)
+		err := service.DeleteJob(arg)
 		if err != nil {
 			output.OutputWithErrorLevelf("Error removing job [%s]: %s", arg, err)
 			continue
 		}
-
-		jobs = append(jobs, job)
 	}
 
-	return output.CommandOutput(cmd, OutputLoadTestJobsProvider(jobs))
+	return nil
 }
 
 func loadtestJobStopCmd(f factory.ClientFactory) *cobra.Command {
@@ -183,7 +183,7 @@ func Test_loadtestJobDelete(t *testing.T) {
 
 		service := mocks.NewMockLTaaSService(mockCtrl)
 
-		service.EXPECT().GetJob("00000000-0000-0000-0000-000000000000").Return(ltaas.Job{}, nil).Times(1)
+		service.EXPECT().DeleteJob("00000000-0000-0000-0000-000000000000").Return(nil).Times(1)
 
 		loadtestJobDelete(service, &cobra.Command{}, []string{"00000000-0000-0000-0000-000000000000"})
 	})
@@ -195,20 +195,20 @@ func Test_loadtestJobDelete(t *testing.T) {
 		service := mocks.NewMockLTaaSService(mockCtrl)
 
 		gomock.InOrder(
-			service.EXPECT().GetJob("00000000-0000-0000-0000-000000000000").Return(ltaas.Job{}, nil),
-			service.EXPECT().GetJob("00000000-0000-0000-0000-000000000001").Return(ltaas.Job{}, nil),
+			service.EXPECT().DeleteJob("00000000-0000-0000-0000-000000000000").Return(nil),
+			service.EXPECT().DeleteJob("00000000-0000-0000-0000-000000000001").Return(nil),
 		)
 
 		loadtestJobDelete(service, &cobra.Command{}, []string{"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"})
 	})
 
-	t.Run("GetJobError_OutputsError", func(t *testing.T) {
+	t.Run("DeleteJobError_OutputsError", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockLTaaSService(mockCtrl)
 
-		service.EXPECT().GetJob("00000000-0000-0000-0000-000000000000").Return(ltaas.Job{}, errors.New("test error"))
+		service.EXPECT().DeleteJob("00000000-0000-0000-0000-000000000000").Return(errors.New("test error"))
 
 		test_output.AssertErrorOutput(t, "Error removing job [00000000-0000-0000-0000-000000000000]: test error\n", func() {
 			loadtestJobDelete(service, &cobra.Command{}, []string{"00000000-0000-0000-0000-000000000000"})
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/ltaas"
 )
@@ -200,13 +201,20 @@ func loadtestTestDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			loadtestTestDelete(c.LTaaSService(), cmd, args)
-			return nil
+			return loadtestTestDelete(c.LTaaSService(), cmd, args)
 		},
 	}
 }
 
-func loadtestTestDelete(service ltaas.LTaaSService, cmd *cobra.Command, args []string) {
+func loadtestTestDelete(service ltaas.LTaaSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, "The following tests will be removed", args, func(id string) (string, error) {
+		test, err := service.GetTest(id)
+		return test.Name, err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		err := service.DeleteTest(arg)
 		if err != nil {
@@ -214,4 +222,6 @@ func loadtestTestDelete(service ltaas.LTaaSService, cmd *cobra.Command, args []s
 			continue
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/credential"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/cli/internal/pkg/plugin"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+// executeArgs are the arguments the CLI was invoked with, used to separate global flags from
+// plugin arguments
+var executeArgs []string
+
+// pluginOutputFlags are global output flags passed to plugins via environment, e.g. UKF_OUTPUT
+var pluginOutputFlags = []string{"output", "property", "sort"}
+
+// addPluginCmds registers plugins discovered within fs as child commands of root. Built-in commands
+// take precedence over plugins with the same name
+func addPluginCmds(root *cobra.Command, fs afero.Fs) {
+	for _, p := range plugin.Discover(fs, plugin.Dirs()) {
+		if p.Name == "help" || hasChildCmd(root, p.Name) {
+			continue
+		}
+
+		root.AddCommand(pluginCmd(fs, p))
+	}
+}
+
+func hasChildCmd(cmd *cobra.Command, name string) bool {
+	for _, child := range cmd.Commands() {
+		if child.Name() == name || child.HasAlias(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pluginCmd(fs afero.Fs, p plugin.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:   p.Name,
+		Short: fmt.Sprintf("Plugin command (%s)", p.Path),
+		Long: "This command invokes plugin " + p.Path + ", passing all arguments following the command name. " +
+			"Global flags must precede the command name",
+		// Flags are passed to the plugin unmodified
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(fs, cmd, p, args)
+		},
+	}
+}
+
+func runPlugin(fs afero.Fs, cmd *cobra.Command, p plugin.Plugin, args []string) error {
+	// As flag parsing is disabled, global flags preceding the command name are parsed here, and
+	// config re-initialised to reflect them
+	if executeArgs != nil {
+		var globalArgs []string
+		globalArgs, args = splitPluginArgs(rootCmd.PersistentFlags(), executeArgs, p.Name)
+		err := rootCmd.ParseFlags(globalArgs)
+		if err != nil {
+			return clierrors.NewErrValidation(err)
+		}
+		initConfig()
+	}
+
+	env, err := pluginEnv(fs, rootCmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	c := exec.Command(p.Path, args...)
+	c.Env = plugin.Environ(os.Environ(), env)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	err = c.Run()
+	if err != nil {
+		// Plugins output their own errors, so only the exit code is propagated
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			output.SetErrorLevel(exitErr.ExitCode())
+			return nil
+		}
+
+		return fmt.Errorf("Error running plugin [%s]: %s", p.Name, err)
+	}
+
+	return nil
+}
+
+// splitPluginArgs splits args into the global flags preceding plugin command name, and the
+// arguments following it
+func splitPluginArgs(flags *pflag.FlagSet, args []string, name string) ([]string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == name {
+			return args[:i], args[i+1:]
+		}
+		if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+			continue
+		}
+
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(arg[2:])
+		} else if len(arg) == 2 {
+			flag = flags.ShorthandLookup(arg[1:])
+		}
+
+		// Skip the value of flags requiring one, e.g. '--context prod'
+		if flag != nil && flag.NoOptDefVal == "" {
+			i++
+		}
+	}
+
+	return args, nil
+}
+
+// pluginEnv returns the environment variables passed to plugins. The effective value of each
+// config key is passed (e.g. UKF_API_URI), with the API key resolved from the credentials file or
+// api_key_command where required. The active context, global output flags and the path of the
+// CLI executable are also passed
+func pluginEnv(fs afero.Fs, flags *pflag.FlagSet) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, definition := range config.Definitions() {
+		if definition.Type == config.KeyTypeStringMap || !config.IsSet(definition.Key) {
+			continue
+		}
+
+		env[config.EnvKey(definition.Key)] = fmt.Sprintf("%v", config.Get(definition.Key))
+	}
+
+	apiKey, err := credential.GetAPIKey(fs, true)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey != "" {
+		env[config.EnvKey("api_key")] = apiKey
+	}
+
+	if _, ok := env[config.EnvKey("api_uri")]; !ok {
+		conn := connection.NewAPIConnection(nil)
+		env[config.EnvKey("api_uri")] = conn.APIScheme + "://" + conn.APIURI
+	}
+
+	context := config.GetCurrentContextName()
+	if context != "" {
+		env[config.EnvKey("context")] = context
+	}
+
+	for _, name := range pluginOutputFlags {
+		flag := flags.Lookup(name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+
+		value := flag.Value.String()
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			value = strings.Join(sliceValue.GetSlice(), ",")
+		}
+		env[config.EnvKey(name)] = value
+	}
+
+	executable, err := os.Executable()
+	if err == nil {
+		env[config.EnvKey("cli")] = executable
+	}
+
+	return env, nil
+}
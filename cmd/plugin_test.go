@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPluginFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("context", "", "")
+	flags.StringP("output", "o", "", "")
+	flags.StringSlice("property", []string{}, "")
+	flags.Bool("trace", false, "")
+	return flags
+}
+
+func Test_splitPluginArgs(t *testing.T) {
+	t.Run("GlobalFlagsPrecedingName_Split", func(t *testing.T) {
+		global, args := splitPluginArgs(newTestPluginFlags(), []string{"--context", "provision", "--trace", "-o", "json", "provision", "--context", "other", "arg"}, "provision")
+
+		assert.Equal(t, []string{"--context", "provision", "--trace", "-o", "json"}, global)
+		assert.Equal(t, []string{"--context", "other", "arg"}, args)
+	})
+
+	t.Run("NoGlobalFlags_ReturnsAllArgs", func(t *testing.T) {
+		global, args := splitPluginArgs(newTestPluginFlags(), []string{"provision", "--help"}, "provision")
+
+		assert.Empty(t, global)
+		assert.Equal(t, []string{"--help"}, args)
+	})
+}
+
+func Test_pluginEnv(t *testing.T) {
+	t.Run("ResolvedConfigAndOutputFlags_Returned", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+		viper.Set("contexts.prod.api_key_command", "echo testkey")
+		viper.Set("contexts.prod.api_timeout_seconds", 30)
+		viper.Set("api_headers", map[string]string{"X-Test": "test"})
+
+		flags := newTestPluginFlags()
+		flags.Parse([]string{"--output=json", "--property=id", "--property=name"})
+
+		env, err := pluginEnv(afero.NewMemMapFs(), flags)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "testkey", env["UKF_API_KEY"])
+		assert.Equal(t, "https://api.ukfast.io", env["UKF_API_URI"])
+		assert.Equal(t, "30", env["UKF_API_TIMEOUT_SECONDS"])
+		assert.Equal(t, "prod", env["UKF_CONTEXT"])
+		assert.Equal(t, "json", env["UKF_OUTPUT"])
+		assert.Equal(t, "id,name", env["UKF_PROPERTY"])
+		assert.NotContains(t, env, "UKF_API_HEADERS")
+		assert.NotContains(t, env, "UKF_SORT")
+	})
+}
+
+func Test_addPluginCmds(t *testing.T) {
+	t.Run("BuiltInCommandsTakePrecedence", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/bin/ukfast-provision", []byte{}, 0755)
+		afero.WriteFile(fs, "/bin/ukfast-config", []byte{}, 0755)
+		oldPath := os.Getenv("PATH")
+		defer os.Setenv("PATH", oldPath)
+		os.Setenv("PATH", "/bin")
+
+		root := &cobra.Command{Use: "ukfast"}
+		root.AddCommand(ConfigRootCmd(fs))
+		addPluginCmds(root, fs)
+
+		cmd, _, err := root.Find([]string{"provision"})
+		assert.Nil(t, err)
+		assert.Equal(t, "Plugin command (/bin/ukfast-provision)", cmd.Short)
+
+		cmd, _, err = root.Find([]string{"config"})
+		assert.Nil(t, err)
+		assert.Equal(t, "sub-commands relating to CLI config", cmd.Short)
+	})
+}
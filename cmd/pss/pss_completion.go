@@ -0,0 +1,30 @@
+package pss
+
+import (
+	"strconv"
+
+	"github.com/ukfast/cli/internal/pkg/completion"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+func pssRequestCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return completion.ResourceCompletionFunc("pss_requests", func() ([]completion.Resource, error) {
+		c, err := f.NewClient()
+		if err != nil {
+			return nil, err
+		}
+
+		requests, err := c.PSSService().GetRequests(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, request := range requests {
+			resources = append(resources, completion.Resource{ID: strconv.Itoa(request.ID), Name: request.Subject})
+		}
+
+		return resources, nil
+	})
+}
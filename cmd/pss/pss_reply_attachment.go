@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/pss"
 )
@@ -151,17 +152,25 @@ func pssReplyAttachmentDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			pssReplyAttachmentDelete(c.PSSService(), cmd, args)
-			return nil
+			return pssReplyAttachmentDelete(c.PSSService(), cmd, args)
 		},
 	}
 }
 
-func pssReplyAttachmentDelete(service pss.PSSService, cmd *cobra.Command, args []string) {
+func pssReplyAttachmentDelete(service pss.PSSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, fmt.Sprintf("The following attachments will be removed from reply [%s]", args[0]), func() []string {
+		return args[1:]
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		err := service.DeleteReplyAttachment(args[0], arg)
 		if err != nil {
 			output.OutputWithErrorLevelf("Error deleting reply attachment [%s]: %s", arg, err)
 		}
 	}
+
+	return nil
 }
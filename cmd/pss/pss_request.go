@@ -77,6 +77,7 @@ func pssRequestShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: pssRequestCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -202,6 +203,7 @@ func pssRequestUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: pssRequestCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -301,6 +303,7 @@ func pssRequestCloseCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: pssRequestCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/afero"
@@ -22,6 +26,8 @@ import (
 	sslcmd "github.com/ukfast/cli/cmd/ssl"
 	storagecmd "github.com/ukfast/cli/cmd/storage"
 	"github.com/ukfast/cli/internal/pkg/build"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/internal/pkg/config"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/output"
 )
@@ -46,7 +52,10 @@ func Execute(build build.BuildInfo) {
 
 	// Global flags
 	rootCmd.PersistentFlags().String("config", "", "config file (default is $HOME/.ukfast.yml)")
-	rootCmd.PersistentFlags().StringP("output", "o", "", "output type {table, json, jsonpath, template, value, csv, list}, with optional argument provided as 'outputname=outputargument'")
+	rootCmd.PersistentFlags().String("context", "", "config context to use, overriding current_context")
+	rootCmd.PersistentFlags().String("record", "", "directory to record API requests and responses to, overriding api_record")
+	rootCmd.PersistentFlags().String("replay", "", "directory to replay API responses from, overriding api_replay")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "output type {table, json, ndjson, yaml, jsonpath, template, value, csv, list, markdown}, with optional argument provided as 'outputname=outputargument'")
 	rootCmd.PersistentFlags().StringP("format", "f", "", "")
 	rootCmd.PersistentFlags().MarkDeprecated("format", "please use --output/-o instead")
 	rootCmd.PersistentFlags().String("outputtemplate", "", "output Go template (used with 'template' format), e.g. 'Name: {{ .Name }}'")
@@ -54,13 +63,32 @@ func Execute(build build.BuildInfo) {
 	rootCmd.PersistentFlags().String("sort", "", "output sorting, e.g. 'name', 'name:asc', 'name:desc'")
 	rootCmd.PersistentFlags().StringSlice("property", []string{}, "property to output (used with several formats), can be repeated")
 	rootCmd.PersistentFlags().StringArray("filter", []string{}, "filter for list commands, can be repeated, e.g. 'property=somevalue', 'property:gt=3', 'property=valu*'")
+	rootCmd.PersistentFlags().String("group-by", "", "property to group output by, with optional period for dates {year, month, day}, e.g. 'vpc_id', 'date:month'")
+	rootCmd.PersistentFlags().StringArray("aggregate", []string{}, "aggregate to output for each group, can be repeated {count, sum:<property>, avg:<property>}, e.g. 'sum:total'")
+	rootCmd.PersistentFlags().String("query", "", "client-side query expression for filtering output, e.g. 'status == \"Complete\" and not (name matches \"^test-\")'")
 	rootCmd.PersistentFlags().Int("page", 0, "page to retrieve for paginated requests")
+	rootCmd.PersistentFlags().Int("max-items", 0, "maximum number of items to output, retrieving further pages for paginated requests as required")
+	rootCmd.PersistentFlags().Bool("trace", false, "trace API requests and responses to stderr, with timing, status, size and redacted headers, overriding api_trace")
+	rootCmd.PersistentFlags().String("trace-file", "", "file to write traced API requests and responses to in HAR format, overriding api_trace_file")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "output mutating API requests (e.g. create, update, delete) rather than sending them, overriding api_dry_run")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "skip confirmation prompts for destructive commands")
+	rootCmd.PersistentFlags().Bool("no-input", false, "never prompt for input, failing destructive commands unless --yes is specified")
+	rootCmd.PersistentFlags().Duration("watch", 0, "re-run list and show commands at the given interval (default 5s), highlighting changes, e.g. '--watch', '--watch 10s'")
+	rootCmd.PersistentFlags().Lookup("watch").NoOptDefVal = output.DefaultWatchInterval.String()
+	rootCmd.PersistentFlags().String("until", "", "condition to stop watching once met by all items, in the format 'property=value' or 'property!=value', e.g. 'sync.status=complete'")
+	rootCmd.PersistentFlags().String("error-format", "", "error output format {text, json}, overriding error_format")
 
 	cobra.OnInitialize(initConfig)
 	fs := afero.NewOsFs()
-	clientFactory := factory.NewUKFastClientFactory(
+	factoryOpts := []factory.UKFastClientFactoryOption{
 		factory.WithUserAgent("ukfast-cli"),
-	)
+		factory.WithVersion(appVersion),
+		factory.WithFilesystem(fs),
+	}
+	if isCompletionRequest(os.Args) {
+		factoryOpts = append(factoryOpts, factory.WithNonInteractive())
+	}
+	clientFactory := factory.NewUKFastClientFactory(factoryOpts...)
 
 	// Child commands
 	rootCmd.AddCommand(updateCmd())
@@ -68,6 +96,11 @@ func Execute(build build.BuildInfo) {
 	// Child root commands
 	rootCmd.AddCommand(ConfigRootCmd(fs))
 	rootCmd.AddCommand(CompletionRootCmd())
+	rootCmd.AddCommand(DocsRootCmd())
+	rootCmd.AddCommand(DevRootCmd(fs))
+	rootCmd.AddCommand(APICmd(clientFactory, fs))
+	rootCmd.AddCommand(ApplyCmd(clientFactory, fs))
+	rootCmd.AddCommand(DiffCmd(clientFactory, fs))
 	rootCmd.AddCommand(accountcmd.AccountRootCmd(clientFactory))
 	rootCmd.AddCommand(billingcmd.BillingRootCmd(clientFactory))
 	rootCmd.AddCommand(ddosxcmd.DDoSXRootCmd(clientFactory, fs))
@@ -83,16 +116,136 @@ func Execute(build build.BuildInfo) {
 	rootCmd.AddCommand(sslcmd.SSLRootCmd(clientFactory, fs))
 	rootCmd.AddCommand(storagecmd.StorageRootCmd(clientFactory))
 
-	if err := rootCmd.Execute(); err != nil {
-		output.Fatal(err.Error())
+	// Plugins are registered once all built-in commands have been added, as built-in commands take
+	// precedence
+	addPluginCmds(rootCmd, fs)
+
+	enableWatch(rootCmd)
+	trackCommandRun(rootCmd)
+
+	executeArgs = normaliseWatchArgs(os.Args[1:])
+	rootCmd.SetArgs(executeArgs)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		// Errors returned before the command has run are the result of invalid usage, e.g. unknown
+		// flags or missing arguments
+		if !commandRun {
+			initErrorFormat()
+			err = clierrors.NewErrValidation(err)
+		}
+		output.FatalError(err)
+	}
+
+	output.ExitWithErrorLevel(getResourceArgs(cmd)...)
+}
+
+// commandRun is set once the Run/RunE func of the executed command has been invoked
+var commandRun bool
+
+// trackCommandRun wraps the Run/RunE funcs of cmd and its children, setting commandRun when invoked
+func trackCommandRun(cmd *cobra.Command) {
+	if cmd.RunE != nil {
+		runE := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			commandRun = true
+			return runE(cmd, args)
+		}
+	}
+	if cmd.Run != nil {
+		run := cmd.Run
+		cmd.Run = func(cmd *cobra.Command, args []string) {
+			commandRun = true
+			run(cmd, args)
+		}
+	}
+
+	for _, child := range cmd.Commands() {
+		trackCommandRun(child)
+	}
+}
+
+// enableWatch wraps the Run/RunE funcs of cmd and its children, re-running list and show commands
+// when the watch or until flags are specified. An error is returned for other commands, as re-running
+// them may modify resources
+func enableWatch(cmd *cobra.Command) {
+	if cmd.Run != nil || cmd.RunE != nil {
+		run := cmd.Run
+		runE := cmd.RunE
+		cmd.Run = nil
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			invoke := func() error {
+				if runE != nil {
+					return runE(cmd, args)
+				}
+				run(cmd, args)
+				return nil
+			}
+
+			if !cmd.Flags().Changed("watch") && !cmd.Flags().Changed("until") {
+				return invoke()
+			}
+			if cmd.Name() != "list" && cmd.Name() != "show" {
+				return clierrors.NewErrValidation(errors.New("--watch and --until are only supported by list and show commands"))
+			}
+
+			return output.Watch(cmd, invoke)
+		}
+	}
+
+	for _, child := range cmd.Commands() {
+		enableWatch(child)
+	}
+}
+
+// isCompletionRequest returns true if args (including the executable) invoke cobra's hidden
+// completion command, used by shell completion scripts
+func isCompletionRequest(args []string) bool {
+	return len(args) > 1 && (args[1] == cobra.ShellCompRequestCmd || args[1] == cobra.ShellCompNoDescRequestCmd)
+}
+
+// normaliseWatchArgs joins a duration following the watch flag with the flag, e.g. '--watch 10s'
+// becomes '--watch=10s'. As the flag value is optional, it would otherwise be treated as an argument
+func normaliseWatchArgs(args []string) []string {
+	var normalised []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			return append(normalised, args[i:]...)
+		}
+
+		if args[i] == "--watch" && i+1 < len(args) {
+			if _, err := time.ParseDuration(args[i+1]); err == nil {
+				normalised = append(normalised, "--watch="+args[i+1])
+				i++
+				continue
+			}
+		}
+
+		normalised = append(normalised, args[i])
 	}
 
-	output.ExitWithErrorLevel()
+	return normalised
+}
+
+// getResourceArgs returns the arguments for the repeatable resource argument of cmd, e.g. the
+// record IDs for 'safedns zone record delete <zone: name> <record: id>...'. nil is returned for
+// commands without a repeatable resource argument
+func getResourceArgs(cmd *cobra.Command) []string {
+	if cmd == nil || !strings.HasSuffix(cmd.Use, ">...") {
+		return nil
+	}
+
+	fixed := strings.Count(cmd.Use, "<") - 1
+	args := cmd.Flags().Args()
+	if len(args) <= fixed {
+		return nil
+	}
+
+	return args[fixed:]
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
-	viper.SetEnvPrefix("ukf")
+	viper.SetEnvPrefix(config.EnvPrefix)
 	viper.AutomaticEnv() // read in environment variables that match
 
 	var configFilePath string
@@ -119,4 +272,44 @@ func initConfig() {
 	if configFile && err != nil {
 		output.Fatalf("Failed to read config from file '%s': %s", configFilePath, err.Error())
 	}
+
+	if rootCmd.Flags().Changed("context") {
+		context, _ := rootCmd.Flags().GetString("context")
+		config.SetContextOverride(context)
+	}
+	for _, key := range []string{"record", "replay"} {
+		if rootCmd.Flags().Changed(key) {
+			value, _ := rootCmd.Flags().GetString(key)
+			config.SetFlagOverride("api_"+key, value)
+		}
+	}
+	if rootCmd.Flags().Changed("trace") {
+		trace, _ := rootCmd.Flags().GetBool("trace")
+		config.SetFlagOverride("api_trace", trace)
+	}
+	if rootCmd.Flags().Changed("trace-file") {
+		traceFile, _ := rootCmd.Flags().GetString("trace-file")
+		config.SetFlagOverride("api_trace_file", traceFile)
+	}
+	initErrorFormat()
+	if rootCmd.Flags().Changed("dry-run") {
+		dryRun, _ := rootCmd.Flags().GetBool("dry-run")
+		config.SetFlagOverride("api_dry_run", dryRun)
+	}
+}
+
+// initErrorFormat sets the error output format from the error-format flag or error_format config.
+// This is also invoked for errors returned prior to config being initialised, e.g. invalid
+// arguments, so that those errors are output in the requested format
+func initErrorFormat() {
+	if rootCmd.Flags().Changed("error-format") {
+		errorFormat, _ := rootCmd.Flags().GetString("error-format")
+		config.SetFlagOverride("error_format", errorFormat)
+	}
+	if config.IsSet("error_format") {
+		err := output.SetErrorFormat(config.GetString("error_format"))
+		if err != nil {
+			output.Fatal(err.Error())
+		}
+	}
 }
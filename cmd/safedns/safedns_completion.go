@@ -0,0 +1,28 @@
+package safedns
+
+import (
+	"github.com/ukfast/cli/internal/pkg/completion"
+	"github.com/ukfast/cli/internal/pkg/factory"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+func safednsZoneCompletionFunc(f factory.ClientFactory) completion.CobraCompletionFunc {
+	return completion.ResourceCompletionFunc("safedns_zones", func() ([]completion.Resource, error) {
+		c, err := f.NewClient()
+		if err != nil {
+			return nil, err
+		}
+
+		zones, err := c.SafeDNSService().GetZones(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, err
+		}
+
+		var resources []completion.Resource
+		for _, zone := range zones {
+			resources = append(resources, completion.Resource{ID: zone.Name, Name: zone.Description})
+		}
+
+		return resources, nil
+	})
+}
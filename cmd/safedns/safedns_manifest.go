@@ -0,0 +1,178 @@
+package safedns
+
+import (
+	"fmt"
+
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/sdk-go/pkg/connection"
+	"github.com/ukfast/sdk-go/pkg/service/safedns"
+)
+
+// ManifestHandlers returns manifest handlers for SafeDNS resources, keyed by kind
+func ManifestHandlers(service safedns.SafeDNSService) map[string]manifest.Handler {
+	return map[string]manifest.Handler{
+		"safedns/zone":   &zoneManifestHandler{service: service},
+		"safedns/record": &recordManifestHandler{service: service},
+	}
+}
+
+type zoneManifestHandler struct {
+	service safedns.SafeDNSService
+}
+
+func (h *zoneManifestHandler) References() map[string]string {
+	return nil
+}
+
+func (h *zoneManifestHandler) UpdatableFields() []string {
+	return []string{"description"}
+}
+
+func (h *zoneManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	zone, err := h.service.GetZone(spec.String("name"))
+	if err != nil {
+		if _, ok := err.(*safedns.ZoneNotFoundError); ok {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return zone.Name, zone, nil
+}
+
+func (h *zoneManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := safedns.CreateZoneRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.Name, h.service.CreateZone(req)
+}
+
+func (h *zoneManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := safedns.PatchZoneRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	return h.service.PatchZone(id.(string), patch)
+}
+
+func (h *zoneManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteZone(id.(string))
+}
+
+// recordManifestHandler manages zone records, which are identified by name and type within the
+// zone specified by spec field 'zone'. Where multiple records exist with the same name and type
+// (e.g. round-robin A records), records are additionally identified by content
+type recordManifestHandler struct {
+	service safedns.SafeDNSService
+}
+
+func (h *recordManifestHandler) References() map[string]string {
+	return map[string]string{"zone": "safedns/zone"}
+}
+
+func (h *recordManifestHandler) UpdatableFields() []string {
+	return []string{"content", "ttl", "priority"}
+}
+
+func (h *recordManifestHandler) Find(spec manifest.Spec) (interface{}, interface{}, error) {
+	if spec.String("zone") == "" {
+		return nil, nil, fmt.Errorf("missing zone")
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: "name", Operator: connection.EQOperator, Value: []string{spec.String("name")}})
+	params.WithFilter(connection.APIRequestFiltering{Property: "type", Operator: connection.EQOperator, Value: []string{spec.String("type")}})
+
+	records, err := h.service.GetZoneRecords(spec.String("zone"), params)
+	if err != nil || len(records) < 1 {
+		return nil, nil, err
+	}
+
+	if len(records) > 1 {
+		var matching []safedns.Record
+		for _, record := range records {
+			if record.Content == spec.String("content") {
+				matching = append(matching, record)
+			}
+		}
+		if len(matching) != 1 {
+			return nil, nil, fmt.Errorf("%d records found with name [%s] and type [%s]", len(records), spec.String("name"), spec.String("type"))
+		}
+		records = matching
+	}
+
+	return records[0].ID, records[0], nil
+}
+
+func (h *recordManifestHandler) Create(spec manifest.Spec) (interface{}, error) {
+	req := safedns.CreateRecordRequest{}
+	err := spec.Decode(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.service.CreateZoneRecord(spec.String("zone"), req)
+}
+
+func (h *recordManifestHandler) Update(id interface{}, spec manifest.Spec, fields []string) error {
+	patch := safedns.PatchRecordRequest{}
+	err := spec.Subset(fields).Decode(&patch)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.service.PatchZoneRecord(spec.String("zone"), id.(int), patch)
+	return err
+}
+
+func (h *recordManifestHandler) Delete(id interface{}, spec manifest.Spec) error {
+	return h.service.DeleteZoneRecord(spec.String("zone"), id.(int))
+}
+
+// exportZoneManifest returns a manifest containing the zone with given name and its records. SOA
+// records are omitted, as these are managed by SafeDNS
+func exportZoneManifest(service safedns.SafeDNSService, zoneName string) (*manifest.Manifest, error) {
+	zone, err := service.GetZone(zoneName)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving zone: %s", err)
+	}
+
+	zoneResource, err := manifest.NewResource("safedns/zone", zone.Name, zone, "description")
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manifest.Manifest{}
+	m.Add(zoneResource)
+
+	records, err := service.GetZoneRecords(zone.Name, connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving records: %s", err)
+	}
+
+	for _, record := range records {
+		if record.Type == safedns.RecordTypeSOA {
+			continue
+		}
+
+		fields := []string{"type", "content", "ttl"}
+		if record.Type == safedns.RecordTypeMX || record.Type == safedns.RecordTypeSRV {
+			fields = append(fields, "priority")
+		}
+
+		r, err := manifest.NewResource("safedns/record", record.Name, record, fields...)
+		if err != nil {
+			return nil, err
+		}
+		r.Spec["zone"] = zoneResource.Name
+
+		m.Add(r)
+	}
+
+	return m, nil
+}
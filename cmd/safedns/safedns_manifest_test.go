@@ -0,0 +1,185 @@
+package safedns
+
+import (
+	"errors"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/manifest"
+	"github.com/ukfast/cli/test/mocks"
+	"github.com/ukfast/sdk-go/pkg/service/safedns"
+)
+
+func Test_zoneManifestHandler_Find(t *testing.T) {
+	t.Run("Exists_ReturnsZone", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+		handler := ManifestHandlers(service)["safedns/zone"]
+
+		service.EXPECT().GetZone("example.com").Return(safedns.Zone{Name: "example.com"}, nil)
+
+		id, current, err := handler.Find(manifest.Spec{"name": "example.com"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "example.com", id)
+		assert.Equal(t, safedns.Zone{Name: "example.com"}, current)
+	})
+
+	t.Run("NotFound_ReturnsNilID", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+		handler := ManifestHandlers(service)["safedns/zone"]
+
+		service.EXPECT().GetZone("example.com").Return(safedns.Zone{}, &safedns.ZoneNotFoundError{ZoneName: "example.com"})
+
+		id, _, err := handler.Find(manifest.Spec{"name": "example.com"})
+
+		assert.Nil(t, err)
+		assert.Nil(t, id)
+	})
+
+	t.Run("GetZoneError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+		handler := ManifestHandlers(service)["safedns/zone"]
+
+		service.EXPECT().GetZone("example.com").Return(safedns.Zone{}, errors.New("test error"))
+
+		_, _, err := handler.Find(manifest.Spec{"name": "example.com"})
+
+		assert.Equal(t, "test error", err.Error())
+	})
+}
+
+func Test_recordManifestHandler_Find(t *testing.T) {
+	t.Run("SingleRecord_ReturnsRecord", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+		handler := ManifestHandlers(service)["safedns/record"]
+
+		service.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return([]safedns.Record{{ID: 123}}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"zone": "example.com", "name": "www.example.com", "type": "A"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 123, id)
+	})
+
+	t.Run("MultipleRecords_ReturnsRecordMatchingContent", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+		handler := ManifestHandlers(service)["safedns/record"]
+
+		service.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return([]safedns.Record{{ID: 123, Content: "1.2.3.4"}, {ID: 456, Content: "5.6.7.8"}}, nil)
+
+		id, _, err := handler.Find(manifest.Spec{"zone": "example.com", "name": "www.example.com", "type": "A", "content": "5.6.7.8"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 456, id)
+	})
+
+	t.Run("MultipleRecordsNoContentMatch_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+		handler := ManifestHandlers(service)["safedns/record"]
+
+		service.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return([]safedns.Record{{ID: 123}, {ID: 456}}, nil)
+
+		_, _, err := handler.Find(manifest.Spec{"zone": "example.com", "name": "www.example.com", "type": "A"})
+
+		assert.Equal(t, "2 records found with name [www.example.com] and type [A]", err.Error())
+	})
+
+	t.Run("MissingZone_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		handler := ManifestHandlers(mocks.NewMockSafeDNSService(mockCtrl))["safedns/record"]
+
+		_, _, err := handler.Find(manifest.Spec{"name": "www.example.com"})
+
+		assert.Equal(t, "missing zone", err.Error())
+	})
+}
+
+func Test_recordManifestHandler_Update(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	service := mocks.NewMockSafeDNSService(mockCtrl)
+	handler := ManifestHandlers(service)["safedns/record"]
+
+	service.EXPECT().PatchZoneRecord("example.com", 123, safedns.PatchRecordRequest{Content: "5.6.7.8"}).Return(123, nil)
+
+	err := handler.Update(123, manifest.Spec{"zone": "example.com", "name": "www.example.com", "content": "5.6.7.8"}, []string{"content"})
+
+	assert.Nil(t, err)
+}
+
+func Test_exportZoneManifest(t *testing.T) {
+	t.Run("ReturnsZoneAndRecordsExcludingSOA", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+
+		service.EXPECT().GetZone("example.com").Return(safedns.Zone{Name: "example.com", Description: "test"}, nil)
+		service.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return([]safedns.Record{
+			{ID: 1, Name: "example.com", Type: safedns.RecordTypeSOA, Content: "ns0.example.com"},
+			{ID: 2, Name: "example.com", Type: safedns.RecordTypeNS, Content: "ns0.example.com", TTL: 3600},
+			{ID: 3, Name: "example.com", Type: safedns.RecordTypeNS, Content: "ns1.example.com", TTL: 3600},
+			{ID: 4, Name: "example.com", Type: safedns.RecordTypeMX, Content: "mx.example.com", TTL: 3600, Priority: 10},
+		}, nil)
+
+		m, err := exportZoneManifest(service, "example.com")
+
+		assert.Nil(t, err)
+		assert.Len(t, m.Resources, 4)
+		assert.Equal(t, map[string]interface{}{"description": "test"}, m.Resources[0].Spec)
+		assert.Equal(t, "example.com", m.Resources[1].Name)
+		assert.Equal(t, map[string]interface{}{"zone": "example.com", "type": "NS", "content": "ns0.example.com", "ttl": float64(3600)}, m.Resources[1].Spec)
+		assert.Equal(t, "example.com-2", m.Resources[2].Name)
+		assert.Equal(t, "example.com", m.Resources[2].Spec["name"])
+		assert.Equal(t, float64(10), m.Resources[3].Spec["priority"])
+	})
+
+	t.Run("GetZoneError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+
+		service.EXPECT().GetZone("example.com").Return(safedns.Zone{}, errors.New("test error"))
+
+		_, err := exportZoneManifest(service, "example.com")
+
+		assert.Equal(t, "Error retrieving zone: test error", err.Error())
+	})
+
+	t.Run("GetZoneRecordsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+
+		service.EXPECT().GetZone("example.com").Return(safedns.Zone{Name: "example.com"}, nil)
+		service.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return([]safedns.Record{}, errors.New("test error"))
+
+		_, err := exportZoneManifest(service, "example.com")
+
+		assert.Equal(t, "Error retrieving records: test error", err.Error())
+	})
+}
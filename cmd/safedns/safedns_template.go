@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/safedns"
@@ -237,13 +238,19 @@ func safednsTemplateDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			safednsTemplateDelete(c.SafeDNSService(), cmd, args)
-			return nil
+			return safednsTemplateDelete(c.SafeDNSService(), cmd, args)
 		},
 	}
 }
 
-func safednsTemplateDelete(service safedns.SafeDNSService, cmd *cobra.Command, args []string) {
+func safednsTemplateDelete(service safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, "The following templates will be removed", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args {
 		templateID, err := getSafeDNSTemplateIDByNameOrID(service, arg)
 		if err != nil {
@@ -258,4 +265,5 @@ func safednsTemplateDelete(service safedns.SafeDNSService, cmd *cobra.Command, a
 		}
 	}
 
+	return nil
 }
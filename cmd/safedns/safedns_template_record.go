@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/safedns"
@@ -336,6 +337,19 @@ func safednsTemplateRecordDelete(service safedns.SafeDNSService, cmd *cobra.Comm
 		return err
 	}
 
+	err = input.CommandConfirmResources(cmd, fmt.Sprintf("The following records will be removed from template [%s]", args[0]), args[1:], func(id string) (string, error) {
+		recordID, err := strconv.Atoi(id)
+		if err != nil {
+			return "", err
+		}
+
+		record, err := service.GetTemplateRecord(templateID, recordID)
+		return fmt.Sprintf("%s %s %s", record.Name, record.Type, record.Content), err
+	})
+	if err != nil {
+		return err
+	}
+
 	for _, arg := range args[1:] {
 		recordID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/service/safedns"
 )
@@ -23,6 +24,7 @@ func safednsZoneRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(safednsZoneCreateCmd(f))
 	cmd.AddCommand(safednsZoneUpdateCmd(f))
 	cmd.AddCommand(safednsZoneDeleteCmd(f))
+	cmd.AddCommand(safednsZoneExportCmd(f))
 
 	// Child root commands
 	cmd.AddCommand(safednsZoneRecordRootCmd(f))
@@ -66,7 +68,7 @@ func safednsZoneList(service safedns.SafeDNSService, cmd *cobra.Command, args []
 }
 
 func safednsZoneShowCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "show <zone: name>...",
 		Short:   "Shows a zone",
 		Long:    "This command shows one or more zones",
@@ -78,6 +80,7 @@ func safednsZoneShowCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: safednsZoneCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -87,18 +90,25 @@ func safednsZoneShowCmd(f factory.ClientFactory) *cobra.Command {
 			return safednsZoneShow(c.SafeDNSService(), cmd, args)
 		},
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func safednsZoneShow(service safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
 	var zones []safedns.Zone
-	for _, arg := range args {
+	results := helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		zone, err := service.GetZone(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving zone [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving zone [%s]: %s", arg, err)
 		}
 
-		zones = append(zones, zone)
+		return zone, nil
+	})
+
+	for _, result := range results {
+		zones = append(zones, result.(safedns.Zone))
 	}
 
 	return output.CommandOutput(cmd, OutputSafeDNSZonesProvider(zones))
@@ -163,6 +173,7 @@ func safednsZoneUpdateCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: safednsZoneCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
@@ -174,6 +185,7 @@ func safednsZoneUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	}
 
 	cmd.Flags().String("description", "", "Description for zone")
+	helper.AddParallelFlag(cmd)
 
 	return cmd
 }
@@ -183,27 +195,29 @@ func safednsZoneUpdate(service safedns.SafeDNSService, cmd *cobra.Command, args
 	patchRequest.Description, _ = cmd.Flags().GetString("description")
 
 	var zones []safedns.Zone
-	for _, arg := range args {
+	results := helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		err := service.PatchZone(arg, patchRequest)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error updating zone [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error updating zone [%s]: %s", arg, err)
 		}
 
 		zone, err := service.GetZone(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving updated zone [%s]: %s", arg, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving updated zone [%s]: %s", arg, err)
 		}
 
-		zones = append(zones, zone)
+		return zone, nil
+	})
+
+	for _, result := range results {
+		zones = append(zones, result.(safedns.Zone))
 	}
 
 	return output.CommandOutput(cmd, OutputSafeDNSZonesProvider(zones))
 }
 
 func safednsZoneDeleteCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "delete <zone: name>...",
 		Short:   "Removes a zone",
 		Long:    "This command removes one or more zones",
@@ -215,23 +229,77 @@ func safednsZoneDeleteCmd(f factory.ClientFactory) *cobra.Command {
 
 			return nil
 		},
+		ValidArgsFunction: safednsZoneCompletionFunc(f),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
 				return err
 			}
 
-			safednsZoneDelete(c.SafeDNSService(), cmd, args)
-			return nil
+			return safednsZoneDelete(c.SafeDNSService(), cmd, args)
 		},
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
-func safednsZoneDelete(service safedns.SafeDNSService, cmd *cobra.Command, args []string) {
-	for _, arg := range args {
+func safednsZoneDelete(service safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirm(cmd, "The following zones will be removed", func() []string {
+		return args
+	})
+	if err != nil {
+		return err
+	}
+
+	helper.ForEachArg(cmd, args, func(arg string) (interface{}, error) {
 		err := service.DeleteZone(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error removing zone [%s]: %s", arg, err)
+			return nil, output.NewCommandErrorf("Error removing zone [%s]: %s", arg, err)
 		}
+
+		return nil, nil
+	})
+
+	return nil
+}
+
+func safednsZoneExportCmd(f factory.ClientFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "export <zone: name>",
+		Short:   "Exports a zone as a manifest",
+		Long:    "This command exports a zone along with its records as a YAML manifest, which can be applied with 'ukfast apply'",
+		Example: "ukfast safedns zone export ukfast.co.uk > zone.yml",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing zone")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return safednsZoneExport(c.SafeDNSService(), cmd, args)
+		},
 	}
 }
+
+func safednsZoneExport(service safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
+	m, err := exportZoneManifest(service, args[0])
+	if err != nil {
+		return err
+	}
+
+	content, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("Error exporting zone: %s", err)
+	}
+
+	fmt.Print(string(content))
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"strconv"
 
 	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/batch"
 	"github.com/ukfast/cli/internal/pkg/factory"
 	"github.com/ukfast/cli/internal/pkg/helper"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/ptr"
 	"github.com/ukfast/sdk-go/pkg/service/safedns"
@@ -78,7 +80,7 @@ func safednsZoneRecordList(service safedns.SafeDNSService, cmd *cobra.Command, a
 }
 
 func safednsZoneRecordShowCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "show <zone: name> <record: id>...",
 		Short:   "Shows a zone record",
 		Long:    "This command shows one or more zone records",
@@ -102,25 +104,31 @@ func safednsZoneRecordShowCmd(f factory.ClientFactory) *cobra.Command {
 			return safednsZoneRecordShow(c.SafeDNSService(), cmd, args)
 		},
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
 func safednsZoneRecordShow(service safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
 	var zoneRecords []safedns.Record
 
-	for _, arg := range args[1:] {
+	results := helper.ForEachArg(cmd, args[1:], func(arg string) (interface{}, error) {
 		recordID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid record ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid record ID [%s]", arg)
 		}
 
 		zoneRecord, err := service.GetZoneRecord(args[0], recordID)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving record [%d]: %s", recordID, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving record [%d]: %s", recordID, err)
 		}
 
-		zoneRecords = append(zoneRecords, zoneRecord)
+		return zoneRecord, nil
+	})
+
+	for _, result := range results {
+		zoneRecords = append(zoneRecords, result.(safedns.Record))
 	}
 
 	return output.CommandOutput(cmd, OutputSafeDNSRecordsProvider(zoneRecords))
@@ -131,7 +139,7 @@ func safednsZoneRecordCreateCmd(f factory.ClientFactory) *cobra.Command {
 		Use:     "create <zone: name>",
 		Short:   "Creates a zone record",
 		Long:    "This command creates a zone record",
-		Example: "ukfast safedns zone record create ukfast.co.uk --name subdomain.ukfast.co.uk --type A --content 1.2.3.4",
+		Example: "ukfast safedns zone record create ukfast.co.uk --name subdomain.ukfast.co.uk --type A --content 1.2.3.4\nukfast safedns zone record create ukfast.co.uk --from-file records.csv",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("Missing zone")
@@ -158,6 +166,8 @@ func safednsZoneRecordCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.MarkFlagRequired("content")
 	cmd.Flags().Int("priority", 0, "Record priority. Only applicable with MX and SRV type records")
 
+	batch.Enable(cmd)
+
 	return cmd
 }
 
@@ -220,6 +230,9 @@ func safednsZoneRecordUpdateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().String("content", "", "Record content")
 	cmd.Flags().Int("priority", 0, "Record priority. Only applicable with MX type records")
 
+	batch.Enable(cmd)
+	helper.AddParallelFlag(cmd)
+
 	return cmd
 }
 
@@ -245,33 +258,34 @@ func safednsZoneRecordUpdate(service safedns.SafeDNSService, cmd *cobra.Command,
 
 	var zoneRecords []safedns.Record
 
-	for _, arg := range args[1:] {
+	results := helper.ForEachArg(cmd, args[1:], func(arg string) (interface{}, error) {
 		recordID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid record ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid record ID [%s]", arg)
 		}
 
 		id, err := service.PatchZoneRecord(args[0], recordID, patchRequest)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error updating record [%d]: %s", recordID, err)
-			continue
+			return nil, output.NewCommandErrorf("Error updating record [%d]: %s", recordID, err)
 		}
 
 		zoneRecord, err := service.GetZoneRecord(args[0], id)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error retrieving updated record [%d]: %s", recordID, err)
-			continue
+			return nil, output.NewCommandErrorf("Error retrieving updated record [%d]: %s", recordID, err)
 		}
 
-		zoneRecords = append(zoneRecords, zoneRecord)
+		return zoneRecord, nil
+	})
+
+	for _, result := range results {
+		zoneRecords = append(zoneRecords, result.(safedns.Record))
 	}
 
 	return output.CommandOutput(cmd, OutputSafeDNSRecordsProvider(zoneRecords))
 }
 
 func safednsZoneRecordDeleteCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "delete <zone: name> <record: id>...",
 		Short:   "Removes a zone record",
 		Long:    "This command removes one or more zone records",
@@ -292,23 +306,42 @@ func safednsZoneRecordDeleteCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			safednsZoneRecordDelete(c.SafeDNSService(), cmd, args)
-			return nil
+			return safednsZoneRecordDelete(c.SafeDNSService(), cmd, args)
 		},
 	}
+
+	helper.AddParallelFlag(cmd)
+
+	return cmd
 }
 
-func safednsZoneRecordDelete(service safedns.SafeDNSService, cmd *cobra.Command, args []string) {
-	for _, arg := range args[1:] {
+func safednsZoneRecordDelete(service safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
+	err := input.CommandConfirmResources(cmd, fmt.Sprintf("The following records will be removed from zone [%s]", args[0]), args[1:], func(id string) (string, error) {
+		recordID, err := strconv.Atoi(id)
+		if err != nil {
+			return "", err
+		}
+
+		record, err := service.GetZoneRecord(args[0], recordID)
+		return fmt.Sprintf("%s %s %s", record.Name, record.Type, record.Content), err
+	})
+	if err != nil {
+		return err
+	}
+
+	helper.ForEachArg(cmd, args[1:], func(arg string) (interface{}, error) {
 		recordID, err := strconv.Atoi(arg)
 		if err != nil {
-			output.OutputWithErrorLevelf("Invalid record ID [%s]", arg)
-			continue
+			return nil, output.NewCommandErrorf("Invalid record ID [%s]", arg)
 		}
 
 		err = service.DeleteZoneRecord(args[0], recordID)
 		if err != nil {
-			output.OutputWithErrorLevelf("Error removing record [%d]: %s", recordID, err)
+			return nil, output.NewCommandErrorf("Error removing record [%d]: %s", recordID, err)
 		}
-	}
+
+		return nil, nil
+	})
+
+	return nil
 }
@@ -2,6 +2,8 @@ package safedns
 
 import (
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/ukfast/sdk-go/pkg/connection"
@@ -10,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/internal/pkg/input"
 	"github.com/ukfast/cli/test/mocks"
 	"github.com/ukfast/cli/test/test_output"
 	"github.com/ukfast/sdk-go/pkg/service/safedns"
@@ -337,4 +340,22 @@ func Test_safednsZoneDelete(t *testing.T) {
 			safednsZoneDelete(service, &cobra.Command{}, []string{"testdomain1.com"})
 		})
 	})
+
+	t.Run("ConfirmationDeclined_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockSafeDNSService(mockCtrl)
+
+		oldIsTerminal, oldReader := input.IsTerminal, input.InputReader
+		defer func() { input.IsTerminal, input.InputReader = oldIsTerminal, oldReader }()
+		input.IsTerminal = func() bool { return true }
+		input.InputReader = func() io.Reader { return strings.NewReader("n\n") }
+
+		test_output.AssertErrorOutput(t, "The following zones will be removed:\n  - testdomain1.com\nContinue? [y/N]: ", func() {
+			err := safednsZoneDelete(service, &cobra.Command{}, []string{"testdomain1.com"})
+
+			assert.Equal(t, input.ErrConfirmationDeclined, err)
+		})
+	})
 }
@@ -1,26 +1,29 @@
 module github.com/ukfast/cli
 
-go 1.13
+go 1.15
 
 require (
+	github.com/antonmedv/expr v1.9.0
 	github.com/blang/semver v3.5.1+incompatible
 	github.com/golang/mock v1.4.4
 	github.com/google/uuid v1.1.1
 	github.com/guptarohit/asciigraph v0.4.2-0.20191006150553-f9506970428c
 	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334
-	github.com/mattn/go-runewidth v0.0.4 // indirect
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/olekukonko/tablewriter v0.0.1
 	github.com/pkg/browser v0.0.0-20180916011732-0a3d74bf9ce4
 	github.com/rhysd/go-github-selfupdate v1.1.0
 	github.com/ryanuber/go-glob v1.0.0
 	github.com/spf13/afero v1.2.1
-	github.com/spf13/cobra v0.0.5
+	github.com/spf13/cobra v1.7.0
+	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.3.2
 	github.com/stretchr/testify v1.6.1
 	github.com/ukfast/sdk-go v1.4.10
-	golang.org/x/sys v0.0.0-20190507160741-ecd444e8653b // indirect
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	golang.org/x/sys v0.0.0-20200212091648-12a6c2dcc1e4 // indirect
 	gopkg.in/go-playground/assert.v1 v1.2.1
+	gopkg.in/yaml.v2 v2.2.2
 	k8s.io/client-go v11.0.0+incompatible
 )
 
@@ -0,0 +1,244 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+// ArgsColumn is the name of the column containing arguments, which are appended to any arguments
+// provided on the command line, e.g. the record ID for 'safedns zone record update'
+const ArgsColumn = "args"
+
+// Fs is the filesystem input and resume files are read from and written to
+var Fs = afero.NewOsFs()
+
+var batchFlags = map[string]bool{
+	"from-file":         true,
+	"continue-on-error": true,
+	"resume-file":       true,
+}
+
+// Enable adds the --from-file, --continue-on-error and --resume-file flags to cmd. When --from-file
+// is specified, cmd is invoked once for each row of the given CSV or JSON file, with each column
+// mapped to the flag of the same name. Flags and arguments provided on the command line apply to
+// all rows. Enable must be invoked once all other flags have been added to cmd, as required flags
+// are validated per row rather than by cobra
+func Enable(cmd *cobra.Command) {
+	cmd.Flags().String("from-file", "", "Path to CSV or JSON file containing a row per invocation, with columns named after flags (and optional 'args' column)")
+	cmd.Flags().Bool("continue-on-error", false, "Specifies remaining rows should be processed after a row fails (used with --from-file)")
+	cmd.Flags().String("resume-file", "", "Path to write rows which failed or weren't processed to, for use with --from-file (used with --from-file)")
+
+	required := removeRequiredFlags(cmd)
+	validateArgs := cmd.Args
+	runE := cmd.RunE
+
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		// Arguments are validated per row when using --from-file, as rows may provide arguments
+		if cmd.Flags().Changed("from-file") || validateArgs == nil {
+			return nil
+		}
+
+		return validateArgs(c, args)
+	}
+
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !c.Flags().Changed("from-file") {
+			err := validateRequiredFlags(c, required)
+			if err != nil {
+				return err
+			}
+
+			return runE(c, args)
+		}
+
+		return run(c, args, validateArgs, runE, required)
+	}
+}
+
+// removeRequiredFlags removes the cobra required annotation from flags of cmd, returning the names
+// of the flags which were required
+func removeRequiredFlags(cmd *cobra.Command) []string {
+	var required []string
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if _, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]; ok {
+			delete(flag.Annotations, cobra.BashCompOneRequiredFlag)
+			required = append(required, flag.Name)
+		}
+	})
+
+	return required
+}
+
+func validateRequiredFlags(cmd *cobra.Command, required []string) error {
+	var missing []string
+	for _, name := range required {
+		if !cmd.Flags().Changed(name) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return clierrors.NewErrValidation(fmt.Errorf("required flag(s) \"%s\" not set", strings.Join(missing, `", "`)))
+	}
+
+	return nil
+}
+
+func run(cmd *cobra.Command, args []string, validateArgs cobra.PositionalArgs, runE func(cmd *cobra.Command, args []string) error, required []string) error {
+	path, _ := cmd.Flags().GetString("from-file")
+	in, err := readInput(path)
+	if err != nil {
+		return err
+	}
+
+	err = validateColumns(cmd, in.columns)
+	if err != nil {
+		return err
+	}
+
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+	var remaining []*row
+	succeeded := 0
+	failed := 0
+	for i, r := range in.rows {
+		errorCount := output.ErrorCount()
+		err := invokeRow(cmd, args, r, validateArgs, runE, required)
+		if err == nil && output.ErrorCount() == errorCount {
+			succeeded++
+			continue
+		}
+
+		outputRowError(r, err)
+		failed++
+		remaining = append(remaining, r)
+		if !continueOnError {
+			remaining = append(remaining, in.rows[i+1:]...)
+			break
+		}
+	}
+
+	output.Errorf("Processed rows from [%s]: %d succeeded, %d failed, %d skipped", path, succeeded, failed, len(remaining)-failed)
+
+	resumePath, _ := cmd.Flags().GetString("resume-file")
+	if resumePath == "" {
+		return nil
+	}
+
+	return writeResumeFile(resumePath, in, remaining)
+}
+
+// validateColumns returns an error if any of columns don't correspond to a flag of cmd
+func validateColumns(cmd *cobra.Command, columns []string) error {
+	for _, column := range columns {
+		if column == ArgsColumn {
+			continue
+		}
+		if cmd.NonInheritedFlags().Lookup(column) == nil || batchFlags[column] {
+			return clierrors.NewErrValidation(fmt.Errorf("Invalid column [%s], expected flag name or '%s'", column, ArgsColumn))
+		}
+	}
+
+	return nil
+}
+
+// invokeRow invokes runE with the flags of cmd set from r, restoring the flags once complete
+func invokeRow(cmd *cobra.Command, args []string, r *row, validateArgs cobra.PositionalArgs, runE func(cmd *cobra.Command, args []string) error, required []string) error {
+	restore, err := setFlags(cmd, r)
+	defer restore()
+	if err != nil {
+		return clierrors.NewErrValidation(err)
+	}
+
+	rowArgs := append(append([]string{}, args...), r.values[ArgsColumn]...)
+	if validateArgs != nil {
+		err := validateArgs(cmd, rowArgs)
+		if err != nil {
+			return clierrors.NewErrValidation(err)
+		}
+	}
+
+	err = validateRequiredFlags(cmd, required)
+	if err != nil {
+		return err
+	}
+
+	return runE(cmd, rowArgs)
+}
+
+// setFlags sets flags of cmd from the columns of r, returning a func to restore them to their
+// previous values
+func setFlags(cmd *cobra.Command, r *row) (func(), error) {
+	var restores []func()
+	restore := func() {
+		for _, fn := range restores {
+			fn()
+		}
+	}
+
+	for _, column := range r.columns {
+		values := r.values[column]
+		if column == ArgsColumn || len(values) == 0 {
+			continue
+		}
+
+		flag := cmd.Flags().Lookup(column)
+		restores = append(restores, saveFlag(flag))
+
+		// Values set on slice flags are appended once the flag has been set, so clear any
+		// existing values first
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace([]string{})
+		}
+
+		for _, value := range values {
+			err := cmd.Flags().Set(column, value)
+			if err != nil {
+				return restore, err
+			}
+		}
+	}
+
+	return restore, nil
+}
+
+// saveFlag returns a func which restores flag to its current value
+func saveFlag(flag *pflag.Flag) func() {
+	changed := flag.Changed
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		values := slice.GetSlice()
+		return func() {
+			slice.Replace(values)
+			flag.Changed = changed
+		}
+	}
+
+	value := flag.Value.String()
+	return func() {
+		flag.Value.Set(value)
+		flag.Changed = changed
+	}
+}
+
+// outputRowError outputs an error for row r. err is nil where the command output its own errors
+// rather than returning an error. The row number is recorded as the error row rather than resource
+// ID, so isn't considered when determining partial failure
+func outputRowError(r *row, err error) {
+	e := output.CommandError{ExitCode: clierrors.ExitCodeError}
+	message := fmt.Sprintf("Error processing row [%d]", r.number)
+	if err != nil {
+		e = output.NewCommandError(err)
+		message = fmt.Sprintf("%s: %s", message, err)
+	}
+
+	e.Message = message
+	e.Operation = "processing row"
+	e.Row = r.number
+	output.OutputCommandError(e)
+}
@@ -0,0 +1,262 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/cli/test"
+)
+
+// setTestFs overrides the filesystem with an in-memory filesystem containing files, returning a
+// func to restore it
+func setTestFs(files map[string]string) func() {
+	oldFs := Fs
+	Fs = afero.NewMemMapFs()
+	for path, content := range files {
+		afero.WriteFile(Fs, path, []byte(content), 0644)
+	}
+
+	return func() {
+		Fs = oldFs
+	}
+}
+
+// newTestCmd returns a command with batch enabled, which records each invocation as a string of
+// its arguments and flags. Invocations with name 'fail' return an error
+func newTestCmd(invocations *[]string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "create <zone: name>",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("Missing zone")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, _ := cmd.Flags().GetString("name")
+			priority, _ := cmd.Flags().GetInt("priority")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			if name == "fail" {
+				return errors.New("Error creating record: test error")
+			}
+
+			*invocations = append(*invocations, fmt.Sprintf("%s name=%s priority=%d(%t) tags=%s",
+				strings.Join(args, ","), name, priority, cmd.Flags().Changed("priority"), strings.Join(tags, "|")))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("name", "", "")
+	cmd.MarkFlagRequired("name")
+	cmd.Flags().Int("priority", 0, "")
+	cmd.Flags().StringSlice("tag", []string{}, "")
+
+	Enable(cmd)
+
+	return cmd
+}
+
+func executeTestCmd(t *testing.T, args ...string) (invocations []string, stdErr string, err error) {
+	stdErr = test.CatchStdErr(t, func() {
+		cmd := newTestCmd(&invocations)
+		cmd.SetArgs(args)
+		err = cmd.Execute()
+	})
+
+	return invocations, stdErr, err
+}
+
+func TestEnable(t *testing.T) {
+	t.Run("WithoutFromFile_InvokesOnce", func(t *testing.T) {
+		invocations, _, err := executeTestCmd(t, "example.com", "--name", "test")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"example.com name=test priority=0(false) tags="}, invocations)
+	})
+
+	t.Run("WithoutFromFile_MissingRequiredFlag_ReturnsError", func(t *testing.T) {
+		_, _, err := executeTestCmd(t, "example.com")
+
+		assert.Equal(t, "required flag(s) \"name\" not set", err.Error())
+	})
+
+	t.Run("WithoutFromFile_MissingArgs_ReturnsError", func(t *testing.T) {
+		_, _, err := executeTestCmd(t, "--name", "test")
+
+		assert.Equal(t, "Missing zone", err.Error())
+	})
+
+	t.Run("CSV_InvokesPerRowWithFlagsRestored", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "name,priority,tag\nrow1,10,\"a,b\"\nrow2,,\nrow3,30,c\n",
+		})()
+
+		invocations, stdErr, err := executeTestCmd(t, "example.com", "--tag", "default", "--from-file", "/rows.csv")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"example.com name=row1 priority=10(true) tags=a|b",
+			"example.com name=row2 priority=0(false) tags=default",
+			"example.com name=row3 priority=30(true) tags=c",
+		}, invocations)
+		assert.Equal(t, "Processed rows from [/rows.csv]: 3 succeeded, 0 failed, 0 skipped\n", stdErr)
+	})
+
+	t.Run("JSON_InvokesPerRow", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.json": `[{"args": "example.com", "name": "row1", "priority": 10, "tag": ["a", "b"]}, {"args": ["example.org"], "name": "row2"}]`,
+		})()
+
+		invocations, _, err := executeTestCmd(t, "--from-file", "/rows.json")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"example.com name=row1 priority=10(true) tags=a|b",
+			"example.org name=row2 priority=0(false) tags=",
+		}, invocations)
+	})
+
+	t.Run("ArgsColumn_AppendedToArgs", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "args,name\n,row1\nexample.com,row2\n",
+		})()
+
+		invocations, stdErr, err := executeTestCmd(t, "--from-file", "/rows.csv", "--continue-on-error")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"example.com name=row2 priority=0(false) tags="}, invocations)
+		assert.Contains(t, stdErr, "Error processing row [1]: Missing zone\n")
+	})
+
+	t.Run("RowFailure_StopsAndWritesResumeFile", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "name,priority\nrow1,10\nfail,20\nrow3,30\n",
+		})()
+
+		invocations, stdErr, err := executeTestCmd(t, "example.com", "--from-file", "/rows.csv", "--resume-file", "/resume.csv")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"example.com name=row1 priority=10(true) tags="}, invocations)
+		assert.Equal(t, "Error processing row [2]: Error creating record: test error\n"+
+			"Processed rows from [/rows.csv]: 1 succeeded, 1 failed, 1 skipped\n", stdErr)
+
+		content, _ := afero.ReadFile(Fs, "/resume.csv")
+		assert.Equal(t, "name,priority\nfail,20\nrow3,30\n", string(content))
+	})
+
+	t.Run("RowFailure_ContinueOnError_ProcessesRemainingRows", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.json": `[{"name": "row1"}, {"name": "fail", "priority": 20}, {"priority": 30}, {"name": "row4"}]`,
+		})()
+
+		invocations, stdErr, err := executeTestCmd(t, "example.com", "--from-file", "/rows.json", "--continue-on-error", "--resume-file", "/resume.json")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"example.com name=row1 priority=0(false) tags=",
+			"example.com name=row4 priority=0(false) tags=",
+		}, invocations)
+		assert.Contains(t, stdErr, "Error processing row [3]: required flag(s) \"name\" not set\n")
+		assert.Contains(t, stdErr, "2 succeeded, 2 failed, 0 skipped")
+
+		content, _ := afero.ReadFile(Fs, "/resume.json")
+		assert.Equal(t, "[\n  {\n    \"name\": \"fail\",\n    \"priority\": 20\n  },\n  {\n    \"priority\": 30\n  }\n]\n", string(content))
+	})
+
+	t.Run("CommandOutputsErrors_RowFails", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "name\nrow1\n",
+		})()
+
+		cmd := &cobra.Command{
+			RunE: func(cmd *cobra.Command, args []string) error {
+				output.OutputWithErrorLevel("Error updating record [1]: test error")
+				return nil
+			},
+		}
+		cmd.Flags().String("name", "", "")
+		Enable(cmd)
+		cmd.SetArgs([]string{"--from-file", "/rows.csv"})
+
+		stdErr := test.CatchStdErr(t, func() {
+			cmd.Execute()
+		})
+
+		assert.Equal(t, "Error updating record [1]: test error\nError processing row [1]\n"+
+			"Processed rows from [/rows.csv]: 0 succeeded, 1 failed, 0 skipped\n", stdErr)
+	})
+
+	t.Run("RowFailure_JSONErrorFormat_OutputsRow", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "name\nrow1\n",
+		})()
+
+		output.SetErrorFormat(output.ErrorFormatJSON)
+		defer output.SetErrorFormat(output.ErrorFormatText)
+
+		cmd := &cobra.Command{
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.New("test error")
+			},
+		}
+		cmd.Flags().String("name", "", "")
+		Enable(cmd)
+		cmd.SetArgs([]string{"--from-file", "/rows.csv"})
+
+		stdErr := test.CatchStdErr(t, func() {
+			cmd.Execute()
+		})
+
+		assert.Contains(t, stdErr, `{"message":"Error processing row [1]: test error","row":1,"operation":"processing row","exit_code":1}`)
+	})
+
+	t.Run("AllRowsSucceed_RemovesResumeFile", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv":   "name\nrow1\n",
+			"/resume.csv": "name\nrow1\n",
+		})()
+
+		_, _, err := executeTestCmd(t, "example.com", "--from-file", "/rows.csv", "--resume-file", "/resume.csv")
+
+		assert.Nil(t, err)
+		exists, _ := afero.Exists(Fs, "/resume.csv")
+		assert.False(t, exists)
+	})
+
+	t.Run("InvalidColumn_ReturnsError", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "name,invalid\nrow1,test\n",
+		})()
+
+		invocations, _, err := executeTestCmd(t, "example.com", "--from-file", "/rows.csv")
+
+		assert.Empty(t, invocations)
+		assert.Equal(t, "Invalid column [invalid], expected flag name or 'args'", err.Error())
+	})
+
+	t.Run("InvalidFlagValue_RowFails", func(t *testing.T) {
+		defer setTestFs(map[string]string{
+			"/rows.csv": "name,priority\nrow1,high\n",
+		})()
+
+		invocations, stdErr, _ := executeTestCmd(t, "example.com", "--from-file", "/rows.csv")
+
+		assert.Empty(t, invocations)
+		assert.Contains(t, stdErr, "Error processing row [1]: invalid argument \"high\" for \"--priority\" flag")
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		defer setTestFs(nil)()
+
+		_, _, err := executeTestCmd(t, "example.com", "--from-file", "/missing.csv")
+
+		assert.Contains(t, err.Error(), "Error reading file [/missing.csv]")
+	})
+}
@@ -0,0 +1,232 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+// row is a single row of input, with values keyed by column. raw holds the row as read, so that it
+// can be written to a resume file unchanged
+type row struct {
+	number  int
+	columns []string
+	values  map[string][]string
+	raw     interface{}
+}
+
+type input struct {
+	format  string
+	columns []string
+	rows    []*row
+}
+
+// readInput reads rows from the file at path. Files with a '.json' extension are expected to
+// contain an array of objects, with all other files read as CSV with a header row
+func readInput(path string) (*input, error) {
+	content, err := afero.ReadFile(Fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading file [%s]: %s", path, err)
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		in, err := readJSONInput(content)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing JSON file [%s]: %s", path, err)
+		}
+
+		return in, nil
+	}
+
+	in, err := readCSVInput(content)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing CSV file [%s]: %s", path, err)
+	}
+
+	return in, nil
+}
+
+func readCSVInput(content []byte) (*input, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header row")
+	}
+
+	in := &input{format: formatCSV}
+	for _, column := range records[0] {
+		in.columns = append(in.columns, strings.TrimSpace(column))
+	}
+
+	for i, record := range records[1:] {
+		r := &row{number: i + 1, columns: in.columns, values: make(map[string][]string), raw: record}
+		for j, value := range record {
+			if value == "" {
+				continue
+			}
+
+			if in.columns[j] == ArgsColumn {
+				r.values[ArgsColumn] = strings.Fields(value)
+				continue
+			}
+
+			r.values[in.columns[j]] = []string{value}
+		}
+
+		in.rows = append(in.rows, r)
+	}
+
+	return in, nil
+}
+
+func readJSONInput(content []byte) (*input, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var objects []map[string]interface{}
+	err := decoder.Decode(&objects)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &input{format: formatJSON}
+	seen := make(map[string]bool)
+	for i, object := range objects {
+		r := &row{number: i + 1, values: make(map[string][]string), raw: object}
+		for column, value := range object {
+			values, err := jsonValues(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for [%s] in row [%d]: %s", column, r.number, err)
+			}
+
+			if column == ArgsColumn && len(values) == 1 {
+				values = strings.Fields(values[0])
+			}
+
+			r.columns = append(r.columns, column)
+			r.values[column] = values
+			if !seen[column] {
+				seen[column] = true
+				in.columns = append(in.columns, column)
+			}
+		}
+
+		sort.Strings(r.columns)
+		in.rows = append(in.rows, r)
+	}
+
+	sort.Strings(in.columns)
+
+	return in, nil
+}
+
+// jsonValues returns the flag values for JSON value v. Arrays result in a value per element, with
+// each element set in turn, as with repeating a flag on the command line
+func jsonValues(v interface{}) ([]string, error) {
+	switch value := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case json.Number, bool:
+		return []string{fmt.Sprint(value)}, nil
+	case []interface{}:
+		var values []string
+		for _, element := range value {
+			elementValues, err := jsonValues(element)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, elementValues...)
+		}
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+// writeResumeFile writes rows to path in the format of in, so that they can be processed again
+// with --from-file. If there are no rows, any existing file at path is removed, so that a stale
+// resume file isn't mistakenly processed again
+func writeResumeFile(path string, in *input, rows []*row) error {
+	if len(rows) == 0 {
+		if exists, _ := afero.Exists(Fs, path); !exists {
+			return nil
+		}
+
+		err := Fs.Remove(path)
+		if err != nil {
+			return fmt.Errorf("Error removing resume file [%s]: %s", path, err)
+		}
+
+		return nil
+	}
+
+	var content []byte
+	var err error
+	if in.format == formatJSON {
+		content, err = marshalJSONRows(rows)
+	} else {
+		content, err = marshalCSVRows(in.columns, rows)
+	}
+	if err != nil {
+		return fmt.Errorf("Error writing resume file [%s]: %s", path, err)
+	}
+
+	err = afero.WriteFile(Fs, path, content, 0644)
+	if err != nil {
+		return fmt.Errorf("Error writing resume file [%s]: %s", path, err)
+	}
+
+	return nil
+}
+
+func marshalJSONRows(rows []*row) ([]byte, error) {
+	var raw []interface{}
+	for _, r := range rows {
+		raw = append(raw, r.raw)
+	}
+
+	content, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+func marshalCSVRows(columns []string, rows []*row) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+
+	err := writer.Write(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rows {
+		err = writer.Write(r.raw.([]string))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
@@ -0,0 +1,121 @@
+package clierrors
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Exit codes returned by the CLI
+const (
+	// ExitCodeError is returned for general errors
+	ExitCodeError = 1
+	// ExitCodeValidation is returned for invalid arguments/flags, and API validation errors
+	ExitCodeValidation = 2
+	// ExitCodeAuth is returned for API authentication and authorisation failures
+	ExitCodeAuth = 3
+	// ExitCodeNotFound is returned when a resource can't be found
+	ExitCodeNotFound = 4
+	// ExitCodeTimeout is returned when waiting for a command to complete times out
+	ExitCodeTimeout = 5
+	// ExitCodePartialFailure is returned when a command operating on multiple resources fails
+	// for some, but not all, of the resources
+	ExitCodePartialFailure = 6
+)
+
+var statusCodeRegexp = regexp.MustCompile(`unexpected status code \((\d+)\)`)
+var detailRegexp = regexp.MustCompile(`(?:detail|message)="([^"]*)"`)
+
+// ErrCommandTimeout indicates waiting for a command to complete timed out
+type ErrCommandTimeout struct{}
+
+func (e *ErrCommandTimeout) Error() string {
+	return "Timed out waiting for command"
+}
+
+// ErrValidation indicates a command was invoked with invalid arguments
+type ErrValidation struct {
+	Err error
+}
+
+func (e *ErrValidation) Error() string { return e.Err.Error() }
+
+func (e *ErrValidation) Unwrap() error { return e.Err }
+
+func NewErrValidation(err error) *ErrValidation {
+	return &ErrValidation{Err: err}
+}
+
+// ExitCode returns the exit code for err
+func ExitCode(err error) int {
+	var timeoutErr *ErrCommandTimeout
+	var validationErr *ErrValidation
+	var flagErr *ErrInvalidFlagValue
+	switch {
+	case errors.As(err, &timeoutErr):
+		return ExitCodeTimeout
+	case errors.As(err, &validationErr), errors.As(err, &flagErr):
+		return ExitCodeValidation
+	case isNotFoundError(err):
+		return ExitCodeNotFound
+	}
+
+	switch StatusCode(err) {
+	case 400, 422:
+		return ExitCodeValidation
+	case 401, 403:
+		return ExitCodeAuth
+	case 404:
+		return ExitCodeNotFound
+	}
+
+	return ExitCodeError
+}
+
+// StatusCode returns the HTTP status code of the API response err was returned for, or 0 if
+// err wasn't caused by an unexpected API response
+func StatusCode(err error) int {
+	matches := statusCodeRegexp.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return 0
+	}
+
+	code, _ := strconv.Atoi(matches[1])
+	return code
+}
+
+// Detail returns the API error details contained within err, or an empty string if err wasn't
+// caused by an unexpected API response
+func Detail(err error) string {
+	message := err.Error()
+	if !statusCodeRegexp.MatchString(message) {
+		return ""
+	}
+
+	var details []string
+	for _, matches := range detailRegexp.FindAllStringSubmatch(message, -1) {
+		if matches[1] != "" {
+			details = append(details, matches[1])
+		}
+	}
+
+	return strings.Join(details, "; ")
+}
+
+// isNotFoundError returns true if err (or any error it wraps) is a resource not found error, as
+// returned by SDK services for 404 responses (e.g. ecloud.InstanceNotFoundError)
+func isNotFoundError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		t := reflect.TypeOf(err)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if strings.HasSuffix(t.Name(), "NotFoundError") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,75 @@
+package clierrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testResourceNotFoundError struct{}
+
+func (e *testResourceNotFoundError) Error() string {
+	return "resource not found"
+}
+
+func TestExitCode(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		err := fmt.Errorf("Error waiting for instance: %w", &ErrCommandTimeout{})
+
+		assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		assert.Equal(t, ExitCodeValidation, ExitCode(NewErrValidation(errors.New("Missing instance"))))
+		assert.Equal(t, ExitCodeValidation, ExitCode(NewErrInvalidFlagValue("mode", "invalid", nil)))
+	})
+
+	t.Run("NotFoundErrorType", func(t *testing.T) {
+		assert.Equal(t, ExitCodeNotFound, ExitCode(&testResourceNotFoundError{}))
+	})
+
+	t.Run("StatusCodes", func(t *testing.T) {
+		codes := map[int]int{
+			400: ExitCodeValidation,
+			401: ExitCodeAuth,
+			403: ExitCodeAuth,
+			404: ExitCodeNotFound,
+			422: ExitCodeValidation,
+			500: ExitCodeError,
+		}
+
+		for status, code := range codes {
+			err := fmt.Errorf("Error retrieving instances: unexpected status code (%d): ", status)
+			assert.Equal(t, code, ExitCode(err), "status %d", status)
+		}
+	})
+
+	t.Run("GeneralError", func(t *testing.T) {
+		assert.Equal(t, ExitCodeError, ExitCode(errors.New("test error")))
+	})
+}
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, 422, StatusCode(errors.New("unexpected status code (422): title=\"Validation Error\"")))
+	assert.Equal(t, 0, StatusCode(errors.New("test error")))
+}
+
+func TestDetail(t *testing.T) {
+	t.Run("APIErrors_ReturnsDetails", func(t *testing.T) {
+		err := errors.New(`unexpected status code (422): title="Validation Error", detail="name is required", status="422", source="name"; title="Validation Error", detail="vpc_id is invalid", status="422", source="vpc_id"`)
+
+		assert.Equal(t, "name is required; vpc_id is invalid", Detail(err))
+	})
+
+	t.Run("Message_ReturnsMessage", func(t *testing.T) {
+		err := errors.New(`unexpected status code (401): message="Unauthenticated"`)
+
+		assert.Equal(t, "Unauthenticated", Detail(err))
+	})
+
+	t.Run("NonAPIError_ReturnsEmpty", func(t *testing.T) {
+		assert.Equal(t, "", Detail(errors.New(`detail="test"`)))
+	})
+}
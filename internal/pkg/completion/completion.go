@@ -0,0 +1,178 @@
+package completion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/credential"
+)
+
+// DefaultCacheTTLSeconds is the default duration in seconds for which completion results are cached
+const DefaultCacheTTLSeconds = 60
+
+// Resource represents a resource offered as a completion
+type Resource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListFunc returns the resources to offer as completions
+type ListFunc func() ([]Resource, error)
+
+// CobraCompletionFunc is the signature of cobra argument and flag completion funcs
+type CobraCompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// Fs is the filesystem used for caching completion results
+var Fs = afero.NewOsFs()
+
+// CacheDir returns the directory completion results are cached within
+var CacheDir = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "ukfast", "completion"), nil
+}
+
+// ResourceCompletionFunc returns a cobra completion func which completes the IDs of resources
+// returned by list, with resource names as descriptions. Resources are cached on disk per config
+// context under key for the duration specified by the 'completion_cache_ttl_seconds' config. IDs
+// already provided as arguments aren't offered again
+func ResourceCompletionFunc(key string, list ListFunc) CobraCompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// Flags aren't parsed until after config is initialised when completing, so apply the
+		// context flag here
+		if flag := cmd.Flags().Lookup("context"); flag != nil && flag.Changed {
+			config.SetContextOverride(flag.Value.String())
+		}
+
+		resources, err := getResources(key, list)
+		if errors.Is(err, credential.ErrPassphraseRequired) {
+			// Completion must never prompt, so no completions are offered where the API key can't be
+			// read without the credentials file passphrase
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("Failed to retrieve completions for [%s]: %s", key, err), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveError
+		}
+
+		return Completions(resources, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Completions returns completions in cobra format ('id\tname') for resources with IDs prefixed
+// with toComplete, excluding those in args
+func Completions(resources []Resource, args []string, toComplete string) []string {
+	provided := make(map[string]bool)
+	for _, arg := range args {
+		provided[arg] = true
+	}
+
+	var completions []string
+	for _, resource := range resources {
+		if provided[resource.ID] || !strings.HasPrefix(resource.ID, toComplete) {
+			continue
+		}
+
+		if resource.Name == "" || resource.Name == resource.ID {
+			completions = append(completions, resource.ID)
+			continue
+		}
+
+		completions = append(completions, fmt.Sprintf("%s\t%s", resource.ID, strings.Replace(resource.Name, "\n", " ", -1)))
+	}
+
+	return completions
+}
+
+func getResources(key string, list ListFunc) ([]Resource, error) {
+	ttl := time.Duration(DefaultCacheTTLSeconds) * time.Second
+	if config.IsSet("completion_cache_ttl_seconds") {
+		ttl = time.Duration(config.GetInt("completion_cache_ttl_seconds")) * time.Second
+	}
+
+	path, err := cachePath(key)
+	if err != nil || ttl <= 0 {
+		return list()
+	}
+
+	resources, ok := readCache(path, ttl)
+	if ok {
+		return resources, nil
+	}
+
+	resources, err = list()
+	if err != nil {
+		return nil, err
+	}
+
+	writeCache(path, resources)
+
+	return resources, nil
+}
+
+// cachePath returns the path of the cache file for key within the current context
+func cachePath(key string) (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	context := config.GetCurrentContextName()
+	if context == "" {
+		context = "default"
+	}
+
+	return filepath.Join(dir, url.PathEscape(context), key+".json"), nil
+}
+
+// readCache returns the resources cached at path, and a bool indicating whether the cache
+// exists and hasn't expired
+func readCache(path string, ttl time.Duration) ([]Resource, bool) {
+	info, err := Fs.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil, false
+	}
+
+	content, err := afero.ReadFile(Fs, path)
+	if err != nil {
+		return nil, false
+	}
+
+	var resources []Resource
+	if json.Unmarshal(content, &resources) != nil {
+		return nil, false
+	}
+
+	return resources, true
+}
+
+// writeCache caches resources at path. Failures are ignored, as caching is best-effort
+func writeCache(path string, resources []Resource) {
+	content, err := json.Marshal(resources)
+	if err != nil {
+		return
+	}
+
+	err = Fs.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("Failed to create completion cache directory: %s", err), false)
+		return
+	}
+
+	err = afero.WriteFile(Fs, path, content, 0600)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("Failed to write completion cache: %s", err), false)
+	}
+}
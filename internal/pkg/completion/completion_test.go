@@ -0,0 +1,152 @@
+package completion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/credential"
+)
+
+// setTestCache overrides the cache filesystem and directory, returning a func to restore them
+func setTestCache() func() {
+	oldFs := Fs
+	oldCacheDir := CacheDir
+
+	Fs = afero.NewMemMapFs()
+	CacheDir = func() (string, error) {
+		return "/cache", nil
+	}
+
+	return func() {
+		Fs = oldFs
+		CacheDir = oldCacheDir
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	resources := []Resource{
+		{ID: "i-abcdef12", Name: "web01"},
+		{ID: "i-abcdef34", Name: "i-abcdef34"},
+		{ID: "i-12345678", Name: "db01\nprimary"},
+	}
+
+	t.Run("NoPrefix_ReturnsAll", func(t *testing.T) {
+		completions := Completions(resources, []string{}, "")
+
+		assert.Equal(t, []string{"i-abcdef12\tweb01", "i-abcdef34", "i-12345678\tdb01 primary"}, completions)
+	})
+
+	t.Run("Prefix_ReturnsMatching", func(t *testing.T) {
+		completions := Completions(resources, []string{}, "i-abc")
+
+		assert.Equal(t, []string{"i-abcdef12\tweb01", "i-abcdef34"}, completions)
+	})
+
+	t.Run("ExistingArgs_Excluded", func(t *testing.T) {
+		completions := Completions(resources, []string{"i-abcdef12"}, "i-abc")
+
+		assert.Equal(t, []string{"i-abcdef34"}, completions)
+	})
+}
+
+func TestResourceCompletionFunc(t *testing.T) {
+	t.Run("Uncached_ListsAndCaches", func(t *testing.T) {
+		defer setTestCache()()
+		defer viper.Reset()
+
+		calls := 0
+		fn := ResourceCompletionFunc("test_resources", func() ([]Resource, error) {
+			calls++
+			return []Resource{{ID: "res-1", Name: "resource 1"}}, nil
+		})
+
+		completions, directive := fn(&cobra.Command{}, []string{}, "")
+		assert.Equal(t, []string{"res-1\tresource 1"}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+		completions, _ = fn(&cobra.Command{}, []string{}, "")
+		assert.Equal(t, []string{"res-1\tresource 1"}, completions)
+		assert.Equal(t, 1, calls)
+
+		exists, _ := afero.Exists(Fs, "/cache/default/test_resources.json")
+		assert.True(t, exists)
+	})
+
+	t.Run("CachedPerContext", func(t *testing.T) {
+		defer setTestCache()()
+		defer viper.Reset()
+
+		afero.WriteFile(Fs, "/cache/default/test_resources.json", []byte(`[{"id":"res-default"}]`), 0600)
+		afero.WriteFile(Fs, "/cache/testcontext/test_resources.json", []byte(`[{"id":"res-context"}]`), 0600)
+		viper.Set("current_context", "testcontext")
+
+		fn := ResourceCompletionFunc("test_resources", func() ([]Resource, error) {
+			t.Fatal("list shouldn't be invoked")
+			return nil, nil
+		})
+
+		completions, _ := fn(&cobra.Command{}, []string{}, "")
+		assert.Equal(t, []string{"res-context"}, completions)
+	})
+
+	t.Run("ExpiredCache_Lists", func(t *testing.T) {
+		defer setTestCache()()
+		defer viper.Reset()
+
+		afero.WriteFile(Fs, "/cache/default/test_resources.json", []byte(`[{"id":"res-cached"}]`), 0600)
+		expired := time.Now().Add(-2 * time.Duration(DefaultCacheTTLSeconds) * time.Second)
+		Fs.Chtimes("/cache/default/test_resources.json", expired, expired)
+
+		fn := ResourceCompletionFunc("test_resources", func() ([]Resource, error) {
+			return []Resource{{ID: "res-listed"}}, nil
+		})
+
+		completions, _ := fn(&cobra.Command{}, []string{}, "")
+		assert.Equal(t, []string{"res-listed"}, completions)
+	})
+
+	t.Run("ZeroTTL_CachingDisabled", func(t *testing.T) {
+		defer setTestCache()()
+		defer viper.Reset()
+		viper.Set("completion_cache_ttl_seconds", 0)
+
+		fn := ResourceCompletionFunc("test_resources", func() ([]Resource, error) {
+			return []Resource{{ID: "res-1"}}, nil
+		})
+
+		fn(&cobra.Command{}, []string{}, "")
+
+		exists, _ := afero.Exists(Fs, "/cache/default/test_resources.json")
+		assert.False(t, exists)
+	})
+
+	t.Run("ListError_ReturnsErrorDirective", func(t *testing.T) {
+		defer setTestCache()()
+		defer viper.Reset()
+
+		fn := ResourceCompletionFunc("test_resources", func() ([]Resource, error) {
+			return nil, errors.New("test error")
+		})
+
+		completions, directive := fn(&cobra.Command{}, []string{}, "")
+		assert.Nil(t, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveError, directive)
+	})
+	t.Run("PassphraseRequired_ReturnsNoCompletions", func(t *testing.T) {
+		defer setTestCache()()
+		defer viper.Reset()
+
+		fn := ResourceCompletionFunc("test_resources", func() ([]Resource, error) {
+			return nil, credential.ErrPassphraseRequired
+		})
+
+		completions, directive := fn(&cobra.Command{}, []string{}, "")
+		assert.Nil(t, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+}
@@ -0,0 +1,292 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvPrefix is the prefix for environment variables overriding config keys, e.g. UKF_API_KEY
+	EnvPrefix = "ukf"
+	// ContextsKey is the config key under which named contexts are stored
+	ContextsKey = "contexts"
+	// CurrentContextKey is the config key holding the name of the active context
+	CurrentContextKey = "current_context"
+)
+
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceContext = "context"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+var contextOverride string
+var flagOverrides = make(map[string]bool)
+
+// SetContextOverride overrides the active context, e.g. via the global --context flag
+func SetContextOverride(name string) {
+	contextOverride = name
+}
+
+// GetCurrentContextName returns the name of the active context. The --context flag takes
+// precedence, followed by the UKF_CONTEXT environment variable, and finally the
+// current_context key from the config file. An empty string is returned when no context is active
+func GetCurrentContextName() string {
+	if contextOverride != "" {
+		return contextOverride
+	}
+	if context, ok := os.LookupEnv(EnvKey("context")); ok && context != "" {
+		return context
+	}
+
+	return viper.GetString(CurrentContextKey)
+}
+
+// GetCurrentContextSource returns the source of the active context name, or an empty
+// string when no context is active
+func GetCurrentContextSource() string {
+	if contextOverride != "" {
+		return SourceFlag
+	}
+	if context, ok := os.LookupEnv(EnvKey("context")); ok && context != "" {
+		return SourceEnv
+	}
+	if viper.InConfig(CurrentContextKey) {
+		return SourceFile
+	}
+
+	return ""
+}
+
+// ContextKey returns the full config key for key within given context
+func ContextKey(context string, key string) string {
+	return fmt.Sprintf("%s.%s.%s", ContextsKey, context, key)
+}
+
+// ContextExists returns true if context with given name is defined in config
+func ContextExists(name string) bool {
+	for _, context := range GetContextNames() {
+		if context == strings.ToLower(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetContextNames returns a sorted slice of defined context names
+func GetContextNames() []string {
+	var names []string
+	for name := range viper.GetStringMap(ContextsKey) {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// ValidateContextName returns an error if given name isn't a valid context name
+func ValidateContextName(name string) error {
+	if len(name) < 1 {
+		return errors.New("Context name must not be empty")
+	}
+	if strings.Contains(name, ".") {
+		return fmt.Errorf("Invalid context name [%s]: must not contain '.'", name)
+	}
+
+	return nil
+}
+
+// ValidateCurrentContext returns an error if a context is active, however isn't defined
+func ValidateCurrentContext() error {
+	context := GetCurrentContextName()
+	if context != "" && !ContextExists(context) {
+		return fmt.Errorf("Context [%s] not found", context)
+	}
+
+	return nil
+}
+
+// SetFlagOverride overrides the value of key, e.g. via a global flag. Overridden values take
+// precedence over all other sources
+func SetFlagOverride(key string, value interface{}) {
+	flagOverrides[key] = true
+	viper.Set(key, value)
+}
+
+// ResetFlagOverrides removes all values overridden via SetFlagOverride
+func ResetFlagOverrides() {
+	for key := range flagOverrides {
+		viper.Set(key, nil)
+	}
+	flagOverrides = make(map[string]bool)
+}
+
+// GetSource returns where the effective value for given key is sourced from. Values are resolved
+// in order of flag, environment variable, active context, config file and finally defaults. An
+// empty string is returned if key isn't set
+func GetSource(key string) string {
+	if flagOverrides[key] {
+		return SourceFlag
+	}
+	if _, ok := os.LookupEnv(EnvKey(key)); ok {
+		return SourceEnv
+	}
+
+	context := GetCurrentContextName()
+	if context != "" && viper.IsSet(ContextKey(context, key)) {
+		return SourceContext
+	}
+	if viper.InConfig(key) {
+		return SourceFile
+	}
+	if viper.IsSet(key) {
+		return SourceDefault
+	}
+
+	return ""
+}
+
+// EnvKey returns the environment variable overriding given key, e.g. UKF_API_KEY for api_key
+func EnvKey(key string) string {
+	return strings.ToUpper(EnvPrefix + "_" + key)
+}
+
+// resolveKey returns the context-specific key for given key if sourced from the active context,
+// otherwise returns key unmodified
+func resolveKey(key string) string {
+	if GetSource(key) == SourceContext {
+		return ContextKey(GetCurrentContextName(), key)
+	}
+
+	return key
+}
+
+// Get returns the value of key, resolved from the active context if set
+func Get(key string) interface{} {
+	return viper.Get(resolveKey(key))
+}
+
+// GetString returns the value of key as a string, resolved from the active context if set
+func GetString(key string) string {
+	return viper.GetString(resolveKey(key))
+}
+
+// GetInt returns the value of key as an int, resolved from the active context if set
+func GetInt(key string) int {
+	return viper.GetInt(resolveKey(key))
+}
+
+// GetBool returns the value of key as a bool, resolved from the active context if set
+func GetBool(key string) bool {
+	return viper.GetBool(resolveKey(key))
+}
+
+// GetStringMapString returns the value of key as a map of strings, resolved from the active
+// context if set
+func GetStringMapString(key string) map[string]string {
+	return viper.GetStringMapString(resolveKey(key))
+}
+
+// IsSet returns true if key is set, either within the active context or globally
+func IsSet(key string) bool {
+	return viper.IsSet(resolveKey(key))
+}
+
+// ReadFile reads the config file at given path into a standalone viper instance. Values sourced
+// from environment variables, flags or defaults are therefore not persisted when the instance is
+// written. A missing file results in an empty instance
+func ReadFile(fs afero.Fs, path string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetFs(fs)
+	v.SetConfigFile(path)
+
+	exists, err := afero.Exists(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		err = v.ReadInConfig()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read config from file '%s': %s", path, err)
+		}
+	}
+
+	return v, nil
+}
+
+// Unset returns a copy of viper instance v with given key removed. Viper doesn't natively support
+// removing keys, so settings are copied to a new instance
+func Unset(fs afero.Fs, v *viper.Viper, key string) *viper.Viper {
+	settings := v.AllSettings()
+	unsetPath(settings, strings.Split(strings.ToLower(key), "."))
+
+	newV := viper.New()
+	newV.SetFs(fs)
+	newV.SetConfigFile(v.ConfigFileUsed())
+	for k, value := range settings {
+		newV.Set(k, value)
+	}
+
+	return newV
+}
+
+func unsetPath(m map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(m, path[0])
+		return
+	}
+
+	child, ok := m[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	unsetPath(child, path[1:])
+}
+
+// MaskSecrets returns a copy of settings map m, with values for secret keys masked
+func MaskSecrets(m map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{})
+	for key, value := range m {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			masked[key] = MaskSecrets(v)
+		default:
+			if IsSecretKey(key) {
+				value = MaskValue(fmt.Sprintf("%v", value))
+			}
+			masked[key] = value
+		}
+	}
+
+	return masked
+}
+
+// IsSecretKey returns true if given key holds a secret value, which should be masked for output
+func IsSecretKey(key string) bool {
+	parts := strings.Split(strings.ToLower(key), ".")
+	return parts[len(parts)-1] == "api_key"
+}
+
+// maskMinLength is the minimum length of a secret value for which characters are retained when
+// masked, so that short values aren't largely revealed
+const maskMinLength = 12
+
+// MaskValue masks given secret value, retaining the last 4 characters for identification. Values
+// shorter than 12 characters are masked fully
+func MaskValue(value string) string {
+	if len(value) < maskMinLength {
+		return strings.Repeat("*", len(value))
+	}
+
+	return strings.Repeat("*", len(value)-4) + value[len(value)-4:]
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupContexts() {
+	viper.Reset()
+	SetContextOverride("")
+	viper.Set("api_key", "globalkey")
+	viper.Set("api_timeout_seconds", 10)
+	viper.Set("contexts.prod.api_key", "prodkey")
+	viper.Set("contexts.dev.api_key", "devkey")
+	viper.Set("contexts.dev.api_timeout_seconds", 30)
+}
+
+func TestGetCurrentContextName(t *testing.T) {
+	t.Run("NoContext_ReturnsEmpty", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+
+		assert.Equal(t, "", GetCurrentContextName())
+	})
+
+	t.Run("CurrentContextSet_ReturnsCurrentContext", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+
+		assert.Equal(t, "prod", GetCurrentContextName())
+	})
+
+	t.Run("OverrideSet_ReturnsOverride", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+		SetContextOverride("dev")
+		defer SetContextOverride("")
+
+		assert.Equal(t, "dev", GetCurrentContextName())
+	})
+}
+
+func TestGetString(t *testing.T) {
+	t.Run("NoContext_ReturnsGlobalValue", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+
+		assert.Equal(t, "globalkey", GetString("api_key"))
+	})
+
+	t.Run("ContextSet_ReturnsContextValue", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+
+		assert.Equal(t, "prodkey", GetString("api_key"))
+	})
+}
+
+func TestGetInt(t *testing.T) {
+	t.Run("ContextSet_ReturnsContextValue", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "dev")
+
+		assert.Equal(t, 30, GetInt("api_timeout_seconds"))
+	})
+
+	t.Run("ContextSetWithoutKey_ReturnsGlobalValue", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+
+		assert.Equal(t, 10, GetInt("api_timeout_seconds"))
+	})
+}
+
+func TestGetContextNames(t *testing.T) {
+	setupContexts()
+	defer viper.Reset()
+
+	assert.Equal(t, []string{"dev", "prod"}, GetContextNames())
+}
+
+func TestValidateContextName(t *testing.T) {
+	t.Run("Valid_NoError", func(t *testing.T) {
+		assert.Nil(t, ValidateContextName("prod"))
+	})
+
+	t.Run("Empty_ReturnsError", func(t *testing.T) {
+		assert.NotNil(t, ValidateContextName(""))
+	})
+
+	t.Run("ContainsDot_ReturnsError", func(t *testing.T) {
+		assert.NotNil(t, ValidateContextName("prod.1"))
+	})
+}
+
+func TestValidateCurrentContext(t *testing.T) {
+	t.Run("ExistingContext_NoError", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+
+		assert.Nil(t, ValidateCurrentContext())
+	})
+
+	t.Run("MissingContext_ReturnsError", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "missing")
+
+		err := ValidateCurrentContext()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Context [missing] not found", err.Error())
+	})
+}
+
+func TestReadFile(t *testing.T) {
+	t.Run("MissingFile_ReturnsEmptyInstance", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		v, err := ReadFile(fs, "/config.yml")
+
+		assert.Nil(t, err)
+		assert.Len(t, v.AllKeys(), 0)
+	})
+
+	t.Run("ExistingFile_ReadsValues", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/config.yml", []byte("api_key: testkey\n"), 0644)
+
+		v, err := ReadFile(fs, "/config.yml")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "testkey", v.GetString("api_key"))
+	})
+
+	t.Run("InvalidFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/config.yml", []byte("api_key: [invalid\n"), 0644)
+
+		_, err := ReadFile(fs, "/config.yml")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestUnset(t *testing.T) {
+	t.Run("NestedKey_RemovesKey", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		v := viper.New()
+		v.Set("contexts.prod.api_key", "prodkey")
+		v.Set("contexts.dev.api_key", "devkey")
+
+		v = Unset(fs, v, "contexts.prod")
+
+		assert.False(t, v.IsSet("contexts.prod.api_key"))
+		assert.Equal(t, "devkey", v.GetString("contexts.dev.api_key"))
+	})
+
+	t.Run("TopLevelKey_RemovesKey", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		v := viper.New()
+		v.Set("api_key", "testkey")
+		v.Set("api_uri", "localhost")
+
+		v = Unset(fs, v, "api_key")
+
+		assert.False(t, v.IsSet("api_key"))
+		assert.Equal(t, "localhost", v.GetString("api_uri"))
+	})
+}
+
+func TestMaskValue(t *testing.T) {
+	t.Run("LongValue_RetainsLast4Characters", func(t *testing.T) {
+		assert.Equal(t, "********9012", MaskValue("123456789012"))
+	})
+
+	t.Run("ShortValue_MasksAll", func(t *testing.T) {
+		assert.Equal(t, "***********", MaskValue("12345678901"))
+	})
+}
+
+func TestGetSource(t *testing.T) {
+	t.Run("EnvSet_ReturnsEnv", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		os.Setenv("UKF_API_KEY", "envkey")
+		defer os.Unsetenv("UKF_API_KEY")
+
+		assert.Equal(t, SourceEnv, GetSource("api_key"))
+	})
+
+	t.Run("ContextSet_ReturnsContext", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		viper.Set("current_context", "prod")
+
+		assert.Equal(t, SourceContext, GetSource("api_key"))
+	})
+
+	t.Run("NotSet_ReturnsEmpty", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+
+		assert.Equal(t, "", GetSource("api_uri"))
+	})
+}
+
+func TestMaskSecrets(t *testing.T) {
+	settings := map[string]interface{}{
+		"api_key": "123456789012",
+		"api_uri": "localhost",
+		"contexts": map[string]interface{}{
+			"prod": map[string]interface{}{
+				"api_key": "210987654321",
+			},
+		},
+	}
+
+	masked := MaskSecrets(settings)
+
+	assert.Equal(t, "********9012", masked["api_key"])
+	assert.Equal(t, "localhost", masked["api_uri"])
+	assert.Equal(t, "********4321", masked["contexts"].(map[string]interface{})["prod"].(map[string]interface{})["api_key"])
+	assert.Equal(t, "123456789012", settings["api_key"])
+}
+
+func TestSetFlagOverride(t *testing.T) {
+	t.Run("ContextSet_ReturnsOverride", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+		defer ResetFlagOverrides()
+		viper.Set("current_context", "prod")
+
+		SetFlagOverride("api_key", "flagkey")
+
+		assert.Equal(t, "flagkey", GetString("api_key"))
+		assert.Equal(t, SourceFlag, GetSource("api_key"))
+	})
+
+	t.Run("Reset_RemovesOverride", func(t *testing.T) {
+		setupContexts()
+		defer viper.Reset()
+
+		SetFlagOverride("api_uri", "localhost")
+		ResetFlagOverrides()
+
+		assert.Equal(t, "", GetSource("api_uri"))
+	})
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+type KeyType string
+
+const (
+	KeyTypeString    KeyType = "string"
+	KeyTypeInt       KeyType = "int"
+	KeyTypeBool      KeyType = "bool"
+	KeyTypeStringMap KeyType = "map"
+)
+
+// Definition describes a supported config key
+type Definition struct {
+	Key         string
+	Type        KeyType
+	Description string
+}
+
+var definitions = []Definition{
+	{Key: "api_key", Type: KeyTypeString, Description: "Specifies API key"},
+	{Key: "api_key_command", Type: KeyTypeString, Description: "Specifies a command to run to obtain the API key, which should output the API key to stdout"},
+	{Key: "credential_file", Type: KeyTypeString, Description: "Specifies the path of the encrypted credentials file used by 'config login', defaulting to ~/.ukfast.credentials"},
+	{Key: "credential_key_file", Type: KeyTypeString, Description: "Specifies the path of a key file for encrypting the credentials file, rather than prompting for a passphrase"},
+	{Key: "api_timeout_seconds", Type: KeyTypeInt, Description: "Specifies API timeout in seconds"},
+	{Key: "api_uri", Type: KeyTypeString, Description: "Specifies API URI"},
+	{Key: "api_insecure", Type: KeyTypeBool, Description: "Specifies API TLS validation should be disabled"},
+	{Key: "api_proxy", Type: KeyTypeString, Description: "Specifies a HTTP, HTTPS or SOCKS5 proxy URL for API requests, e.g. 'socks5://127.0.0.1:1080'"},
+	{Key: "api_ca_file", Type: KeyTypeString, Description: "Specifies a PEM bundle of CA certificates to trust for API requests, in addition to system roots"},
+	{Key: "api_client_cert_file", Type: KeyTypeString, Description: "Specifies a PEM client certificate for mutual TLS authentication with the API"},
+	{Key: "api_client_key_file", Type: KeyTypeString, Description: "Specifies the PEM private key for api_client_cert_file"},
+	{Key: "api_debug", Type: KeyTypeBool, Description: "Specifies API debug logging should be enabled"},
+	{Key: "api_headers", Type: KeyTypeStringMap, Description: "Specifies additional headers to send with API requests, e.g. 'X-Header=value'"},
+	{Key: "api_pagination_perpage", Type: KeyTypeInt, Description: "Specifies how many items should be retrieved per-page for paginated API requests"},
+	{Key: "api_retry_max", Type: KeyTypeInt, Description: "Specifies the maximum number of retries for API requests failing with transient errors"},
+	{Key: "api_retry_wait_min_seconds", Type: KeyTypeInt, Description: "Specifies the wait in seconds before the first retry, doubling for each subsequent retry"},
+	{Key: "api_retry_wait_max_seconds", Type: KeyTypeInt, Description: "Specifies the maximum wait in seconds between retries"},
+	{Key: "api_retry_non_idempotent", Type: KeyTypeBool, Description: "Specifies non-idempotent API requests (e.g. POST) should also be retried"},
+	{Key: "api_record", Type: KeyTypeString, Description: "Specifies a directory to record API requests and responses to as cassette files"},
+	{Key: "api_replay", Type: KeyTypeString, Description: "Specifies a directory of cassette files to replay API responses from, without making API requests"},
+	{Key: "api_trace", Type: KeyTypeBool, Description: "Specifies API requests and responses should be traced to stderr, with timing, status, size and headers"},
+	{Key: "api_trace_file", Type: KeyTypeString, Description: "Specifies a file to write traced API requests and responses to, in HAR 1.2 format"},
+	{Key: "api_trace_unredacted", Type: KeyTypeBool, Description: "Specifies sensitive headers (e.g. Authorization) should not be redacted from traces"},
+	{Key: "api_dry_run", Type: KeyTypeBool, Description: "Specifies mutating API requests should be output rather than sent"},
+	{Key: "command_wait_timeout_seconds", Type: KeyTypeInt, Description: "Specifies how long commands supporting 'wait' parameter should wait"},
+	{Key: "command_wait_sleep_seconds", Type: KeyTypeInt, Description: "Specifies how often commands supporting 'wait' parameter should poll"},
+	{Key: "command_confirm", Type: KeyTypeBool, Description: "Specifies destructive commands should require confirmation even when stdin isn't a terminal. --yes overrides this"},
+	{Key: "error_format", Type: KeyTypeString, Description: "Specifies the format errors are output in, either 'text' (default) or 'json'"},
+	{Key: "completion_cache_ttl_seconds", Type: KeyTypeInt, Description: "Specifies how long in seconds shell completion results are cached for, with 0 disabling caching"},
+}
+
+// Definitions returns all supported config key definitions, which may be set globally or
+// within a context
+func Definitions() []Definition {
+	return definitions
+}
+
+// GetDefinition returns the definition for given key, and a bool indicating whether the key
+// is supported
+func GetDefinition(key string) (Definition, bool) {
+	for _, definition := range definitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+
+	return Definition{}, false
+}
+
+// ValidateValue returns an error if value isn't of the type expected for definition d
+func (d Definition) ValidateValue(value interface{}) error {
+	valid := false
+	switch d.Type {
+	case KeyTypeString:
+		_, valid = value.(string)
+	case KeyTypeBool:
+		_, valid = value.(bool)
+	case KeyTypeInt:
+		switch v := value.(type) {
+		case int, int64:
+			valid = true
+		case float64:
+			valid = v == math.Trunc(v)
+		}
+	case KeyTypeStringMap:
+		_, valid = value.(map[string]interface{})
+	}
+
+	if !valid {
+		return fmt.Errorf("expected %s value, got %T", d.Type, value)
+	}
+
+	return nil
+}
+
+// Validate validates given config settings, as returned from viper AllSettings(), returning
+// a slice of errors for unknown keys, invalid values and undefined contexts
+func Validate(settings map[string]interface{}) []error {
+	var errs []error
+
+	contexts, _ := settings[ContextsKey].(map[string]interface{})
+	for _, key := range sortedKeys(settings) {
+		value := settings[key]
+		switch key {
+		case ContextsKey:
+			if contexts == nil {
+				errs = append(errs, fmt.Errorf("Invalid value for key [%s]: expected map of contexts", key))
+				continue
+			}
+			for _, name := range sortedKeys(contexts) {
+				contextSettings, ok := contexts[name].(map[string]interface{})
+				if !ok {
+					if contexts[name] != nil {
+						errs = append(errs, fmt.Errorf("Invalid value for context [%s]: expected map of config keys", name))
+					}
+					continue
+				}
+				errs = append(errs, validateKeys(contextSettings, ContextsKey+"."+name+".")...)
+			}
+		case CurrentContextKey:
+			name, ok := value.(string)
+			if !ok {
+				errs = append(errs, fmt.Errorf("Invalid value for key [%s]: expected string value, got %T", key, value))
+				continue
+			}
+			if _, exists := contexts[name]; !exists {
+				errs = append(errs, fmt.Errorf("Invalid value for key [%s]: context [%s] not found", key, name))
+			}
+		default:
+			errs = append(errs, validateKeys(map[string]interface{}{key: value}, "")...)
+		}
+	}
+
+	return errs
+}
+
+func validateKeys(settings map[string]interface{}, prefix string) []error {
+	var errs []error
+	for _, key := range sortedKeys(settings) {
+		definition, ok := GetDefinition(key)
+		if !ok {
+			errs = append(errs, fmt.Errorf("Unknown key [%s%s]", prefix, key))
+			continue
+		}
+
+		err := definition.ValidateValue(settings[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Invalid value for key [%s%s]: %s", prefix, key, err))
+		}
+	}
+
+	return errs
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
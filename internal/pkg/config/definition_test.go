@@ -0,0 +1,94 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDefinition(t *testing.T) {
+	t.Run("KnownKey_ReturnsDefinition", func(t *testing.T) {
+		definition, ok := GetDefinition("api_timeout_seconds")
+
+		assert.True(t, ok)
+		assert.Equal(t, KeyTypeInt, definition.Type)
+	})
+
+	t.Run("UnknownKey_ReturnsFalse", func(t *testing.T) {
+		_, ok := GetDefinition("api_unknown")
+
+		assert.False(t, ok)
+	})
+}
+
+func TestDefinition_ValidateValue(t *testing.T) {
+	t.Run("ValidValues_NoError", func(t *testing.T) {
+		assert.Nil(t, Definition{Type: KeyTypeString}.ValidateValue("test"))
+		assert.Nil(t, Definition{Type: KeyTypeInt}.ValidateValue(123))
+		assert.Nil(t, Definition{Type: KeyTypeInt}.ValidateValue(float64(123)))
+		assert.Nil(t, Definition{Type: KeyTypeBool}.ValidateValue(true))
+		assert.Nil(t, Definition{Type: KeyTypeStringMap}.ValidateValue(map[string]interface{}{"a": "b"}))
+	})
+
+	t.Run("InvalidValues_ReturnsError", func(t *testing.T) {
+		assert.NotNil(t, Definition{Type: KeyTypeString}.ValidateValue(123))
+		assert.NotNil(t, Definition{Type: KeyTypeInt}.ValidateValue("123"))
+		assert.NotNil(t, Definition{Type: KeyTypeInt}.ValidateValue(1.5))
+		assert.NotNil(t, Definition{Type: KeyTypeBool}.ValidateValue("true"))
+		assert.NotNil(t, Definition{Type: KeyTypeStringMap}.ValidateValue("a=b"))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("ValidSettings_NoErrors", func(t *testing.T) {
+		settings := map[string]interface{}{
+			"api_key":         "testkey",
+			"current_context": "prod",
+			"contexts": map[string]interface{}{
+				"prod": map[string]interface{}{
+					"api_timeout_seconds": 30,
+				},
+			},
+		}
+
+		errs := Validate(settings)
+
+		assert.Len(t, errs, 0)
+	})
+
+	t.Run("UnknownKey_ReturnsError", func(t *testing.T) {
+		errs := Validate(map[string]interface{}{"api_kye": "testkey"})
+
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Unknown key [api_kye]", errs[0].Error())
+	})
+
+	t.Run("UnknownContextKey_ReturnsError", func(t *testing.T) {
+		settings := map[string]interface{}{
+			"contexts": map[string]interface{}{
+				"prod": map[string]interface{}{
+					"api_kye": "testkey",
+				},
+			},
+		}
+
+		errs := Validate(settings)
+
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Unknown key [contexts.prod.api_kye]", errs[0].Error())
+	})
+
+	t.Run("InvalidType_ReturnsError", func(t *testing.T) {
+		errs := Validate(map[string]interface{}{"api_timeout_seconds": "abc"})
+
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Invalid value for key [api_timeout_seconds]: expected int value, got string", errs[0].Error())
+	})
+
+	t.Run("MissingCurrentContext_ReturnsError", func(t *testing.T) {
+		errs := Validate(map[string]interface{}{"current_context": "prod"})
+
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Invalid value for key [current_context]: context [prod] not found", errs[0].Error())
+	})
+}
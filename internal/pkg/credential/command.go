@@ -0,0 +1,52 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// CommandStore is a Store which obtains API keys by running an external helper command, in a
+// similar fashion to git credential helpers. The command is run via the shell, with the context
+// name exposed via environment variable UKF_CREDENTIAL_CONTEXT, and the first line of its output
+// used as the API key
+type CommandStore struct {
+	Command string
+}
+
+// NewCommandStore returns a new CommandStore running given command
+func NewCommandStore(command string) *CommandStore {
+	return &CommandStore{Command: command}
+}
+
+// Get implements Store
+func (s *CommandStore) Get(context string) (string, error) {
+	cmd := shellCommand(s.Command)
+	cmd.Env = append(os.Environ(), "UKF_CREDENTIAL_CONTEXT="+context)
+	// Helpers may prompt for input, e.g. to unlock a password manager
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("Error running api_key_command: %s", err)
+	}
+
+	apiKey := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	if apiKey == "" {
+		return "", errors.New("Error running api_key_command: No API key output")
+	}
+
+	return apiKey, nil
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,37 @@
+package credential
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandStore_Get(t *testing.T) {
+	t.Run("ReturnsFirstLineOfOutput", func(t *testing.T) {
+		apiKey, err := NewCommandStore("printf 'testkey\\nignored\\n'").Get("")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "testkey", apiKey)
+	})
+
+	t.Run("ContextExposedToCommand", func(t *testing.T) {
+		apiKey, err := NewCommandStore("echo key-$UKF_CREDENTIAL_CONTEXT").Get("prod")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "key-prod", apiKey)
+	})
+
+	t.Run("CommandFails_ReturnsError", func(t *testing.T) {
+		_, err := NewCommandStore("exit 3").Get("")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error running api_key_command: exit status 3", err.Error())
+	})
+
+	t.Run("NoOutput_ReturnsError", func(t *testing.T) {
+		_, err := NewCommandStore("true").Get("")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error running api_key_command: No API key output", err.Error())
+	})
+}
@@ -0,0 +1,142 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/afero"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/input"
+)
+
+// PassphraseEnvKey is the environment variable from which the credentials file passphrase is read,
+// allowing the credentials file to be used non-interactively
+const PassphraseEnvKey = "UKF_CREDENTIAL_PASSPHRASE"
+
+// ErrNotFound is returned when a store holds no API key for a context
+var ErrNotFound = errors.New("Credential not found")
+
+// ErrPassphraseRequired is returned when the credentials file passphrase is required, but can't be
+// read without prompting
+var ErrPassphraseRequired = fmt.Errorf("Credentials file passphrase required, set %s or credential_key_file", PassphraseEnvKey)
+
+// Store retrieves API keys by context name. An empty context name refers to the API key used when
+// no context is active
+type Store interface {
+	Get(context string) (string, error)
+}
+
+// WritableStore is a Store which API keys can also be saved to and removed from
+type WritableStore interface {
+	Store
+	Set(context string, apiKey string) error
+	Delete(context string) error
+}
+
+// GetAPIKey returns the API key for the active context. The api_key config takes precedence,
+// followed by the output of api_key_command, and finally the credentials file populated by
+// 'config login'. An empty string is returned if no API key is found. If interactive is false, the
+// credentials file passphrase is never prompted for, with ErrPassphraseRequired returned instead
+func GetAPIKey(fs afero.Fs, interactive bool) (string, error) {
+	apiKey := config.GetString("api_key")
+	if apiKey != "" {
+		return apiKey, nil
+	}
+
+	context := config.GetCurrentContextName()
+
+	apiKeyCommand := config.GetString("api_key_command")
+	if apiKeyCommand != "" {
+		return NewCommandStore(apiKeyCommand).Get(context)
+	}
+
+	passphrase := PromptPassphrase
+	if !interactive {
+		passphrase = EnvPassphrase
+	}
+
+	store, err := newFileStoreFromConfig(fs, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	apiKey, err = store.Get(context)
+	if err == ErrNotFound {
+		return "", nil
+	}
+
+	return apiKey, err
+}
+
+// NewFileStoreFromConfig returns a FileStore for the credentials file and key file specified in
+// config, prompting for a passphrase via PromptPassphrase if no key file is specified
+func NewFileStoreFromConfig(fs afero.Fs) (*FileStore, error) {
+	return newFileStoreFromConfig(fs, PromptPassphrase)
+}
+
+func newFileStoreFromConfig(fs afero.Fs, passphrase PassphraseFunc) (*FileStore, error) {
+	path, err := homedir.Expand(config.GetString("credential_file"))
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		home, err := homedir.Dir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".ukfast.credentials")
+	}
+
+	keyFile, err := homedir.Expand(config.GetString("credential_key_file"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFileStore(fs, path, keyFile, passphrase), nil
+}
+
+// EnvPassphrase returns the credentials file passphrase from environment variable
+// UKF_CREDENTIAL_PASSPHRASE, returning ErrPassphraseRequired if it isn't set
+func EnvPassphrase(confirm bool) (string, error) {
+	if passphrase, ok := os.LookupEnv(PassphraseEnvKey); ok {
+		return passphrase, nil
+	}
+
+	return "", ErrPassphraseRequired
+}
+
+// PromptPassphrase returns the credentials file passphrase from environment variable
+// UKF_CREDENTIAL_PASSPHRASE if set, otherwise prompts for the passphrase when stdin is a terminal.
+// When confirm is true (i.e. when creating a new credentials file), the passphrase must be entered
+// twice
+func PromptPassphrase(confirm bool) (string, error) {
+	if passphrase, ok := os.LookupEnv(PassphraseEnvKey); ok {
+		return passphrase, nil
+	}
+	if !input.IsTerminal() {
+		return "", fmt.Errorf("Credentials file passphrase required, set %s or credential_key_file when stdin isn't a terminal", PassphraseEnvKey)
+	}
+
+	passphrase, err := input.ReadSecret("Credentials file passphrase")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("Passphrase cannot be empty")
+	}
+
+	if confirm {
+		confirmation, err := input.ReadSecret("Confirm passphrase")
+		if err != nil {
+			return "", err
+		}
+		if confirmation != passphrase {
+			return "", errors.New("Passphrases don't match")
+		}
+	}
+
+	return passphrase, nil
+}
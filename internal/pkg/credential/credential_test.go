@@ -0,0 +1,79 @@
+package credential
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAPIKey(t *testing.T) {
+	defer setTestScryptParameters()()
+
+	t.Run("APIKeySet_ReturnsAPIKey", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("api_key", "testkey")
+		viper.Set("api_key_command", "echo commandkey")
+
+		apiKey, err := GetAPIKey(afero.NewMemMapFs(), true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "testkey", apiKey)
+	})
+
+	t.Run("APIKeyCommandSet_ReturnsCommandOutput", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("api_key_command", "echo commandkey")
+
+		apiKey, err := GetAPIKey(afero.NewMemMapFs(), true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "commandkey", apiKey)
+	})
+
+	t.Run("CredentialFile_ReturnsContextAPIKey", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("credential_file", "/creds")
+		viper.Set("current_context", "prod")
+		viper.Set("contexts.prod.api_uri", "api.example.com")
+		os.Setenv(PassphraseEnvKey, "testpassphrase")
+		defer os.Unsetenv(PassphraseEnvKey)
+
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Set("prod", "filekey")
+
+		apiKey, err := GetAPIKey(fs, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "filekey", apiKey)
+	})
+
+	t.Run("CredentialFileNonInteractiveWithoutPassphrase_ReturnsErrPassphraseRequired", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("credential_file", "/creds")
+
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Set("", "filekey")
+
+		_, err := GetAPIKey(fs, false)
+
+		assert.Equal(t, ErrPassphraseRequired, err)
+	})
+
+	t.Run("NotFound_ReturnsEmpty", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("credential_file", "/creds")
+
+		apiKey, err := GetAPIKey(afero.NewMemMapFs(), true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "", apiKey)
+	})
+}
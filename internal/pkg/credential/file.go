@@ -0,0 +1,360 @@
+package credential
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	fileVersion = 1
+	keySize     = 32
+	saltSize    = 16
+
+	kdfScrypt  = "scrypt"
+	kdfKeyFile = "key_file"
+)
+
+// scryptParameters are the scrypt cost parameters used when creating a credentials file. These are
+// stored within the file, so may be changed without affecting existing files
+var scryptParameters = ScryptParameters{N: 1 << 15, R: 8, P: 1}
+
+// PassphraseFunc returns the passphrase protecting a credentials file. confirm is true when a new
+// credentials file is being created, indicating the passphrase should be confirmed
+type PassphraseFunc func(confirm bool) (string, error)
+
+// ScryptParameters are the cost parameters used to derive an encryption key from a passphrase
+type ScryptParameters struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+type credentialFile struct {
+	Version     int                         `json:"version"`
+	KDF         string                      `json:"kdf"`
+	Salt        []byte                      `json:"salt,omitempty"`
+	Scrypt      *ScryptParameters           `json:"scrypt,omitempty"`
+	Credentials map[string]sealedCredential `json:"credentials"`
+}
+
+type sealedCredential struct {
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// FileStore is a WritableStore which stores API keys in a local file, encrypted with AES-256-GCM.
+// The encryption key is either derived from a passphrase using scrypt, or read from a key file
+// containing a random base64-encoded key. Context names are stored in plaintext, so that API
+// keys can be removed without the passphrase. As with config contexts, context names are
+// case-insensitive
+type FileStore struct {
+	fs         afero.Fs
+	path       string
+	keyFile    string
+	passphrase PassphraseFunc
+	key        []byte
+}
+
+// NewFileStore returns a new FileStore for the credentials file at path. If keyFile is specified, the
+// encryption key is read from keyFile (which is generated when creating a new credentials file if it
+// doesn't exist), otherwise the encryption key is derived from the passphrase returned by passphrase
+func NewFileStore(fs afero.Fs, path string, keyFile string, passphrase PassphraseFunc) *FileStore {
+	return &FileStore{
+		fs:         fs,
+		path:       path,
+		keyFile:    keyFile,
+		passphrase: passphrase,
+	}
+}
+
+// Path returns the path of the credentials file
+func (s *FileStore) Path() string {
+	return s.path
+}
+
+// Get implements Store
+func (s *FileStore) Get(context string) (string, error) {
+	context = strings.ToLower(context)
+	file, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	if file == nil {
+		return "", ErrNotFound
+	}
+
+	sealed, ok := file.Credentials[context]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	key, err := s.getKey(file, false)
+	if err != nil {
+		return "", err
+	}
+
+	return s.open(key, context, sealed)
+}
+
+// Set implements WritableStore
+func (s *FileStore) Set(context string, apiKey string) error {
+	context = strings.ToLower(context)
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	create := file == nil
+	if create {
+		file, err = s.newFile()
+		if err != nil {
+			return err
+		}
+	}
+
+	key, err := s.getKey(file, create)
+	if err != nil {
+		return err
+	}
+
+	// Ensure all credentials within the file remain encrypted with the same key
+	for existingContext, existing := range file.Credentials {
+		_, err := s.open(key, existingContext, existing)
+		if err != nil {
+			return err
+		}
+		break
+	}
+
+	sealed, err := seal(key, context, apiKey)
+	if err != nil {
+		return err
+	}
+
+	file.Credentials[context] = sealed
+	return s.write(file)
+}
+
+// Delete implements WritableStore
+func (s *FileStore) Delete(context string) error {
+	context = strings.ToLower(context)
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		return ErrNotFound
+	}
+
+	if _, ok := file.Credentials[context]; !ok {
+		return ErrNotFound
+	}
+
+	delete(file.Credentials, context)
+	return s.write(file)
+}
+
+// read returns the contents of the credentials file, or nil if the file doesn't exist
+func (s *FileStore) read() (*credentialFile, error) {
+	exists, err := afero.Exists(s.fs, s.path)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	content, err := afero.ReadFile(s.fs, s.path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read credentials file '%s': %s", s.path, err)
+	}
+
+	file := &credentialFile{}
+	err = json.Unmarshal(content, file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse credentials file '%s': %s", s.path, err)
+	}
+	if file.Version != fileVersion {
+		return nil, fmt.Errorf("Unsupported credentials file version [%d]", file.Version)
+	}
+	if file.Credentials == nil {
+		file.Credentials = make(map[string]sealedCredential)
+	}
+
+	return file, nil
+}
+
+func (s *FileStore) write(file *credentialFile) error {
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal credentials: %s", err)
+	}
+
+	err = s.fs.MkdirAll(filepath.Dir(s.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	err = afero.WriteFile(s.fs, s.path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write credentials file '%s': %s", s.path, err)
+	}
+
+	return nil
+}
+
+func (s *FileStore) newFile() (*credentialFile, error) {
+	file := &credentialFile{
+		Version:     fileVersion,
+		Credentials: make(map[string]sealedCredential),
+	}
+
+	if s.keyFile != "" {
+		file.KDF = kdfKeyFile
+		return file, nil
+	}
+
+	salt, err := randomBytes(saltSize)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := scryptParameters
+	file.KDF = kdfScrypt
+	file.Salt = salt
+	file.Scrypt = &parameters
+
+	return file, nil
+}
+
+// getKey returns the encryption key for file, which is cached for subsequent operations
+func (s *FileStore) getKey(file *credentialFile, create bool) ([]byte, error) {
+	if s.key != nil {
+		return s.key, nil
+	}
+
+	var err error
+	switch file.KDF {
+	case kdfKeyFile:
+		if s.keyFile == "" {
+			return nil, fmt.Errorf("Credentials file '%s' is encrypted with a key file, credential_key_file must be set", s.path)
+		}
+		s.key, err = s.readKeyFile(create)
+	case kdfScrypt:
+		if file.Scrypt == nil {
+			return nil, fmt.Errorf("Credentials file '%s' is missing scrypt parameters", s.path)
+		}
+
+		var passphrase string
+		passphrase, err = s.passphrase(create)
+		if err != nil {
+			return nil, err
+		}
+
+		s.key, err = scrypt.Key([]byte(passphrase), file.Salt, file.Scrypt.N, file.Scrypt.R, file.Scrypt.P, keySize)
+	default:
+		return nil, fmt.Errorf("Unsupported credentials file key derivation [%s]", file.KDF)
+	}
+
+	return s.key, err
+}
+
+// readKeyFile returns the key read from the key file. If create is true and the key file doesn't
+// exist, a new random key is generated and written to the key file
+func (s *FileStore) readKeyFile(create bool) ([]byte, error) {
+	exists, err := afero.Exists(s.fs, s.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if !create {
+			return nil, fmt.Errorf("Key file '%s' not found", s.keyFile)
+		}
+
+		key, err := randomBytes(keySize)
+		if err != nil {
+			return nil, err
+		}
+
+		err = afero.WriteFile(s.fs, s.keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to write key file '%s': %s", s.keyFile, err)
+		}
+
+		return key, nil
+	}
+
+	content, err := afero.ReadFile(s.fs, s.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read key file '%s': %s", s.keyFile, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("Invalid key file '%s': expected base64-encoded %d byte key", s.keyFile, keySize)
+	}
+
+	return key, nil
+}
+
+// open decrypts sealed credential for context. The context name is used as additional data, so
+// that credentials can't be swapped between contexts
+func (s *FileStore) open(key []byte, context string, sealed sealedCredential) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := gcm.Open(nil, sealed.Nonce, sealed.Data, []byte(context))
+	if err != nil {
+		return "", fmt.Errorf("Failed to decrypt credentials file '%s': incorrect passphrase or key", s.path)
+	}
+
+	return string(plaintext), nil
+}
+
+func seal(key []byte, context string, apiKey string) (sealedCredential, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return sealedCredential{}, err
+	}
+
+	nonce, err := randomBytes(gcm.NonceSize())
+	if err != nil {
+		return sealedCredential{}, err
+	}
+
+	return sealedCredential{
+		Nonce: nonce,
+		Data:  gcm.Seal(nil, nonce, []byte(apiKey), []byte(context)),
+	}, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate random bytes: %s", err)
+	}
+
+	return b, nil
+}
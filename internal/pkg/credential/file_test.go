@@ -0,0 +1,187 @@
+package credential
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// setTestScryptParameters lowers scrypt cost so tests run quickly, returning a func restoring the
+// original parameters
+func setTestScryptParameters() func() {
+	oldParameters := scryptParameters
+	scryptParameters = ScryptParameters{N: 16, R: 8, P: 1}
+	return func() { scryptParameters = oldParameters }
+}
+
+func testPassphrase(passphrase string) PassphraseFunc {
+	return func(confirm bool) (string, error) {
+		return passphrase, nil
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	defer setTestScryptParameters()()
+
+	t.Run("Passphrase_SetThenGet_ReturnsAPIKey", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Set("Prod", "testkey")
+		assert.Nil(t, err)
+
+		apiKey, err := NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Get("prod")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "testkey", apiKey)
+
+		content, _ := afero.ReadFile(fs, "/creds")
+		assert.NotContains(t, string(content), "testkey")
+		info, _ := fs.Stat("/creds")
+		assert.Equal(t, "-rw-------", info.Mode().String())
+	})
+
+	t.Run("Passphrase_ConfirmedOnlyWhenCreating", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		var confirms []bool
+		passphrase := func(confirm bool) (string, error) {
+			confirms = append(confirms, confirm)
+			return "testpassphrase", nil
+		}
+
+		NewFileStore(fs, "/creds", "", passphrase).Set("", "testkey1")
+		NewFileStore(fs, "/creds", "", passphrase).Set("prod", "testkey2")
+
+		assert.Equal(t, []bool{true, false}, confirms)
+	})
+
+	t.Run("IncorrectPassphrase_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Set("", "testkey")
+
+		_, err := NewFileStore(fs, "/creds", "", testPassphrase("wrong")).Get("")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Failed to decrypt credentials file '/creds': incorrect passphrase or key", err.Error())
+	})
+
+	t.Run("IncorrectPassphrase_SetReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Set("", "testkey")
+
+		err := NewFileStore(fs, "/creds", "", testPassphrase("wrong")).Set("prod", "testkey2")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Failed to decrypt credentials file '/creds': incorrect passphrase or key", err.Error())
+	})
+
+	t.Run("PassphraseError_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := NewFileStore(fs, "/creds", "", func(confirm bool) (string, error) {
+			return "", errors.New("test error")
+		}).Set("", "testkey")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "test error", err.Error())
+	})
+
+	t.Run("MissingFile_ReturnsErrNotFound", func(t *testing.T) {
+		_, err := NewFileStore(afero.NewMemMapFs(), "/creds", "", testPassphrase("testpassphrase")).Get("")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("MissingContext_ReturnsErrNotFoundWithoutPassphrase", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Set("", "testkey")
+
+		_, err := NewFileStore(fs, "/creds", "", func(confirm bool) (string, error) {
+			t.Fatal("Unexpected passphrase prompt")
+			return "", nil
+		}).Get("prod")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("KeyFile_GeneratedAndUsed", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := NewFileStore(fs, "/creds", "/key", nil).Set("", "testkey")
+		assert.Nil(t, err)
+
+		apiKey, err := NewFileStore(fs, "/creds", "/key", nil).Get("")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "testkey", apiKey)
+
+		content, _ := afero.ReadFile(fs, "/key")
+		key, _ := base64.StdEncoding.DecodeString(string(content))
+		assert.Len(t, key, keySize)
+	})
+
+	t.Run("KeyFileMissing_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "/key", nil).Set("", "testkey")
+		fs.Remove("/key")
+
+		_, err := NewFileStore(fs, "/creds", "/key", nil).Get("")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Key file '/key' not found", err.Error())
+	})
+
+	t.Run("KeyFileNotConfigured_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		NewFileStore(fs, "/creds", "/key", nil).Set("", "testkey")
+
+		_, err := NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase")).Get("")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Credentials file '/creds' is encrypted with a key file, credential_key_file must be set", err.Error())
+	})
+
+	t.Run("InvalidKeyFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/key", []byte("invalid"), 0600)
+
+		err := NewFileStore(fs, "/creds", "/key", nil).Set("", "testkey")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Invalid key file '/key': expected base64-encoded 32 byte key", err.Error())
+	})
+
+	t.Run("Delete_RemovesContextOnly", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		store := NewFileStore(fs, "/creds", "", testPassphrase("testpassphrase"))
+		store.Set("", "testkey1")
+		store.Set("prod", "testkey2")
+
+		err := NewFileStore(fs, "/creds", "", nil).Delete("PROD")
+		assert.Nil(t, err)
+
+		_, err = store.Get("prod")
+		assert.Equal(t, ErrNotFound, err)
+		apiKey, _ := store.Get("")
+		assert.Equal(t, "testkey1", apiKey)
+	})
+
+	t.Run("DeleteMissingContext_ReturnsErrNotFound", func(t *testing.T) {
+		err := NewFileStore(afero.NewMemMapFs(), "/creds", "", nil).Delete("prod")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("InvalidFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/creds", []byte("invalid"), 0600)
+
+		_, err := NewFileStore(fs, "/creds", "", nil).Get("")
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "Failed to parse credentials file '/creds'")
+	})
+}
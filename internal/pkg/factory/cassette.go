@@ -0,0 +1,305 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+	"github.com/ukfast/sdk-go/pkg/logging"
+)
+
+const redactedHeaderValue = "REDACTED"
+
+// redactedHeaders are headers which are redacted from cassette files and traces
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// CassetteInteraction is a recorded request/response pair, stored as a cassette file
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers"`
+	Body    string      `json:"body"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+}
+
+// matches returns true if interaction i was recorded for request with given method, URL and body.
+// The scheme and host of the URL are ignored, so cassettes can be replayed against any API URI
+func (i CassetteInteraction) matches(method string, url string, body string) bool {
+	return i.Request.Method == method && cassetteURL(i.Request.URL) == cassetteURL(url) && i.Request.Body == body
+}
+
+// RecordTransport is a http.RoundTripper which records each request/response pair to a cassette
+// file within Dir, with sensitive request headers redacted
+type RecordTransport struct {
+	Transport http.RoundTripper
+	Dir       string
+
+	fs    afero.Fs
+	mutex sync.Mutex
+	index int
+}
+
+// NewRecordTransport returns a new RecordTransport wrapping transport, recording to directory dir.
+// If transport is nil, http.DefaultTransport is used
+func NewRecordTransport(fs afero.Fs, transport http.RoundTripper, dir string) (*RecordTransport, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	err := fs.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record directory: %s", err)
+	}
+
+	// Continue numbering from existing cassettes, so an existing recording can be extended
+	files, err := cassetteFiles(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordTransport{
+		Transport: transport,
+		Dir:       dir,
+		fs:        fs,
+		index:     len(files),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RecordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := CassetteInteraction{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: redactHeaders(req.Header),
+			Body:    reqBody,
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       string(respBody),
+		},
+	}
+
+	return resp, t.write(interaction)
+}
+
+func (t *RecordTransport) write(interaction CassetteInteraction) error {
+	out, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %s", err)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.index++
+	path := filepath.Join(t.Dir, fmt.Sprintf("%05d.json", t.index))
+	logging.Debugf("Recording %s %s to cassette [%s]", interaction.Request.Method, interaction.Request.URL, path)
+
+	err = afero.WriteFile(t.fs, path, out, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write cassette: %s", err)
+	}
+
+	return nil
+}
+
+// ReplayTransport is a http.RoundTripper which responds to requests using cassette files recorded
+// by RecordTransport, without making any network requests. Matching interactions are replayed in the
+// order they were recorded, with the last matching interaction repeated once all have been replayed,
+// allowing for polling requests (e.g. --wait) to be replayed
+type ReplayTransport struct {
+	interactions []CassetteInteraction
+	replayed     []bool
+	mutex        sync.Mutex
+}
+
+// NewReplayTransport returns a new ReplayTransport, replaying cassettes from directory dir
+func NewReplayTransport(fs afero.Fs, dir string) (*ReplayTransport, error) {
+	files, err := cassetteFiles(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) < 1 {
+		return nil, fmt.Errorf("no cassettes found in replay directory [%s]", dir)
+	}
+
+	t := &ReplayTransport{}
+	for _, file := range files {
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette [%s]: %s", file, err)
+		}
+
+		var interaction CassetteInteraction
+		err = json.Unmarshal(content, &interaction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cassette [%s]: %s", file, err)
+		}
+
+		t.interactions = append(t.interactions, interaction)
+	}
+	t.replayed = make([]bool, len(t.interactions))
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction, err := t.next(req.Method, req.URL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Debugf("Replaying %s %s from cassette", req.Method, req.URL)
+
+	headers := interaction.Response.Headers
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// next returns the next interaction to replay for given request
+func (t *ReplayTransport) next(method string, url string, body string) (CassetteInteraction, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	last := -1
+	for i, interaction := range t.interactions {
+		if !interaction.matches(method, url, body) {
+			continue
+		}
+		if !t.replayed[i] {
+			t.replayed[i] = true
+			return interaction, nil
+		}
+		last = i
+	}
+
+	if last < 0 {
+		return CassetteInteraction{}, fmt.Errorf("no recorded interaction found for request %s %s", method, cassetteURL(url))
+	}
+
+	return t.interactions[last], nil
+}
+
+// cassetteFiles returns a sorted slice of cassette file paths within dir
+func cassetteFiles(fs afero.Fs, dir string) ([]string, error) {
+	exists, err := afero.DirExists(fs, dir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("cassette directory [%s] not found", dir)
+	}
+
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette directory: %s", err)
+	}
+
+	var files []string
+	for _, info := range infos {
+		if !info.IsDir() && filepath.Ext(info.Name()) == ".json" {
+			files = append(files, filepath.Join(dir, info.Name()))
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// readRequestBody returns the body of req as a string, replacing the body so it can be read again
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return string(body), nil
+}
+
+func redactHeaders(headers http.Header) http.Header {
+	redacted := http.Header{}
+	for key, values := range headers {
+		redacted[key] = values
+	}
+	for _, key := range redactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, redactedHeaderValue)
+		}
+	}
+
+	return redacted
+}
+
+// cassetteURL returns the path and query of rawURL, stripping scheme and host
+func cassetteURL(rawURL string) string {
+	if i := strings.Index(rawURL, "://"); i >= 0 {
+		rawURL = rawURL[i+3:]
+		if j := strings.Index(rawURL, "/"); j >= 0 {
+			return rawURL[j:]
+		}
+		return "/"
+	}
+
+	return rawURL
+}
@@ -0,0 +1,119 @@
+package factory
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCassetteServer() (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(r.Method + " " + r.URL.Path + " " + string(body) + " " + string(rune('0'+requests))))
+	}))
+
+	return server, &requests
+}
+
+func doTestCassetteRequest(t *testing.T, transport http.RoundTripper, method string, url string, body string) string {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "testkey")
+	resp, err := transport.RoundTrip(req)
+	assert.Nil(t, err)
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	return string(respBody)
+}
+
+func TestRecordTransport_RoundTrip(t *testing.T) {
+	t.Run("WritesRedactedCassette", func(t *testing.T) {
+		server, _ := newTestCassetteServer()
+		defer server.Close()
+		fs := afero.NewMemMapFs()
+
+		transport, err := NewRecordTransport(fs, nil, "/cassettes")
+		assert.Nil(t, err)
+
+		out := doTestCassetteRequest(t, transport, "POST", server.URL+"/test", "testbody")
+		cassette, _ := afero.ReadFile(fs, "/cassettes/00001.json")
+
+		assert.Equal(t, "POST /test testbody 1", out)
+		assert.Contains(t, string(cassette), "REDACTED")
+		assert.NotContains(t, string(cassette), "testkey")
+		assert.Contains(t, string(cassette), "POST /test testbody 1")
+	})
+
+	t.Run("ExistingCassettes_ContinuesNumbering", func(t *testing.T) {
+		server, _ := newTestCassetteServer()
+		defer server.Close()
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/cassettes/00001.json", []byte("{}"), 0600)
+
+		transport, _ := NewRecordTransport(fs, nil, "/cassettes")
+		doTestCassetteRequest(t, transport, "GET", server.URL+"/test", "")
+
+		exists, _ := afero.Exists(fs, "/cassettes/00002.json")
+		assert.True(t, exists)
+	})
+}
+
+func TestReplayTransport_RoundTrip(t *testing.T) {
+	server, requests := newTestCassetteServer()
+	defer server.Close()
+	fs := afero.NewMemMapFs()
+
+	recorder, _ := NewRecordTransport(fs, nil, "/cassettes")
+	doTestCassetteRequest(t, recorder, "GET", server.URL+"/status", "")
+	doTestCassetteRequest(t, recorder, "GET", server.URL+"/status", "")
+	doTestCassetteRequest(t, recorder, "POST", server.URL+"/create", "testbody")
+
+	t.Run("ReplaysInRecordedOrder", func(t *testing.T) {
+		transport, err := NewReplayTransport(fs, "/cassettes")
+		assert.Nil(t, err)
+
+		assert.Equal(t, "POST /create testbody 3", doTestCassetteRequest(t, transport, "POST", "https://otherhost/create", "testbody"))
+		assert.Equal(t, "GET /status  1", doTestCassetteRequest(t, transport, "GET", "https://otherhost/status", ""))
+		assert.Equal(t, "GET /status  2", doTestCassetteRequest(t, transport, "GET", "https://otherhost/status", ""))
+		assert.Equal(t, 3, *requests)
+	})
+
+	t.Run("AllReplayed_RepeatsLastMatching", func(t *testing.T) {
+		transport, _ := NewReplayTransport(fs, "/cassettes")
+
+		doTestCassetteRequest(t, transport, "GET", server.URL+"/status", "")
+		doTestCassetteRequest(t, transport, "GET", server.URL+"/status", "")
+
+		assert.Equal(t, "GET /status  2", doTestCassetteRequest(t, transport, "GET", server.URL+"/status", ""))
+	})
+
+	t.Run("NoMatchingInteraction_ReturnsError", func(t *testing.T) {
+		transport, _ := NewReplayTransport(fs, "/cassettes")
+
+		req, _ := http.NewRequest("GET", server.URL+"/missing", nil)
+		_, err := transport.RoundTrip(req)
+
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "GET /missing"))
+	})
+
+	t.Run("MissingDirectory_ReturnsError", func(t *testing.T) {
+		_, err := NewReplayTransport(fs, "/missing")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCassetteURL(t *testing.T) {
+	assert.Equal(t, "/ecloud/v1/vms?page=1", cassetteURL("https://api.ukfast.io/ecloud/v1/vms?page=1"))
+	assert.Equal(t, "/", cassetteURL("https://api.ukfast.io"))
+	assert.Equal(t, "/test", cassetteURL("/test"))
+}
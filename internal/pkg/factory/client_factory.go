@@ -1,12 +1,14 @@
 package factory
 
 import (
-	"crypto/tls"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
-	"github.com/spf13/viper"
+	"github.com/spf13/afero"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/credential"
 	"github.com/ukfast/cli/internal/pkg/output"
 	"github.com/ukfast/sdk-go/pkg/client"
 	"github.com/ukfast/sdk-go/pkg/connection"
@@ -17,10 +19,20 @@ type ClientFactory interface {
 	NewClient() (client.Client, error)
 }
 
+// ConnectionFactory returns an authenticated API connection, for invoking requests against
+// endpoints which aren't supported by the SDK
+type ConnectionFactory interface {
+	NewConnection() (connection.Connection, error)
+}
+
 type UKFastClientFactoryOption func(f *UKFastClientFactory)
 
 type UKFastClientFactory struct {
 	apiUserAgent string
+	version      string
+	fs           afero.Fs
+	interactive  bool
+	har          *HARRecorder
 }
 
 func WithUserAgent(userAgent string) UKFastClientFactoryOption {
@@ -29,8 +41,32 @@ func WithUserAgent(userAgent string) UKFastClientFactoryOption {
 	}
 }
 
+// WithVersion specifies the CLI version, recorded within trace files
+func WithVersion(version string) UKFastClientFactoryOption {
+	return func(p *UKFastClientFactory) {
+		p.version = version
+	}
+}
+
+func WithFilesystem(fs afero.Fs) UKFastClientFactoryOption {
+	return func(p *UKFastClientFactory) {
+		p.fs = fs
+	}
+}
+
+// WithNonInteractive specifies that the factory must never prompt for input, e.g. for the
+// credentials file passphrase when completing arguments
+func WithNonInteractive() UKFastClientFactoryOption {
+	return func(p *UKFastClientFactory) {
+		p.interactive = false
+	}
+}
+
 func NewUKFastClientFactory(opts ...UKFastClientFactoryOption) *UKFastClientFactory {
-	f := &UKFastClientFactory{}
+	f := &UKFastClientFactory{
+		fs:          afero.NewOsFs(),
+		interactive: true,
+	}
 	for _, opt := range opts {
 		opt(f)
 	}
@@ -38,29 +74,110 @@ func NewUKFastClientFactory(opts ...UKFastClientFactoryOption) *UKFastClientFact
 }
 
 func (f *UKFastClientFactory) NewClient() (client.Client, error) {
-	apiKey := viper.GetString("api_key")
-	if len(apiKey) < 1 {
-		return nil, errors.New("Missing api_key")
+	conn, err := f.NewConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewClient(conn), nil
+}
+
+// NewConnection returns an API connection configured from the current config
+func (f *UKFastClientFactory) NewConnection() (connection.Connection, error) {
+	err := config.ValidateCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	apiRecord := config.GetString("api_record")
+	apiReplay := config.GetString("api_replay")
+	if apiRecord != "" && apiReplay != "" {
+		return nil, errors.New("api_record and api_replay cannot be used together")
+	}
+
+	// API keys aren't required when replaying, so aren't resolved (which may prompt for a passphrase)
+	var apiKey string
+	if apiReplay == "" {
+		apiKey, err = credential.GetAPIKey(f.fs, f.interactive)
+		if err != nil {
+			return nil, err
+		}
+		if len(apiKey) < 1 {
+			return nil, errors.New("Missing api_key, set api_key or api_key_command, or run 'config login'")
+		}
 	}
 
 	conn := connection.NewAPIConnection(&connection.APIKeyCredentials{APIKey: apiKey})
 	conn.UserAgent = f.apiUserAgent
-	apiURI := viper.GetString("api_uri")
+	apiURI := config.GetString("api_uri")
 	if apiURI != "" {
-		conn.APIURI = apiURI
+		// Allow scheme to be specified, e.g. 'http://127.0.0.1:8080' for a local mock server
+		if i := strings.Index(apiURI, "://"); i >= 0 {
+			conn.APIScheme = apiURI[:i]
+			apiURI = apiURI[i+3:]
+		}
+		conn.APIURI = strings.TrimSuffix(apiURI, "/")
 	}
-	apiTimeoutSeconds := viper.GetInt("api_timeout_seconds")
+	apiTimeoutSeconds := config.GetInt("api_timeout_seconds")
 	if apiTimeoutSeconds > 0 {
 		conn.HTTPClient.Timeout = (time.Duration(apiTimeoutSeconds) * time.Second)
 	}
-	if viper.GetBool("api_insecure") {
-		conn.HTTPClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
+	// The transport is built once, with further behaviour (recording, retries etc.) layered on top
+	conn.HTTPClient.Transport, err = NewTransport(f.fs, TransportConfig{
+		Proxy:          config.GetString("api_proxy"),
+		CAFile:         config.GetString("api_ca_file"),
+		ClientCertFile: config.GetString("api_client_cert_file"),
+		ClientKeyFile:  config.GetString("api_client_key_file"),
+		Insecure:       config.GetBool("api_insecure"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if apiRecord != "" {
+		conn.HTTPClient.Transport, err = NewRecordTransport(f.fs, conn.HTTPClient.Transport, apiRecord)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if apiReplay != "" {
+		conn.HTTPClient.Transport, err = NewReplayTransport(f.fs, apiReplay)
+		if err != nil {
+			return nil, err
 		}
 	}
-	apiHeaders := viper.GetStringMapString("api_headers")
+	apiTraceFile := config.GetString("api_trace_file")
+	if config.GetBool("api_trace") || apiTraceFile != "" {
+		// Traced beneath retries, so that each attempt is traced
+		trace := NewTraceTransport(conn.HTTPClient.Transport)
+		trace.Output = config.GetBool("api_trace")
+		trace.Unredacted = config.GetBool("api_trace_unredacted")
+		if apiTraceFile != "" {
+			// The recorder is shared between connections, so the trace file holds all requests
+			if f.har == nil || f.har.Path != apiTraceFile {
+				f.har = NewHARRecorder(f.fs, apiTraceFile, f.apiUserAgent, f.version)
+			}
+			trace.HAR = f.har
+		}
+		conn.HTTPClient.Transport = trace
+	}
+	apiRetryMax := config.GetInt("api_retry_max")
+	if apiRetryMax > 0 {
+		conn.HTTPClient.Transport = NewRetryTransport(
+			conn.HTTPClient.Transport,
+			apiRetryMax,
+			getDurationSeconds("api_retry_wait_min_seconds", 1),
+			getDurationSeconds("api_retry_wait_max_seconds", 30),
+			config.GetBool("api_retry_non_idempotent"),
+		)
+	}
+	if config.GetBool("api_dry_run") {
+		// Once a request has been intercepted, further command output would reflect the
+		// synthetic response rather than actual results, so is suppressed
+		conn.HTTPClient.Transport = NewDryRunTransport(conn.HTTPClient.Transport, func(req *http.Request) {
+			output.Suppress()
+		})
+	}
+	apiHeaders := config.GetStringMapString("api_headers")
 	if apiHeaders != nil {
 		conn.Headers = http.Header{}
 		for headerKey, headerValue := range apiHeaders {
@@ -68,9 +185,20 @@ func (f *UKFastClientFactory) NewClient() (client.Client, error) {
 		}
 	}
 
-	if viper.GetBool("api_debug") {
+	if config.GetBool("api_debug") {
 		logging.SetLogger(&output.DebugLogger{})
 	}
 
-	return client.NewClient(conn), nil
+	return conn, nil
+}
+
+// getDurationSeconds returns the duration for config key specified in seconds, or defaultSeconds
+// if the key isn't set
+func getDurationSeconds(key string, defaultSeconds int) time.Duration {
+	seconds := defaultSeconds
+	if config.IsSet(key) {
+		seconds = config.GetInt(key)
+	}
+
+	return time.Duration(seconds) * time.Second
 }
@@ -0,0 +1,58 @@
+package factory
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/cli/test"
+	"github.com/ukfast/sdk-go/pkg/service/ecloud"
+)
+
+func TestUKFastClientFactory_NewConnection(t *testing.T) {
+	t.Run("DryRunGetFailsAfterInterceptedRequest_ExitsWithZero", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		config.SetFlagOverride("api_key", "testkey")
+		config.SetFlagOverride("api_uri", server.URL)
+		config.SetFlagOverride("api_dry_run", true)
+		defer config.ResetFlagOverrides()
+
+		code := 1
+		oldOutputExit := output.SetOutputExit(func(c int) {
+			code = c
+		})
+		defer output.SetOutputExit(oldOutputExit)
+		defer output.SetErrorLevel(0)
+
+		stdOut, stdErr := test.CatchStdOutStdErr(t, func() {
+			conn, err := NewUKFastClientFactory(WithFilesystem(afero.NewMemMapFs())).NewConnection()
+			assert.Nil(t, err)
+
+			service := ecloud.NewService(conn)
+
+			err = service.PatchVPC("vpc-abcdef12", ecloud.PatchVPCRequest{Name: "test"})
+			assert.Nil(t, err)
+
+			_, err = service.GetVPC("vpc-abcdef12")
+			assert.NotNil(t, err)
+			output.OutputCommandError(output.NewCommandError(err))
+		})
+
+		output.ExitWithErrorLevel()
+
+		assert.Equal(t, 1, requests)
+		assert.Contains(t, stdOut, "PATCH /ecloud/v2/vpcs/vpc-abcdef12")
+		assert.Equal(t, "", stdErr)
+		assert.Equal(t, 0, code)
+	})
+}
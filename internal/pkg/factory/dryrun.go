@@ -0,0 +1,108 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ukfast/sdk-go/pkg/logging"
+)
+
+// dryRunResponseBody is the response body returned for intercepted requests
+const dryRunResponseBody = `{"data":{},"meta":{}}`
+
+// DryRunTransport is a http.RoundTripper which outputs mutating requests (e.g. POST, PATCH, DELETE)
+// to Writer rather than sending them, responding with an empty successful response. Non-mutating
+// requests are sent via Transport, so that lookups required by commands continue to function
+type DryRunTransport struct {
+	Transport http.RoundTripper
+	Writer    io.Writer
+	// OnIntercept is invoked after each request is intercepted
+	OnIntercept func(req *http.Request)
+
+	mutex sync.Mutex
+}
+
+// NewDryRunTransport returns a new DryRunTransport wrapping transport, outputting to stdout. If
+// transport is nil, http.DefaultTransport is used
+func NewDryRunTransport(transport http.RoundTripper, onIntercept func(req *http.Request)) *DryRunTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &DryRunTransport{
+		Transport:   transport,
+		Writer:      os.Stdout,
+		OnIntercept: onIntercept,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *DryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutating(req.Method) {
+		return t.Transport.RoundTrip(req)
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Debugf("Intercepting %s %s for dry run", req.Method, req.URL)
+
+	err = t.write(req.Method, req.URL.RequestURI(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.OnIntercept != nil {
+		t.OnIntercept(req)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(dryRunResponseBody)),
+		ContentLength: int64(len(dryRunResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+func (t *DryRunTransport) write(method string, uri string, body string) error {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s %s\n", method, uri))
+	if body != "" {
+		// Indent JSON bodies for readability, falling back to the raw body
+		if json.Indent(&buf, []byte(body), "", "  ") != nil {
+			buf.WriteString(body)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	_, err := t.Writer.Write(buf.Bytes())
+	return err
+}
+
+// isMutating returns true if requests with given method may modify resources
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	return true
+}
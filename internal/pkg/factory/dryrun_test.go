@@ -0,0 +1,93 @@
+package factory
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDryRunServer() (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":{"id":"abc"}}`))
+	}))
+
+	return server, &requests
+}
+
+func TestDryRunTransport_RoundTrip(t *testing.T) {
+	t.Run("GetRequest_SendsRequest", func(t *testing.T) {
+		server, requests := newTestDryRunServer()
+		defer server.Close()
+
+		buf := &bytes.Buffer{}
+		transport := NewDryRunTransport(nil, nil)
+		transport.Writer = buf
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/ecloud/v2/images?name=test", nil)
+		resp, err := transport.RoundTrip(req)
+
+		assert.Nil(t, err)
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, `{"data":{"id":"abc"}}`, string(body))
+		assert.Equal(t, 1, *requests)
+		assert.Equal(t, "", buf.String())
+	})
+
+	t.Run("PostRequest_OutputsRequestWithoutSending", func(t *testing.T) {
+		server, requests := newTestDryRunServer()
+		defer server.Close()
+
+		buf := &bytes.Buffer{}
+		intercepted := 0
+		transport := NewDryRunTransport(nil, func(req *http.Request) {
+			intercepted++
+		})
+		transport.Writer = buf
+
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/ecloud/v2/firewall-rules", strings.NewReader(`{"name":"test","sequence":1}`))
+		resp, err := transport.RoundTrip(req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, `{"data":{},"meta":{}}`, string(body))
+		assert.Equal(t, 0, *requests)
+		assert.Equal(t, 1, intercepted)
+		assert.Equal(t, "POST /ecloud/v2/firewall-rules\n{\n  \"name\": \"test\",\n  \"sequence\": 1\n}\n\n", buf.String())
+	})
+
+	t.Run("DeleteRequestWithoutBody_OutputsRequestLine", func(t *testing.T) {
+		server, requests := newTestDryRunServer()
+		defer server.Close()
+
+		buf := &bytes.Buffer{}
+		transport := NewDryRunTransport(nil, nil)
+		transport.Writer = buf
+
+		req, _ := http.NewRequest(http.MethodDelete, server.URL+"/safedns/v1/zones/example.com", nil)
+		_, err := transport.RoundTrip(req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, *requests)
+		assert.Equal(t, "DELETE /safedns/v1/zones/example.com\n\n", buf.String())
+	})
+
+	t.Run("NonJSONBody_OutputsRawBody", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		transport := NewDryRunTransport(nil, nil)
+		transport.Writer = buf
+
+		req, _ := http.NewRequest(http.MethodPut, "http://localhost/test", strings.NewReader("raw body"))
+		_, err := transport.RoundTrip(req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "PUT /test\nraw body\n\n", buf.String())
+	})
+}
@@ -0,0 +1,174 @@
+package factory
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ukfast/sdk-go/pkg/logging"
+)
+
+// RetryTransport is a http.RoundTripper which retries requests failing with transient errors,
+// waiting with exponential backoff and jitter between attempts. Requests are retried when a
+// connection error occurs, or when a 429 or 5xx (excluding 501) response is received
+type RetryTransport struct {
+	Transport http.RoundTripper
+	// MaxRetries specifies the maximum number of retries for a single request
+	MaxRetries int
+	// MinWait specifies the wait before the first retry, which doubles for each subsequent retry
+	MinWait time.Duration
+	// MaxWait specifies the maximum wait between retries, including waits requested via Retry-After
+	MaxWait time.Duration
+	// NonIdempotent specifies whether non-idempotent requests (e.g. POST) should be retried. 429
+	// responses are always retried, as the request won't have been processed
+	NonIdempotent bool
+
+	sleep func(req *http.Request, d time.Duration) error
+}
+
+// NewRetryTransport returns a new RetryTransport wrapping transport. If transport is nil,
+// http.DefaultTransport is used
+func NewRetryTransport(transport http.RoundTripper, maxRetries int, minWait time.Duration, maxWait time.Duration, nonIdempotent bool) *RetryTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &RetryTransport{
+		Transport:     transport,
+		MaxRetries:    maxRetries,
+		MinWait:       minWait,
+		MaxWait:       maxWait,
+		NonIdempotent: nonIdempotent,
+		sleep:         sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.Transport.RoundTrip(req)
+		if attempt >= t.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			logging.Debugf("Retrying request %s %s in %s (retry %d/%d): %s", req.Method, req.URL, wait, attempt+1, t.MaxRetries, err)
+		} else {
+			logging.Debugf("Retrying request %s %s in %s (retry %d/%d): received status %d", req.Method, req.URL, wait, attempt+1, t.MaxRetries, resp.StatusCode)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		err = t.sleep(req, wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry returns true if the request should be retried given the response/error received
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !t.NonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	return resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns the wait before the next retry, using the Retry-After header of resp if
+// present, otherwise an exponential backoff with jitter
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if t.MaxWait > 0 && wait > t.MaxWait {
+				return t.MaxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.MinWait << uint(attempt)
+	if wait <= 0 || (t.MaxWait > 0 && wait > t.MaxWait) {
+		wait = t.MaxWait
+	}
+
+	// Apply jitter between half and full wait, so concurrent clients don't retry in lockstep
+	if wait > 1 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header value, in either seconds or HTTP-date format
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := time.Until(date)
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// sleepContext sleeps for duration d, returning early with an error if the request context is done
+func sleepContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
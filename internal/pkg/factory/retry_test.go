@@ -0,0 +1,167 @@
+package factory
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRetryTransport(maxRetries int, nonIdempotent bool) (*RetryTransport, *[]time.Duration) {
+	var waits []time.Duration
+	transport := NewRetryTransport(nil, maxRetries, time.Second, 10*time.Second, nonIdempotent)
+	transport.sleep = func(req *http.Request, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return transport, &waits
+}
+
+func newTestRetryServer(statuses ...int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if requests < len(statuses) {
+			status = statuses[requests]
+		}
+		requests++
+
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "3")
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+
+	return server, &requests
+}
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	t.Run("TransientError_Retries", func(t *testing.T) {
+		server, requests := newTestRetryServer(503, 502, 200)
+		defer server.Close()
+		transport, waits := newTestRetryTransport(3, false)
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := transport.RoundTrip(req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, 3, *requests)
+		assert.Len(t, *waits, 2)
+	})
+
+	t.Run("MaxRetriesExceeded_ReturnsLastResponse", func(t *testing.T) {
+		server, requests := newTestRetryServer(500)
+		defer server.Close()
+		transport, _ := newTestRetryTransport(2, false)
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := transport.RoundTrip(req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 3, *requests)
+	})
+
+	t.Run("ClientError_DoesNotRetry", func(t *testing.T) {
+		server, requests := newTestRetryServer(404)
+		defer server.Close()
+		transport, _ := newTestRetryTransport(3, false)
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, _ := transport.RoundTrip(req)
+
+		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, 1, *requests)
+	})
+
+	t.Run("NonIdempotent_DoesNotRetry", func(t *testing.T) {
+		server, requests := newTestRetryServer(503, 200)
+		defer server.Close()
+		transport, _ := newTestRetryTransport(3, false)
+
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString("test"))
+		resp, _ := transport.RoundTrip(req)
+
+		assert.Equal(t, 503, resp.StatusCode)
+		assert.Equal(t, 1, *requests)
+	})
+
+	t.Run("NonIdempotentEnabled_RetriesWithBody", func(t *testing.T) {
+		server, requests := newTestRetryServer(503, 200)
+		defer server.Close()
+		transport, _ := newTestRetryTransport(3, true)
+
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString("test"))
+		resp, _ := transport.RoundTrip(req)
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "test", string(body))
+		assert.Equal(t, 2, *requests)
+	})
+
+	t.Run("TooManyRequests_RetriesNonIdempotentHonouringRetryAfter", func(t *testing.T) {
+		server, requests := newTestRetryServer(429, 200)
+		defer server.Close()
+		transport, waits := newTestRetryTransport(3, false)
+
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString("test"))
+		resp, _ := transport.RoundTrip(req)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, 2, *requests)
+		assert.Equal(t, []time.Duration{3 * time.Second}, *waits)
+	})
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	transport, _ := newTestRetryTransport(10, false)
+
+	t.Run("IncreasesExponentially", func(t *testing.T) {
+		wait := transport.backoff(2, nil)
+
+		assert.True(t, wait >= 2*time.Second && wait <= 4*time.Second)
+	})
+
+	t.Run("LimitedToMaxWait", func(t *testing.T) {
+		wait := transport.backoff(8, nil)
+
+		assert.True(t, wait >= 5*time.Second && wait <= 10*time.Second)
+	})
+
+	t.Run("RetryAfterExceedsMaxWait_ReturnsMaxWait", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"60"}}}
+
+		assert.Equal(t, 10*time.Second, transport.backoff(0, resp))
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Run("Seconds_ReturnsDuration", func(t *testing.T) {
+		wait, ok := parseRetryAfter("5")
+
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, wait)
+	})
+
+	t.Run("Date_ReturnsDuration", func(t *testing.T) {
+		wait, ok := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+		assert.True(t, ok)
+		assert.True(t, wait > 50*time.Second && wait <= time.Minute)
+	})
+
+	t.Run("Invalid_ReturnsFalse", func(t *testing.T) {
+		_, ok := parseRetryAfter("invalid")
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,355 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+const harVersion = "1.2"
+
+// harFooter closes the entries array and HAR document, and is overwritten as each entry is appended
+const harFooter = "\n    ]\n  }\n}"
+
+// TraceTransport is a http.RoundTripper which traces each request/response pair. When Output is
+// set, the method, URL, status, timing, size and headers of each are output to stderr. When HAR is
+// set, each pair is additionally recorded to a HAR file. Sensitive headers are redacted unless
+// Unredacted is set
+type TraceTransport struct {
+	Transport  http.RoundTripper
+	Output     bool
+	HAR        *HARRecorder
+	Unredacted bool
+
+	now func() time.Time
+}
+
+// NewTraceTransport returns a new TraceTransport wrapping transport. If transport is nil,
+// http.DefaultTransport is used
+func NewTraceTransport(transport http.RoundTripper) *TraceTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &TraceTransport{
+		Transport: transport,
+		now:       time.Now,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start := t.now()
+	resp, respErr := t.Transport.RoundTrip(req)
+
+	var respBody []byte
+	if respErr == nil {
+		respBody, respErr = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	}
+	elapsed := t.now().Sub(start)
+
+	reqHeaders := t.headers(req.Header)
+	if t.Output {
+		output.Errorf("> %s %s", req.Method, req.URL.String())
+		t.outputHeaders(">", reqHeaders)
+		if respErr != nil {
+			output.Errorf("< error after %s: %s", formatTraceDuration(elapsed), respErr)
+		} else {
+			output.Errorf("< %s (%s, %d bytes)", resp.Status, formatTraceDuration(elapsed), len(respBody))
+			t.outputHeaders("<", t.headers(resp.Header))
+		}
+	}
+
+	if t.HAR != nil {
+		entry := newHAREntry(req, reqHeaders, reqBody, start, elapsed)
+		if respErr != nil {
+			entry.Error = respErr.Error()
+		} else {
+			entry.Response = newHARResponse(resp, t.headers(resp.Header), respBody)
+		}
+
+		err := t.HAR.Add(entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	return resp, nil
+}
+
+// headers returns headers to be traced, with sensitive headers redacted unless t.Unredacted is set
+func (t *TraceTransport) headers(headers http.Header) http.Header {
+	if t.Unredacted {
+		return headers
+	}
+
+	return redactHeaders(headers)
+}
+
+func (t *TraceTransport) outputHeaders(prefix string, headers http.Header) {
+	for _, name := range sortedHeaderNames(headers) {
+		for _, value := range headers[name] {
+			output.Errorf("%s %s: %s", prefix, name, value)
+		}
+	}
+}
+
+// HARRecorder records request/response pairs to a file in HAR 1.2 format, which can be imported
+// into browser developer tools. A single HARRecorder may be shared between connections, so that all
+// requests made by a command are recorded to the same file
+type HARRecorder struct {
+	Path string
+
+	fs      afero.Fs
+	creator HARCreator
+	mutex   sync.Mutex
+	entries int
+}
+
+// NewHARRecorder returns a new HARRecorder writing to path, with the CLI name and version recorded
+// as the HAR creator
+func NewHARRecorder(fs afero.Fs, path string, name string, version string) *HARRecorder {
+	return &HARRecorder{
+		Path:    path,
+		fs:      fs,
+		creator: HARCreator{Name: name, Version: version},
+	}
+}
+
+// Add appends entry to the HAR file. The file is created with the first entry, with further entries
+// written over the closing brackets (which are then rewritten), so that the file remains complete
+// should the CLI exit at any point without being rewritten in full for each entry
+func (r *HARRecorder) Add(entry HAREntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entryJSON, err := json.MarshalIndent(entry, "      ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal HAR entry: %s", err)
+	}
+
+	if r.entries == 0 {
+		err = r.create(entryJSON)
+	} else {
+		err = r.append(entryJSON)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write trace file: %s", err)
+	}
+
+	r.entries++
+	return nil
+}
+
+// create writes a new HAR file containing the single entry entryJSON. The output matches that of
+// json.MarshalIndent for the complete document
+func (r *HARRecorder) create(entryJSON []byte) error {
+	creatorJSON, err := json.MarshalIndent(r.creator, "    ", "  ")
+	if err != nil {
+		return err
+	}
+
+	out := fmt.Sprintf("{\n  \"log\": {\n    \"version\": %q,\n    \"creator\": %s,\n    \"entries\": [\n      %s%s", harVersion, creatorJSON, entryJSON, harFooter)
+	return afero.WriteFile(r.fs, r.Path, []byte(out), 0600)
+}
+
+// append writes entryJSON over the closing brackets of the existing HAR file, followed by the
+// closing brackets
+func (r *HARRecorder) append(entryJSON []byte) error {
+	file, err := r.fs.OpenFile(r.Path, os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteAt([]byte(fmt.Sprintf(",\n      %s%s", entryJSON, harFooter)), info.Size()-int64(len(harFooter)))
+	return err
+}
+
+func formatTraceDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+func sortedHeaderNames(headers http.Header) []string {
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// HAR is the root of a HTTP Archive (HAR) 1.2 document
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	// Error is a custom field holding the error for requests which failed without a response
+	Error string `json:"_error,omitempty"`
+}
+
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings holds request timings in milliseconds. Only the overall wait is measured, with
+// other phases reported as -1 (not applicable) or 0 as required by the specification
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+func newHAREntry(req *http.Request, headers http.Header, body string, start time.Time, elapsed time.Duration) HAREntry {
+	elapsedMs := float64(elapsed) / float64(time.Millisecond)
+
+	entry := HAREntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Time:            elapsedMs,
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(headers),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(body),
+		},
+		// Placeholder response for requests which fail without a response
+		Response: HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: HARTimings{Blocked: -1, DNS: -1, Connect: -1, Send: 0, Wait: elapsedMs, Receive: 0, SSL: -1},
+	}
+
+	query := req.URL.Query()
+	for _, name := range sortedHeaderNames(http.Header(query)) {
+		for _, value := range query[name] {
+			entry.Request.QueryString = append(entry.Request.QueryString, HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	if body != "" {
+		entry.Request.PostData = &HARPostData{MimeType: req.Header.Get("Content-Type"), Text: body}
+	}
+
+	return entry
+}
+
+func newHARResponse(resp *http.Response, headers http.Header, body []byte) HARResponse {
+	return HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprintf("%d", resp.StatusCode))),
+		HTTPVersion: resp.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(headers),
+		Content: HARContent{
+			Size:     len(body),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     string(body),
+		},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+}
+
+func harHeaders(headers http.Header) []HARNameValue {
+	values := []HARNameValue{}
+	for _, name := range sortedHeaderNames(headers) {
+		for _, value := range headers[name] {
+			values = append(values, HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,151 @@
+package factory
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test"
+)
+
+func newTestTraceServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{}}`))
+	}))
+}
+
+// newTestTraceTransport returns a TraceTransport where each request appears to take 150ms
+func newTestTraceTransport() *TraceTransport {
+	transport := NewTraceTransport(nil)
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	calls := 0
+	transport.now = func() time.Time {
+		calls++
+		if calls%2 == 0 {
+			return start.Add(150 * time.Millisecond)
+		}
+		return start
+	}
+
+	return transport
+}
+
+func newTestTraceRequest(url string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, url+"/ecloud/v2/vpcs?page=2", strings.NewReader(`{"name":"test"}`))
+	req.Header.Set("Authorization", "secretkey")
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestTraceTransport_RoundTrip(t *testing.T) {
+	t.Run("Output_TracesRequestWithRedactedHeaders", func(t *testing.T) {
+		server := newTestTraceServer()
+		defer server.Close()
+
+		transport := newTestTraceTransport()
+		transport.Output = true
+
+		var resp *http.Response
+		stdErr := test.CatchStdErr(t, func() {
+			resp, _ = transport.RoundTrip(newTestTraceRequest(server.URL))
+		})
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, `{"data":{}}`, string(body))
+		assert.Contains(t, stdErr, "> POST "+server.URL+"/ecloud/v2/vpcs?page=2\n")
+		assert.Contains(t, stdErr, "> Authorization: REDACTED\n")
+		assert.Contains(t, stdErr, "< 201 Created (150ms, 11 bytes)\n")
+		assert.Contains(t, stdErr, "< Set-Cookie: REDACTED\n")
+		assert.NotContains(t, stdErr, "secret")
+	})
+
+	t.Run("Unredacted_OutputsSensitiveHeaders", func(t *testing.T) {
+		server := newTestTraceServer()
+		defer server.Close()
+
+		transport := newTestTraceTransport()
+		transport.Output = true
+		transport.Unredacted = true
+
+		stdErr := test.CatchStdErr(t, func() {
+			transport.RoundTrip(newTestTraceRequest(server.URL))
+		})
+
+		assert.Contains(t, stdErr, "> Authorization: secretkey\n")
+	})
+
+	t.Run("RequestError_TracesError", func(t *testing.T) {
+		server := newTestTraceServer()
+		server.Close()
+
+		fs := afero.NewMemMapFs()
+		transport := newTestTraceTransport()
+		transport.Output = true
+		transport.HAR = NewHARRecorder(fs, "/trace.har", "ukfast-cli", "v1.0.0")
+
+		var err error
+		stdErr := test.CatchStdErr(t, func() {
+			_, err = transport.RoundTrip(newTestTraceRequest(server.URL))
+		})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, stdErr, "< error after 150ms: ")
+
+		content, _ := afero.ReadFile(fs, "/trace.har")
+		har := HAR{}
+		json.Unmarshal(content, &har)
+		assert.Len(t, har.Log.Entries, 1)
+		assert.NotEmpty(t, har.Log.Entries[0].Error)
+	})
+
+	t.Run("HAR_WritesEntries", func(t *testing.T) {
+		server := newTestTraceServer()
+		defer server.Close()
+
+		fs := afero.NewMemMapFs()
+		transport := newTestTraceTransport()
+		transport.HAR = NewHARRecorder(fs, "/trace.har", "ukfast-cli", "v1.0.0")
+
+		stdErr := test.CatchStdErr(t, func() {
+			transport.RoundTrip(newTestTraceRequest(server.URL))
+			transport.RoundTrip(newTestTraceRequest(server.URL))
+		})
+
+		assert.Empty(t, stdErr)
+
+		content, _ := afero.ReadFile(fs, "/trace.har")
+		assert.NotContains(t, string(content), "secret")
+
+		har := HAR{}
+		err := json.Unmarshal(content, &har)
+		assert.Nil(t, err)
+		assert.Equal(t, "1.2", har.Log.Version)
+		assert.Equal(t, HARCreator{Name: "ukfast-cli", Version: "v1.0.0"}, har.Log.Creator)
+		assert.Len(t, har.Log.Entries, 2)
+
+		// Entries are appended in place, so the file should match the complete document
+		expected, _ := json.MarshalIndent(har, "", "  ")
+		assert.Equal(t, string(expected), string(content))
+
+		entry := har.Log.Entries[0]
+		assert.Equal(t, "2020-01-02T03:04:05Z", entry.StartedDateTime)
+		assert.Equal(t, float64(150), entry.Time)
+		assert.Equal(t, "POST", entry.Request.Method)
+		assert.Equal(t, []HARNameValue{{Name: "page", Value: "2"}}, entry.Request.QueryString)
+		assert.Contains(t, entry.Request.Headers, HARNameValue{Name: "Authorization", Value: "REDACTED"})
+		assert.Equal(t, &HARPostData{MimeType: "application/json", Text: `{"name":"test"}`}, entry.Request.PostData)
+		assert.Equal(t, 201, entry.Response.Status)
+		assert.Equal(t, "Created", entry.Response.StatusText)
+		assert.Equal(t, HARContent{Size: 11, MimeType: "application/json", Text: `{"data":{}}`}, entry.Response.Content)
+		assert.Equal(t, float64(150), entry.Timings.Wait)
+	})
+}
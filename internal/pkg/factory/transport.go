@@ -0,0 +1,111 @@
+package factory
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/afero"
+)
+
+// TransportConfig specifies proxy and TLS configuration for API requests
+type TransportConfig struct {
+	// Proxy is the URL of a HTTP, HTTPS or SOCKS5 proxy. If empty, proxies are configured via the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+	Proxy string
+	// CAFile is the path of a PEM bundle of CA certificates, trusted in addition to system roots
+	CAFile string
+	// ClientCertFile and ClientKeyFile are the paths of a PEM certificate and key, presented to the
+	// API for mutual TLS authentication
+	ClientCertFile string
+	ClientKeyFile  string
+	// Insecure specifies TLS certificate verification should be disabled
+	Insecure bool
+}
+
+// NewTransport returns a new http.Transport with given proxy and TLS configuration, based on
+// http.DefaultTransport so that default timeouts and connection pooling are retained
+func NewTransport(fs afero.Fs, c TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid api_proxy [%s]: %s", c.Proxy, err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("Invalid api_proxy [%s]: scheme must be one of http, https or socks5", c.Proxy)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("Invalid api_proxy [%s]: missing host", c.Proxy)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if c.CAFile != "" {
+		pem, err := readTransportFile(fs, c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read api_ca_file '%s': %s", c.CAFile, err)
+		}
+
+		// Custom CAs are trusted in addition to the system roots, so that a corporate CA can be
+		// added without losing trust in public CAs
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("Failed to load api_ca_file '%s': no PEM certificates found", c.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, errors.New("api_client_cert_file and api_client_key_file must be specified together")
+		}
+
+		certPEM, err := readTransportFile(fs, c.ClientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read api_client_cert_file '%s': %s", c.ClientCertFile, err)
+		}
+		keyPEM, err := readTransportFile(fs, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read api_client_key_file '%s': %s", c.ClientKeyFile, err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load client certificate: %s", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// readTransportFile reads the file at path, expanding a leading '~' to the home directory
+func readTransportFile(fs afero.Fs, path string) ([]byte, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return afero.ReadFile(fs, path)
+}
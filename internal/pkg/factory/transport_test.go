@@ -0,0 +1,153 @@
+package factory
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestServerCA writes the certificate of TLS server s to path as a PEM CA bundle
+func writeTestServerCA(fs afero.Fs, s *httptest.Server, path string) {
+	afero.WriteFile(fs, path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0644)
+}
+
+// writeTestClientCert generates a self-signed client certificate and key, written to certPath and
+// keyPath as PEM
+func writeTestClientCert(t *testing.T, fs afero.Fs, certPath string, keyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	afero.WriteFile(fs, certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0644)
+	afero.WriteFile(fs, keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+}
+
+func testTransportGet(transport http.RoundTripper, url string) error {
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Run("CAFile_VerifiesServer", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		fs := afero.NewMemMapFs()
+		writeTestServerCA(fs, server, "/ca.pem")
+
+		transport, err := NewTransport(fs, TransportConfig{})
+		assert.Nil(t, err)
+		assert.NotNil(t, testTransportGet(transport, server.URL))
+
+		transport, err = NewTransport(fs, TransportConfig{CAFile: "/ca.pem"})
+		assert.Nil(t, err)
+		assert.Nil(t, testTransportGet(transport, server.URL))
+	})
+
+	t.Run("ClientCert_PresentedToServer", func(t *testing.T) {
+		var peerCertificates int
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			peerCertificates = len(r.TLS.PeerCertificates)
+		}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		server.StartTLS()
+		defer server.Close()
+
+		fs := afero.NewMemMapFs()
+		writeTestServerCA(fs, server, "/ca.pem")
+		writeTestClientCert(t, fs, "/client.pem", "/client-key.pem")
+
+		transport, err := NewTransport(fs, TransportConfig{CAFile: "/ca.pem", ClientCertFile: "/client.pem", ClientKeyFile: "/client-key.pem"})
+
+		assert.Nil(t, err)
+		assert.Nil(t, testTransportGet(transport, server.URL))
+		assert.Equal(t, 1, peerCertificates)
+	})
+
+	t.Run("Proxy_RequestsSentViaProxy", func(t *testing.T) {
+		var proxiedURL string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxiedURL = r.URL.String()
+		}))
+		defer proxy.Close()
+
+		transport, err := NewTransport(afero.NewMemMapFs(), TransportConfig{Proxy: proxy.URL})
+
+		assert.Nil(t, err)
+		assert.Nil(t, testTransportGet(transport, "http://api.example.com/ecloud/v2/vpcs"))
+		assert.Equal(t, "http://api.example.com/ecloud/v2/vpcs", proxiedURL)
+	})
+
+	t.Run("InvalidProxyScheme_ReturnsError", func(t *testing.T) {
+		_, err := NewTransport(afero.NewMemMapFs(), TransportConfig{Proxy: "ftp://proxy.example.com"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Invalid api_proxy [ftp://proxy.example.com]: scheme must be one of http, https or socks5", err.Error())
+	})
+
+	t.Run("ProxyMissingHost_ReturnsError", func(t *testing.T) {
+		_, err := NewTransport(afero.NewMemMapFs(), TransportConfig{Proxy: "proxy.example.com:3128"})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("InvalidCAFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/ca.pem", []byte("invalid"), 0644)
+
+		_, err := NewTransport(fs, TransportConfig{CAFile: "/ca.pem"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Failed to load api_ca_file '/ca.pem': no PEM certificates found", err.Error())
+	})
+
+	t.Run("ClientCertWithoutKey_ReturnsError", func(t *testing.T) {
+		_, err := NewTransport(afero.NewMemMapFs(), TransportConfig{ClientCertFile: "/client.pem"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "api_client_cert_file and api_client_key_file must be specified together", err.Error())
+	})
+
+	t.Run("Insecure_SkipsVerification", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		transport, err := NewTransport(afero.NewMemMapFs(), TransportConfig{Insecure: true})
+
+		assert.Nil(t, err)
+		assert.Nil(t, testTransportGet(transport, server.URL))
+	})
+}
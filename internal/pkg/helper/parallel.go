@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+// ForEachArgFunc processes a single argument, returning an optional result and any error to output
+type ForEachArgFunc func(arg string) (interface{}, error)
+
+type forEachArgResult struct {
+	value interface{}
+	err   error
+	done  chan struct{}
+}
+
+// AddParallelFlag adds the 'parallel' flag to cmd, for commands which process their arguments with
+// ForEachArg
+func AddParallelFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("parallel", 1, "Specifies the number of resources to process concurrently")
+}
+
+// ForEachArg invokes fn for each of args, with the number of concurrent invocations bounded by the
+// 'parallel' flag (1 by default, or where cmd doesn't have the flag). Errors returned by fn are
+// output in the order of args, so that output is deterministic regardless of concurrency, and
+// non-nil results are returned in the order of args
+func ForEachArg(cmd *cobra.Command, args []string, fn ForEachArgFunc) []interface{} {
+	workers, _ := cmd.Flags().GetInt("parallel")
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(args) {
+		workers = len(args)
+	}
+
+	results := make([]*forEachArgResult, len(args))
+	for i := range results {
+		results[i] = &forEachArgResult{done: make(chan struct{})}
+	}
+
+	indexes := make(chan int)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for index := range indexes {
+				results[index].value, results[index].err = fn(args[index])
+				close(results[index].done)
+			}
+		}()
+	}
+
+	go func() {
+		for i := range args {
+			indexes <- i
+		}
+		close(indexes)
+	}()
+
+	var values []interface{}
+	for _, result := range results {
+		<-result.done
+		if result.err != nil {
+			outputForEachArgError(result.err)
+			continue
+		}
+		if result.value != nil {
+			values = append(values, result.value)
+		}
+	}
+
+	return values
+}
+
+func outputForEachArgError(err error) {
+	if commandErr, ok := err.(output.CommandError); ok {
+		output.OutputCommandError(commandErr)
+		return
+	}
+
+	output.OutputCommandError(output.NewCommandError(err))
+}
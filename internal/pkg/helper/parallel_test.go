@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/cli/test"
+)
+
+func newTestParallelCmd(parallel int) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("parallel", parallel, "")
+
+	return cmd
+}
+
+func TestAddParallelFlag(t *testing.T) {
+	cmd := &cobra.Command{}
+
+	AddParallelFlag(cmd)
+
+	parallel, err := cmd.Flags().GetInt("parallel")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, parallel)
+}
+
+func TestForEachArg(t *testing.T) {
+	t.Run("Parallel_ResultsAndErrorsInArgOrder", func(t *testing.T) {
+		args := []string{"1", "2", "3", "4", "5", "6"}
+		delays := map[string]time.Duration{"1": 40, "2": 30, "3": 20, "4": 10, "5": 0, "6": 0}
+
+		var results []interface{}
+		stdErr := test.CatchStdErr(t, func() {
+			results = ForEachArg(newTestParallelCmd(3), args, func(arg string) (interface{}, error) {
+				time.Sleep(delays[arg] * time.Millisecond)
+				if arg == "2" || arg == "5" {
+					return nil, output.NewCommandErrorf("Error retrieving resource [%s]", arg)
+				}
+
+				return "resource" + arg, nil
+			})
+		})
+
+		assert.Equal(t, []interface{}{"resource1", "resource3", "resource4", "resource6"}, results)
+		assert.Equal(t, "Error retrieving resource [2]\nError retrieving resource [5]\n", stdErr)
+	})
+
+	t.Run("Parallel_BoundsConcurrency", func(t *testing.T) {
+		var running, maxRunning int32
+		ForEachArg(newTestParallelCmd(2), []string{"1", "2", "3", "4", "5"}, func(arg string) (interface{}, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+
+			return nil, nil
+		})
+
+		assert.Equal(t, int32(2), maxRunning)
+	})
+
+	t.Run("NoParallelFlag_InvokesSequentially", func(t *testing.T) {
+		var invoked []string
+		results := ForEachArg(&cobra.Command{}, []string{"1", "2"}, func(arg string) (interface{}, error) {
+			invoked = append(invoked, arg)
+			return nil, nil
+		})
+
+		assert.Equal(t, []string{"1", "2"}, invoked)
+		assert.Nil(t, results)
+	})
+
+	t.Run("NonCommandError_OutputsError", func(t *testing.T) {
+		stdErr := test.CatchStdErr(t, func() {
+			ForEachArg(&cobra.Command{}, []string{"1"}, func(arg string) (interface{}, error) {
+				return nil, errors.New("test error")
+			})
+		})
+
+		assert.Equal(t, "test error\n", stdErr)
+	})
+}
@@ -0,0 +1,97 @@
+package input
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/config"
+	"github.com/ukfast/cli/internal/pkg/output"
+)
+
+// ErrConfirmationDeclined is returned when the user declines a confirmation prompt
+var ErrConfirmationDeclined = errors.New("Aborted")
+
+// IsTerminal returns true if stdin is attached to a terminal
+var IsTerminal = func() bool {
+	return output.IsTerminal(os.Stdin)
+}
+
+// CommandConfirm prompts the user to confirm the given action against the resources returned
+// by describe. Confirmation is skipped when the 'yes' flag is set (overriding 'command_confirm'),
+// in dry-run mode (as no changes will be made), or when stdin isn't a terminal, unless the
+// 'no-input' flag or 'command_confirm' config are set, in which case an error is returned as
+// confirmation can't be obtained. describe is only invoked when prompting, so that resource names
+// aren't needlessly retrieved
+func CommandConfirm(cmd *cobra.Command, action string, describe func() []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+	if yes || config.GetBool("api_dry_run") {
+		return nil
+	}
+
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	if noInput {
+		return errors.New("Confirmation required with --no-input, use --yes to confirm")
+	}
+
+	if !IsTerminal() {
+		if config.GetBool("command_confirm") {
+			return errors.New("Confirmation required as stdin isn't a terminal, use --yes to confirm")
+		}
+
+		return nil
+	}
+
+	return Confirm(action, describe())
+}
+
+// Confirm outputs the given action and affected resources to stderr, and reads confirmation from
+// input. ErrConfirmationDeclined is returned if the response isn't 'y' or 'yes'
+func Confirm(action string, resources []string) error {
+	output.Errorf("%s:", action)
+	for _, resource := range resources {
+		output.Errorf("  - %s", resource)
+	}
+	os.Stderr.WriteString("Continue? [y/N]: ")
+
+	response, err := bufio.NewReader(InputReader()).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("Error reading confirmation from stdin input: %s", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "y", "yes":
+		return nil
+	}
+
+	return ErrConfirmationDeclined
+}
+
+// DescribeResources returns a description for each of ids, including the resource name
+// retrieved via nameFunc where possible
+func DescribeResources(ids []string, nameFunc func(id string) (string, error)) []string {
+	var descriptions []string
+	for _, id := range ids {
+		name, err := nameFunc(id)
+		if err != nil || name == "" || name == id {
+			descriptions = append(descriptions, id)
+			continue
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", id, name))
+	}
+
+	return descriptions
+}
+
+// CommandConfirmResources is a wrapper for CommandConfirm, which describes resources with given
+// ids using DescribeResources
+func CommandConfirmResources(cmd *cobra.Command, action string, ids []string, nameFunc func(id string) (string, error)) error {
+	return CommandConfirm(cmd, action, func() []string {
+		return DescribeResources(ids, nameFunc)
+	})
+}
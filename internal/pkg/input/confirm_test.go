@@ -0,0 +1,161 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test/test_output"
+)
+
+func newTestConfirmCmd(yes bool, noInput bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("yes", yes, "")
+	cmd.Flags().Bool("no-input", noInput, "")
+
+	return cmd
+}
+
+// setTestTerminal overrides terminal detection and input, returning a func to restore them
+func setTestTerminal(terminal bool, input string) func() {
+	oldIsTerminal := IsTerminal
+	oldReader := InputReader
+
+	IsTerminal = func() bool {
+		return terminal
+	}
+	InputReader = func() io.Reader {
+		return bytes.NewReader([]byte(input))
+	}
+
+	return func() {
+		IsTerminal = oldIsTerminal
+		InputReader = oldReader
+	}
+}
+
+func TestCommandConfirm(t *testing.T) {
+	describe := func() []string {
+		return []string{"resource1", "resource2"}
+	}
+
+	t.Run("TerminalConfirmed_ReturnsNil", func(t *testing.T) {
+		defer setTestTerminal(true, "y\n")()
+
+		test_output.AssertErrorOutput(t, "Removing resources:\n  - resource1\n  - resource2\nContinue? [y/N]: ", func() {
+			err := CommandConfirm(newTestConfirmCmd(false, false), "Removing resources", describe)
+
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("TerminalDeclined_ReturnsError", func(t *testing.T) {
+		defer setTestTerminal(true, "n\n")()
+
+		test_output.AssertErrorOutputFunc(t, func(stdErr string) {}, func() {
+			err := CommandConfirm(newTestConfirmCmd(false, false), "Removing resources", describe)
+
+			assert.Equal(t, ErrConfirmationDeclined, err)
+		})
+	})
+
+	t.Run("YesFlag_SkipsPrompt", func(t *testing.T) {
+		defer setTestTerminal(true, "")()
+
+		err := CommandConfirm(newTestConfirmCmd(true, true), "Removing resources", func() []string {
+			t.Fatal("describe shouldn't be invoked")
+			return nil
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("DryRun_SkipsPrompt", func(t *testing.T) {
+		defer setTestTerminal(true, "")()
+		viper.Set("api_dry_run", true)
+		defer viper.Reset()
+
+		err := CommandConfirm(newTestConfirmCmd(false, true), "Removing resources", describe)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("NoInputFlag_ReturnsError", func(t *testing.T) {
+		defer setTestTerminal(true, "y\n")()
+
+		err := CommandConfirm(newTestConfirmCmd(false, true), "Removing resources", describe)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Confirmation required with --no-input, use --yes to confirm", err.Error())
+	})
+
+	t.Run("NotTerminal_SkipsPrompt", func(t *testing.T) {
+		defer setTestTerminal(false, "")()
+
+		err := CommandConfirm(newTestConfirmCmd(false, false), "Removing resources", describe)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("NotTerminalWithCommandConfirmConfig_ReturnsError", func(t *testing.T) {
+		defer setTestTerminal(false, "")()
+		viper.Set("command_confirm", true)
+		defer viper.Reset()
+
+		err := CommandConfirm(newTestConfirmCmd(false, false), "Removing resources", describe)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Confirmation required as stdin isn't a terminal, use --yes to confirm", err.Error())
+	})
+
+	t.Run("NotTerminalWithCommandConfirmConfigAndYesFlag_SkipsPrompt", func(t *testing.T) {
+		defer setTestTerminal(false, "")()
+		viper.Set("command_confirm", true)
+		defer viper.Reset()
+
+		err := CommandConfirm(newTestConfirmCmd(true, false), "Removing resources", describe)
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	t.Run("YesResponse_ReturnsNil", func(t *testing.T) {
+		defer setTestTerminal(true, " YES \n")()
+
+		test_output.AssertErrorOutputFunc(t, func(stdErr string) {}, func() {
+			err := Confirm("Removing resources", []string{"resource1"})
+
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("EmptyResponse_ReturnsError", func(t *testing.T) {
+		defer setTestTerminal(true, "")()
+
+		test_output.AssertErrorOutputFunc(t, func(stdErr string) {}, func() {
+			err := Confirm("Removing resources", []string{"resource1"})
+
+			assert.Equal(t, ErrConfirmationDeclined, err)
+		})
+	})
+}
+
+func TestDescribeResources(t *testing.T) {
+	t.Run("IncludesResolvedNames", func(t *testing.T) {
+		names := map[string]string{"id1": "name1", "id2": "id2"}
+
+		descriptions := DescribeResources([]string{"id1", "id2", "id3"}, func(id string) (string, error) {
+			if id == "id3" {
+				return "", errors.New("test error")
+			}
+			return names[id], nil
+		})
+
+		assert.Equal(t, []string{"id1 (name1)", "id2", "id3"}, descriptions)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/ukfast/cli/internal/pkg/output"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var InputReader = func() io.Reader {
@@ -34,3 +35,50 @@ func ReadInput(name string) (string, error) {
 
 	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
+
+// ReadSecret outputs prompt to stderr and reads a single line secret value, such as an API key
+// or passphrase, from input. When stdin is a terminal, the value isn't echoed
+func ReadSecret(prompt string) (string, error) {
+	os.Stderr.WriteString(prompt + ": ")
+
+	if IsTerminal() {
+		secret, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		os.Stderr.WriteString("\n")
+		if err != nil {
+			return "", fmt.Errorf("Error reading %s from terminal: %s", strings.ToLower(prompt), err)
+		}
+
+		return strings.TrimSpace(string(secret)), nil
+	}
+
+	secret, err := readLine(InputReader())
+	if err != nil {
+		return "", fmt.Errorf("Error reading %s from stdin input: %s", strings.ToLower(prompt), err)
+	}
+
+	return strings.TrimSpace(secret), nil
+}
+
+// readLine reads a single line from r. Bytes are read individually rather than buffered, so that
+// subsequent lines remain available for further reads
+func readLine(r io.Reader) (string, error) {
+	buf := bytes.Buffer{}
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				break
+			}
+			buf.WriteByte(b[0])
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
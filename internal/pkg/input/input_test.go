@@ -55,3 +55,43 @@ func TestReadInput(t *testing.T) {
 		assert.Equal(t, "test text", text)
 	})
 }
+
+func TestReadSecret(t *testing.T) {
+	t.Run("NotTerminal_ReadsLinesFromInput", func(t *testing.T) {
+		oldReader := InputReader
+		oldIsTerminal := IsTerminal
+		defer func() { InputReader = oldReader; IsTerminal = oldIsTerminal }()
+
+		IsTerminal = func() bool { return false }
+		reader := bytes.NewReader([]byte("secret1\nsecret2\n"))
+		InputReader = func() io.Reader {
+			return reader
+		}
+
+		first, err := ReadSecret("Passphrase")
+		assert.Nil(t, err)
+		second, err := ReadSecret("Confirm passphrase")
+		assert.Nil(t, err)
+
+		assert.Equal(t, "secret1", first)
+		assert.Equal(t, "secret2", second)
+	})
+
+	t.Run("StdinReadError_ReturnsError", func(t *testing.T) {
+		oldReader := InputReader
+		oldIsTerminal := IsTerminal
+		defer func() { InputReader = oldReader; IsTerminal = oldIsTerminal }()
+
+		IsTerminal = func() bool { return false }
+		InputReader = func() io.Reader {
+			return &test_input.TestReadCloser{
+				ReadError: errors.New("test error"),
+			}
+		}
+
+		_, err := ReadSecret("Passphrase")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error reading passphrase from stdin input: test error", err.Error())
+	})
+}
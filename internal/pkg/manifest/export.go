@@ -0,0 +1,54 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// NewResource returns a resource of given kind and name, with spec containing the given fields of
+// model (typically an SDK model struct). Fields with a nil value are omitted
+func NewResource(kind string, name string, model interface{}, fields ...string) (*Resource, error) {
+	modelMap, err := normalize(model)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := make(map[string]interface{})
+	for _, field := range fields {
+		if value, ok := modelMap[field]; ok && value != nil {
+			spec[field] = value
+		}
+	}
+
+	return &Resource{Kind: kind, Name: name, Spec: spec}, nil
+}
+
+// Add adds resource r to the manifest. Where a resource of the same kind and name already exists,
+// the name of r is suffixed, with the original name retained within spec
+func (m *Manifest) Add(r *Resource) {
+	name := r.Name
+	for i := 2; m.Find(r.Kind, r.Name) != nil; i++ {
+		r.Name = fmt.Sprintf("%s-%d", name, i)
+	}
+	if r.Name != name && r.Spec["name"] == nil {
+		r.Spec["name"] = name
+	}
+
+	m.Resources = append(m.Resources, r)
+}
+
+// Marshal returns the manifest as YAML
+func (m *Manifest) Marshal() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buf)
+
+	err := encoder.Encode(m)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	return buf.Bytes(), err
+}
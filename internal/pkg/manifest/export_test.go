@@ -0,0 +1,69 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResource(t *testing.T) {
+	t.Run("ReturnsGivenFields", func(t *testing.T) {
+		model := struct {
+			ID       string  `json:"id"`
+			Size     int     `json:"size"`
+			Region   string  `json:"region"`
+			ParentID *string `json:"parent_id"`
+		}{ID: "id-1", Size: 2, Region: "a"}
+
+		r, err := NewResource("test/a", "a1", model, "size", "parent_id", "missing")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "test/a", r.Kind)
+		assert.Equal(t, "a1", r.Name)
+		assert.Equal(t, map[string]interface{}{"size": float64(2)}, r.Spec)
+	})
+}
+
+func TestManifest_Add(t *testing.T) {
+	t.Run("UniqueName_AddsResource", func(t *testing.T) {
+		m := &Manifest{}
+
+		m.Add(&Resource{Kind: "test/a", Name: "a1", Spec: map[string]interface{}{}})
+		m.Add(&Resource{Kind: "test/b", Name: "a1", Spec: map[string]interface{}{}})
+
+		assert.Equal(t, "a1", m.Resources[0].Name)
+		assert.Equal(t, "a1", m.Resources[1].Name)
+		assert.Nil(t, m.Resources[1].Spec["name"])
+	})
+
+	t.Run("DuplicateName_SuffixesNameAndRetainsNameInSpec", func(t *testing.T) {
+		m := &Manifest{}
+
+		m.Add(&Resource{Kind: "test/a", Name: "a1", Spec: map[string]interface{}{}})
+		m.Add(&Resource{Kind: "test/a", Name: "a1", Spec: map[string]interface{}{}})
+		m.Add(&Resource{Kind: "test/a", Name: "a1", Spec: map[string]interface{}{}})
+
+		assert.Equal(t, "a1-2", m.Resources[1].Name)
+		assert.Equal(t, "a1", m.Resources[1].Spec["name"])
+		assert.Equal(t, "a1-3", m.Resources[2].Name)
+	})
+}
+
+func TestManifest_Marshal(t *testing.T) {
+	t.Run("RoundTrips", func(t *testing.T) {
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/a", Name: "a1", Spec: map[string]interface{}{"size": 2, "tags": map[string]interface{}{"env": "test"}}},
+			{Kind: "test/b", Name: "b1"},
+		}}
+
+		content, err := m.Marshal()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "resources:\n- kind: test/a\n  name: a1\n  spec:\n    size: 2\n    tags:\n      env: test\n- kind: test/b\n  name: b1\n", string(content))
+
+		parsed, err := Parse(content)
+
+		assert.Nil(t, err)
+		assert.Len(t, parsed.Resources, 2)
+	})
+}
@@ -0,0 +1,137 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Spec contains the properties of a resource, keyed by API property name. References to other
+// resources are resolved to resource IDs before being passed to a Handler
+type Spec map[string]interface{}
+
+// Decode decodes spec into out, typically an SDK request struct, via JSON
+func (s Spec) Decode(out interface{}) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(b, out)
+	if err != nil {
+		return fmt.Errorf("invalid spec: %s", err)
+	}
+
+	return nil
+}
+
+// Subset returns a copy of spec containing only given fields
+func (s Spec) Subset(fields []string) Spec {
+	subset := make(Spec)
+	for _, field := range fields {
+		if value, ok := s[field]; ok {
+			subset[field] = value
+		}
+	}
+
+	return subset
+}
+
+// String returns the value of field as a string
+func (s Spec) String(field string) string {
+	if s[field] == nil {
+		return ""
+	}
+
+	return fmt.Sprint(s[field])
+}
+
+// Matches returns true if given fields of model (typically an SDK model struct) are equal to those
+// within spec, with values compared via JSON
+func (s Spec) Matches(model interface{}, fields []string) (bool, error) {
+	modelMap, err := normalize(model)
+	if err != nil {
+		return false, err
+	}
+	specMap, err := normalize(s)
+	if err != nil {
+		return false, err
+	}
+
+	for _, field := range fields {
+		if !reflect.DeepEqual(modelMap[field], specMap[field]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Handler manages resources of a single kind
+type Handler interface {
+	// References returns spec fields which reference other resources, keyed by field with the
+	// referenced kind as value, e.g. {"vpc_id": "ecloud/vpc"}
+	References() map[string]string
+	// UpdatableFields returns spec fields which can be updated for an existing resource
+	UpdatableFields() []string
+	// Find returns the ID and current state of the resource described by spec, or a nil ID if
+	// the resource doesn't exist. Current state is typically an SDK model struct
+	Find(spec Spec) (id interface{}, current interface{}, err error)
+	// Create creates a resource, returning its ID once created
+	Create(spec Spec) (id interface{}, err error)
+	// Update updates given fields of an existing resource
+	Update(id interface{}, spec Spec, fields []string) error
+	// Delete removes an existing resource
+	Delete(id interface{}, spec Spec) error
+}
+
+// UnnamedHandler is implemented by handlers for resources which aren't identified by name, such as
+// rules. The name of these resources is used only within the manifest, and isn't set within spec
+type UnnamedHandler interface {
+	Handler
+	Unnamed()
+}
+
+// Registry holds handlers keyed by resource kind
+type Registry struct {
+	handlers map[string]Handler
+}
+
+// NewRegistry returns a new, empty Registry
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]Handler)}
+}
+
+// Register registers handler for given kind
+func (r *Registry) Register(kind string, handler Handler) {
+	r.handlers[kind] = handler
+}
+
+// RegisterAll registers all handlers within given map, keyed by kind
+func (r *Registry) RegisterAll(handlers map[string]Handler) {
+	for kind, handler := range handlers {
+		r.Register(kind, handler)
+	}
+}
+
+// Get returns the handler for given kind
+func (r *Registry) Get(kind string) (Handler, error) {
+	handler, ok := r.handlers[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported kind [%s]", kind)
+	}
+
+	return handler, nil
+}
+
+// Kinds returns a sorted slice of registered kinds
+func (r *Registry) Kinds() []string {
+	var kinds []string
+	for kind := range r.handlers {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+	return kinds
+}
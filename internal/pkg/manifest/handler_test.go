@@ -0,0 +1,95 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec_Decode(t *testing.T) {
+	t.Run("Valid_DecodesIntoStruct", func(t *testing.T) {
+		var out struct {
+			Name string `json:"name"`
+			TTL  int    `json:"ttl"`
+		}
+
+		err := Spec{"name": "test", "ttl": 3600}.Decode(&out)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "test", out.Name)
+		assert.Equal(t, 3600, out.TTL)
+	})
+
+	t.Run("InvalidType_ReturnsError", func(t *testing.T) {
+		var out struct {
+			TTL int `json:"ttl"`
+		}
+
+		err := Spec{"ttl": "invalid"}.Decode(&out)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSpec_Subset(t *testing.T) {
+	subset := Spec{"name": "test", "ttl": 3600}.Subset([]string{"ttl", "missing"})
+
+	assert.Equal(t, Spec{"ttl": 3600}, subset)
+}
+
+func TestSpec_String(t *testing.T) {
+	spec := Spec{"name": "test", "ttl": 3600}
+
+	assert.Equal(t, "test", spec.String("name"))
+	assert.Equal(t, "3600", spec.String("ttl"))
+	assert.Equal(t, "", spec.String("missing"))
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Get_Registered_ReturnsHandler", func(t *testing.T) {
+		handler := newTestHandler()
+		registry := NewRegistry()
+		registry.RegisterAll(map[string]Handler{"test/a": handler})
+
+		h, err := registry.Get("test/a")
+
+		assert.Nil(t, err)
+		assert.Equal(t, handler, h)
+	})
+
+	t.Run("Get_Unregistered_ReturnsError", func(t *testing.T) {
+		_, err := NewRegistry().Get("test/a")
+
+		assert.Equal(t, "unsupported kind [test/a]", err.Error())
+	})
+
+	t.Run("Kinds_ReturnsSortedKinds", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("test/b", newTestHandler())
+		registry.Register("test/a", newTestHandler())
+
+		assert.Equal(t, []string{"test/a", "test/b"}, registry.Kinds())
+	})
+}
+
+func TestSpec_Matches(t *testing.T) {
+	model := struct {
+		IP         string  `json:"ip"`
+		URI        string  `json:"uri"`
+		RecordName *string `json:"record_name"`
+	}{IP: "1.2.3.4", URI: "/admin"}
+
+	t.Run("Matching_ReturnsTrue", func(t *testing.T) {
+		matches, err := Spec{"ip": "1.2.3.4", "uri": "/admin", "mode": "Allow"}.Matches(model, []string{"ip", "uri", "record_name"})
+
+		assert.Nil(t, err)
+		assert.True(t, matches)
+	})
+
+	t.Run("NotMatching_ReturnsFalse", func(t *testing.T) {
+		matches, err := Spec{"ip": "1.2.3.4", "uri": "/"}.Matches(model, []string{"ip", "uri"})
+
+		assert.Nil(t, err)
+		assert.False(t, matches)
+	})
+}
@@ -0,0 +1,158 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	StatePresent = "present"
+	StateAbsent  = "absent"
+)
+
+// Resource is a single resource described within a manifest
+type Resource struct {
+	// Kind is the kind of resource, e.g. 'ecloud/vpc'
+	Kind string `yaml:"kind" json:"kind"`
+	// Name is the name of the resource, which identifies the resource and may be used to
+	// reference the resource from other resources in the manifest
+	Name string `yaml:"name" json:"name"`
+	// State is the desired state of the resource, either 'present' (default) or 'absent'
+	State string `yaml:"state,omitempty" json:"state"`
+	// Spec contains the desired properties of the resource, using API property names
+	Spec map[string]interface{} `yaml:"spec,omitempty" json:"spec"`
+}
+
+// String returns the kind and name of resource r
+func (r *Resource) String() string {
+	return fmt.Sprintf("%s [%s]", r.Kind, r.Name)
+}
+
+// Manifest is a set of resources
+type Manifest struct {
+	Resources []*Resource `yaml:"resources"`
+}
+
+// Parse parses a YAML manifest. Multiple YAML documents may be included, with each document
+// containing a 'resources' list
+func Parse(content []byte) (*Manifest, error) {
+	m := &Manifest{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc Manifest
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %s", err)
+		}
+
+		m.Resources = append(m.Resources, doc.Resources...)
+	}
+
+	for _, r := range m.Resources {
+		if r.Spec == nil {
+			r.Spec = make(map[string]interface{})
+		}
+		r.Spec = normalizeYAML(r.Spec).(map[string]interface{})
+		if r.State == "" {
+			r.State = StatePresent
+		}
+	}
+
+	return m, m.Validate()
+}
+
+// ReadFiles reads and merges the manifests at given paths
+func ReadFiles(fs afero.Fs, paths []string) (*Manifest, error) {
+	if len(paths) < 1 {
+		return nil, errors.New("At least one manifest file must be specified")
+	}
+
+	m := &Manifest{}
+	for _, path := range paths {
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest [%s]: %s", path, err)
+		}
+
+		fileManifest, err := Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest [%s]: %s", path, err)
+		}
+
+		m.Resources = append(m.Resources, fileManifest.Resources...)
+	}
+
+	return m, m.Validate()
+}
+
+// Validate returns an error if any resource is missing a kind or name, has an invalid state,
+// or is defined more than once
+func (m *Manifest) Validate() error {
+	seen := make(map[string]bool)
+	for i, r := range m.Resources {
+		if r.Kind == "" {
+			return fmt.Errorf("resource %d: missing kind", i+1)
+		}
+		if r.Name == "" {
+			return fmt.Errorf("resource %d: missing name", i+1)
+		}
+		if r.State != StatePresent && r.State != StateAbsent {
+			return fmt.Errorf("%s: invalid state [%s], expected '%s' or '%s'", r, r.State, StatePresent, StateAbsent)
+		}
+
+		key := r.Kind + "/" + r.Name
+		if seen[key] {
+			return fmt.Errorf("%s: defined more than once", r)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// Find returns the resource with given kind and name, or nil if not found
+func (m *Manifest) Find(kind string, name string) *Resource {
+	for _, r := range m.Resources {
+		if r.Kind == kind && r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// normalizeYAML converts maps unmarshalled by yaml (map[interface{}]interface{}) into
+// map[string]interface{}, so they can be marshalled as JSON
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for key, value := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for key, value := range v {
+			m[key] = normalizeYAML(value)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			s[i] = normalizeYAML(value)
+		}
+		return s
+	}
+
+	return value
+}
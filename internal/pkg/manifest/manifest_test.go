@@ -0,0 +1,114 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("MultipleDocuments_ReturnsMergedResources", func(t *testing.T) {
+		content := []byte(`
+resources:
+  - kind: ecloud/vpc
+    name: vpc1
+    spec:
+      region_id: reg-abcdef12
+      tags:
+        env: test
+---
+resources:
+  - kind: safedns/zone
+    name: example.com
+    state: absent
+`)
+
+		m, err := Parse(content)
+
+		assert.Nil(t, err)
+		assert.Len(t, m.Resources, 2)
+		assert.Equal(t, "ecloud/vpc", m.Resources[0].Kind)
+		assert.Equal(t, StatePresent, m.Resources[0].State)
+		assert.Equal(t, map[string]interface{}{"env": "test"}, m.Resources[0].Spec["tags"])
+		assert.Equal(t, StateAbsent, m.Resources[1].State)
+		assert.NotNil(t, m.Resources[1].Spec)
+	})
+
+	t.Run("InvalidYAML_ReturnsError", func(t *testing.T) {
+		_, err := Parse([]byte("resources: ["))
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("InvalidResource_ReturnsError", func(t *testing.T) {
+		_, err := Parse([]byte("resources:\n  - name: vpc1"))
+
+		assert.Equal(t, "resource 1: missing kind", err.Error())
+	})
+}
+
+func TestReadFiles(t *testing.T) {
+	t.Run("MultipleFiles_ReturnsMergedResources", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/a.yml", []byte("resources:\n  - kind: ecloud/vpc\n    name: vpc1"), 0644)
+		afero.WriteFile(fs, "/b.yml", []byte("resources:\n  - kind: ecloud/vpc\n    name: vpc2"), 0644)
+
+		m, err := ReadFiles(fs, []string{"/a.yml", "/b.yml"})
+
+		assert.Nil(t, err)
+		assert.Len(t, m.Resources, 2)
+	})
+
+	t.Run("DuplicateAcrossFiles_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/a.yml", []byte("resources:\n  - kind: ecloud/vpc\n    name: vpc1"), 0644)
+		afero.WriteFile(fs, "/b.yml", []byte("resources:\n  - kind: ecloud/vpc\n    name: vpc1"), 0644)
+
+		_, err := ReadFiles(fs, []string{"/a.yml", "/b.yml"})
+
+		assert.Equal(t, "ecloud/vpc [vpc1]: defined more than once", err.Error())
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		_, err := ReadFiles(afero.NewMemMapFs(), []string{"/missing.yml"})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("NoFiles_ReturnsError", func(t *testing.T) {
+		_, err := ReadFiles(afero.NewMemMapFs(), []string{})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestManifest_Validate(t *testing.T) {
+	t.Run("MissingName_ReturnsError", func(t *testing.T) {
+		m := &Manifest{Resources: []*Resource{{Kind: "ecloud/vpc", State: StatePresent}}}
+
+		err := m.Validate()
+
+		assert.Equal(t, "resource 1: missing name", err.Error())
+	})
+
+	t.Run("InvalidState_ReturnsError", func(t *testing.T) {
+		m := &Manifest{Resources: []*Resource{{Kind: "ecloud/vpc", Name: "vpc1", State: "invalid"}}}
+
+		err := m.Validate()
+
+		assert.Contains(t, err.Error(), "invalid state [invalid]")
+	})
+}
+
+func TestManifest_Find(t *testing.T) {
+	m := &Manifest{Resources: []*Resource{{Kind: "ecloud/vpc", Name: "vpc1"}}}
+
+	t.Run("Exists_ReturnsResource", func(t *testing.T) {
+		assert.Equal(t, m.Resources[0], m.Find("ecloud/vpc", "vpc1"))
+	})
+
+	t.Run("DifferentKind_ReturnsNil", func(t *testing.T) {
+		assert.Nil(t, m.Find("ecloud/router", "vpc1"))
+	})
+}
@@ -0,0 +1,389 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionNone   Action = "none"
+)
+
+// Change is a difference between the desired and current value of a spec field
+type Change struct {
+	Field   string      `json:"field"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+	// Updatable is false when the field can't be updated for an existing resource
+	Updatable bool `json:"updatable"`
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s: %s => %s", c.Field, formatValue(c.Current), formatValue(c.Desired))
+	if !c.Updatable {
+		s += " (not updatable)"
+	}
+
+	return s
+}
+
+// Step is a single action to be taken for a resource
+type Step struct {
+	Action   Action    `json:"action"`
+	Resource *Resource `json:"resource"`
+	// ID is the ID of the existing resource, or nil if the resource doesn't yet exist
+	ID      interface{} `json:"id"`
+	Changes []Change    `json:"changes"`
+}
+
+// Plan is an ordered set of steps which bring the current state of resources in line with
+// a manifest
+type Plan struct {
+	Steps []*Step
+
+	registry *Registry
+	manifest *Manifest
+	ids      map[*Resource]interface{}
+}
+
+// HasChanges returns true if any step requires an action
+func (p *Plan) HasChanges() bool {
+	for _, step := range p.Steps {
+		if step.Action != ActionNone {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Summary returns a summary of the number of resources to be created, updated and deleted
+func (p *Plan) Summary() string {
+	counts := make(map[Action]int)
+	for _, step := range p.Steps {
+		counts[step.Action]++
+	}
+
+	return fmt.Sprintf("%d to create, %d to update, %d to delete, %d unchanged", counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete], counts[ActionNone])
+}
+
+// NewPlan builds a plan for manifest m, retrieving the current state of resources via the
+// handlers within registry. Resources to be created or updated are ordered so that referenced
+// resources are created first, followed by resources to be deleted in reverse order
+func NewPlan(registry *Registry, m *Manifest) (*Plan, error) {
+	p := &Plan{
+		registry: registry,
+		manifest: m,
+		ids:      make(map[*Resource]interface{}),
+	}
+
+	ordered, err := p.order()
+	if err != nil {
+		return nil, err
+	}
+
+	var deletes []*Step
+	for _, r := range ordered {
+		step, err := p.planResource(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", r, err)
+		}
+
+		if r.State == StateAbsent {
+			deletes = append([]*Step{step}, deletes...)
+			continue
+		}
+		p.Steps = append(p.Steps, step)
+	}
+	p.Steps = append(p.Steps, deletes...)
+
+	return p, nil
+}
+
+func (p *Plan) planResource(r *Resource) (*Step, error) {
+	handler, _ := p.registry.Get(r.Kind)
+	step := &Step{Action: ActionNone, Resource: r}
+
+	spec, resolved := p.resolve(r)
+	if !resolved {
+		// A referenced resource doesn't yet exist, so neither can this resource
+		if r.State == StatePresent {
+			step.Action = ActionCreate
+		}
+		return step, nil
+	}
+
+	id, current, err := handler.Find(spec)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		if r.State == StatePresent {
+			step.Action = ActionCreate
+		}
+		return step, nil
+	}
+
+	step.ID = id
+	p.ids[r] = id
+
+	if r.State == StateAbsent {
+		step.Action = ActionDelete
+		return step, nil
+	}
+
+	step.Changes, err = diff(spec, current, handler.UpdatableFields())
+	if err != nil {
+		return nil, err
+	}
+	if len(step.Changes) > 0 {
+		step.Action = ActionUpdate
+	}
+
+	return step, nil
+}
+
+// Validate returns an error if any step contains changes which can't be applied
+func (p *Plan) Validate() error {
+	for _, step := range p.Steps {
+		for _, change := range step.Changes {
+			if !change.Updatable {
+				return fmt.Errorf("%s: field [%s] cannot be updated, resource must be removed and recreated", step.Resource, change.Field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply executes each step of the plan in order, calling stepFunc prior to each step
+// requiring an action
+func (p *Plan) Apply(stepFunc func(step *Step)) error {
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	for _, step := range p.Steps {
+		if step.Action == ActionNone {
+			continue
+		}
+		if stepFunc != nil {
+			stepFunc(step)
+		}
+
+		err := p.applyStep(step)
+		if err != nil {
+			return fmt.Errorf("Failed to %s %s: %s", step.Action, step.Resource, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Plan) applyStep(step *Step) error {
+	handler, _ := p.registry.Get(step.Resource.Kind)
+	spec, resolved := p.resolve(step.Resource)
+	if !resolved {
+		return fmt.Errorf("unresolved reference")
+	}
+
+	switch step.Action {
+	case ActionCreate:
+		id, err := handler.Create(spec)
+		if err != nil {
+			return err
+		}
+		step.ID = id
+		p.ids[step.Resource] = id
+	case ActionUpdate:
+		var fields []string
+		for _, change := range step.Changes {
+			fields = append(fields, change.Field)
+		}
+		return handler.Update(step.ID, spec, fields)
+	case ActionDelete:
+		return handler.Delete(step.ID, spec)
+	}
+
+	return nil
+}
+
+// resolve returns the spec for resource r, with references to resources within the manifest
+// replaced with their IDs. The name of the resource is set within spec unless already present, or
+// the resource is unnamed. False is returned if a referenced resource doesn't yet exist
+func (p *Plan) resolve(r *Resource) (Spec, bool) {
+	handler, _ := p.registry.Get(r.Kind)
+
+	spec := make(Spec)
+	for key, value := range r.Spec {
+		spec[key] = value
+	}
+	if _, unnamed := handler.(UnnamedHandler); !unnamed && spec["name"] == nil {
+		spec["name"] = r.Name
+	}
+
+	for field, kind := range handler.References() {
+		referenced := p.reference(kind, spec[field])
+		if referenced == nil {
+			continue
+		}
+
+		id, ok := p.ids[referenced]
+		if !ok {
+			return spec, false
+		}
+		spec[field] = id
+	}
+
+	return spec, true
+}
+
+// reference returns the resource within the manifest of given kind, named by value. Nil is returned
+// if value doesn't name a resource in the manifest, in which case value is treated as an ID
+func (p *Plan) reference(kind string, value interface{}) *Resource {
+	name, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	return p.manifest.Find(kind, name)
+}
+
+// order returns the resources of the manifest ordered such that referenced resources precede
+// the resources referencing them
+func (p *Plan) order() ([]*Resource, error) {
+	var ordered []*Resource
+	visited := make(map[*Resource]bool)
+	visiting := make(map[*Resource]bool)
+
+	var visit func(r *Resource) error
+	visit = func(r *Resource) error {
+		if visited[r] {
+			return nil
+		}
+		if visiting[r] {
+			return fmt.Errorf("%s: circular reference", r)
+		}
+		visiting[r] = true
+
+		handler, err := p.registry.Get(r.Kind)
+		if err != nil {
+			return fmt.Errorf("%s: %s", r, err)
+		}
+
+		var fields []string
+		for field := range handler.References() {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			referenced := p.reference(handler.References()[field], r.Spec[field])
+			if referenced == nil {
+				continue
+			}
+			if r.State == StatePresent && referenced.State == StateAbsent {
+				return fmt.Errorf("%s: references %s, which is marked as absent", r, referenced)
+			}
+
+			err := visit(referenced)
+			if err != nil {
+				return err
+			}
+		}
+
+		visiting[r] = false
+		visited[r] = true
+		ordered = append(ordered, r)
+		return nil
+	}
+
+	for _, r := range p.manifest.Resources {
+		err := visit(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// diff returns changes between the desired spec and current state, for fields within spec which
+// are also present within current state
+func diff(spec Spec, current interface{}, updatableFields []string) ([]Change, error) {
+	currentMap, err := normalize(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredMap, err := normalize(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for field := range desiredMap {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []Change
+	for _, field := range fields {
+		currentValue, ok := currentMap[field]
+		if !ok || reflect.DeepEqual(currentValue, desiredMap[field]) {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Field:     field,
+			Current:   currentValue,
+			Desired:   desiredMap[field],
+			Updatable: contains(updatableFields, field),
+		})
+	}
+
+	return changes, nil
+}
+
+// normalize converts v into a map via JSON, so values can be compared regardless of type
+func normalize(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{})
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return strings.Trim(string(b), "\"")
+}
+
+func contains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,351 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testModel struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+	Size     int    `json:"size"`
+	Region   string `json:"region"`
+}
+
+// testHandler is an in-memory Handler, storing models keyed by ID
+type testHandler struct {
+	references map[string]string
+	prefix     string
+	models     map[string]testModel
+	calls      *[]string
+	nextID     int
+}
+
+func newTestHandler() *testHandler {
+	return &testHandler{prefix: "id", models: make(map[string]testModel), calls: &[]string{}}
+}
+
+func (h *testHandler) References() map[string]string {
+	return h.references
+}
+
+func (h *testHandler) UpdatableFields() []string {
+	return []string{"size"}
+}
+
+func (h *testHandler) Find(spec Spec) (interface{}, interface{}, error) {
+	for id, model := range h.models {
+		if model.Name == spec.String("name") {
+			return id, model, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+func (h *testHandler) Create(spec Spec) (interface{}, error) {
+	model := testModel{}
+	spec.Decode(&model)
+	h.nextID++
+	id := fmt.Sprintf("%s-%d", h.prefix, h.nextID)
+	h.models[id] = model
+	*h.calls = append(*h.calls, "create "+id)
+	return id, nil
+}
+
+func (h *testHandler) Update(id interface{}, spec Spec, fields []string) error {
+	model := h.models[id.(string)]
+	spec.Subset(fields).Decode(&model)
+	h.models[id.(string)] = model
+	*h.calls = append(*h.calls, fmt.Sprintf("update %s %v", id, fields))
+	return nil
+}
+
+func (h *testHandler) Delete(id interface{}, spec Spec) error {
+	delete(h.models, id.(string))
+	*h.calls = append(*h.calls, fmt.Sprintf("delete %s", id))
+	return nil
+}
+
+func newTestRegistry() (*Registry, *testHandler, *testHandler) {
+	calls := &[]string{}
+	parent := &testHandler{prefix: "parent", models: make(map[string]testModel), calls: calls}
+	child := &testHandler{prefix: "child", models: make(map[string]testModel), calls: calls, references: map[string]string{"parent_id": "test/parent"}}
+
+	registry := NewRegistry()
+	registry.Register("test/parent", parent)
+	registry.Register("test/child", child)
+
+	return registry, parent, child
+}
+
+func TestNewPlan(t *testing.T) {
+	t.Run("NewResources_CreatesInDependencyOrder", func(t *testing.T) {
+		registry, _, _ := newTestRegistry()
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "parent1"}},
+			{Kind: "test/parent", Name: "parent1", State: StatePresent, Spec: map[string]interface{}{}},
+		}}
+
+		plan, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.Len(t, plan.Steps, 2)
+		assert.Equal(t, "parent1", plan.Steps[0].Resource.Name)
+		assert.Equal(t, ActionCreate, plan.Steps[0].Action)
+		assert.Equal(t, "child1", plan.Steps[1].Resource.Name)
+		assert.Equal(t, ActionCreate, plan.Steps[1].Action)
+		assert.True(t, plan.HasChanges())
+		assert.Equal(t, "2 to create, 0 to update, 0 to delete, 0 unchanged", plan.Summary())
+	})
+
+	t.Run("ExistingResources_ReturnsChanges", func(t *testing.T) {
+		registry, parent, child := newTestRegistry()
+		parent.models["parent-1"] = testModel{Name: "parent1"}
+		child.models["child-1"] = testModel{Name: "child1", ParentID: "parent-1", Size: 1}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/parent", Name: "parent1", State: StatePresent, Spec: map[string]interface{}{}},
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "parent1", "size": 2}},
+		}}
+
+		plan, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.Equal(t, ActionNone, plan.Steps[0].Action)
+		assert.Equal(t, "parent-1", plan.Steps[0].ID)
+		assert.Equal(t, ActionUpdate, plan.Steps[1].Action)
+		assert.Equal(t, []Change{{Field: "size", Current: float64(1), Desired: float64(2), Updatable: true}}, plan.Steps[1].Changes)
+	})
+
+	t.Run("ReferenceToExternalID_PassesThroughID", func(t *testing.T) {
+		registry, _, child := newTestRegistry()
+		child.models["child-1"] = testModel{Name: "child1", ParentID: "parent-external"}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "parent-external"}},
+		}}
+
+		plan, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.False(t, plan.HasChanges())
+	})
+
+	t.Run("AbsentResources_DeletesInReverseDependencyOrder", func(t *testing.T) {
+		registry, parent, child := newTestRegistry()
+		parent.models["parent-1"] = testModel{Name: "parent1"}
+		child.models["child-1"] = testModel{Name: "child1", ParentID: "parent-1"}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/parent", Name: "parent1", State: StateAbsent, Spec: map[string]interface{}{}},
+			{Kind: "test/child", Name: "child1", State: StateAbsent, Spec: map[string]interface{}{"parent_id": "parent1"}},
+		}}
+
+		plan, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "child1", plan.Steps[0].Resource.Name)
+		assert.Equal(t, ActionDelete, plan.Steps[0].Action)
+		assert.Equal(t, "parent1", plan.Steps[1].Resource.Name)
+		assert.Equal(t, ActionDelete, plan.Steps[1].Action)
+	})
+
+	t.Run("AbsentResourceNotExisting_NoAction", func(t *testing.T) {
+		registry, _, _ := newTestRegistry()
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/parent", Name: "parent1", State: StateAbsent, Spec: map[string]interface{}{}},
+		}}
+
+		plan, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.False(t, plan.HasChanges())
+	})
+
+	t.Run("PresentReferencingAbsent_ReturnsError", func(t *testing.T) {
+		registry, _, _ := newTestRegistry()
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/parent", Name: "parent1", State: StateAbsent, Spec: map[string]interface{}{}},
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "parent1"}},
+		}}
+
+		_, err := NewPlan(registry, m)
+
+		assert.Equal(t, "test/child [child1]: references test/parent [parent1], which is marked as absent", err.Error())
+	})
+
+	t.Run("CircularReference_ReturnsError", func(t *testing.T) {
+		registry, parent, _ := newTestRegistry()
+		parent.references = map[string]string{"parent_id": "test/child"}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/parent", Name: "parent1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "child1"}},
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "parent1"}},
+		}}
+
+		_, err := NewPlan(registry, m)
+
+		assert.Contains(t, err.Error(), "circular reference")
+	})
+
+	t.Run("UnsupportedKind_ReturnsError", func(t *testing.T) {
+		m := &Manifest{Resources: []*Resource{{Kind: "test/unknown", Name: "a", State: StatePresent}}}
+
+		_, err := NewPlan(NewRegistry(), m)
+
+		assert.Equal(t, "test/unknown [a]: unsupported kind [test/unknown]", err.Error())
+	})
+
+	t.Run("FindError_ReturnsError", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("test/error", &errorHandler{testHandler: newTestHandler()})
+		m := &Manifest{Resources: []*Resource{{Kind: "test/error", Name: "a", State: StatePresent}}}
+
+		_, err := NewPlan(registry, m)
+
+		assert.Equal(t, "test/error [a]: test error", err.Error())
+	})
+}
+
+type errorHandler struct {
+	*testHandler
+}
+
+func (h *errorHandler) Find(spec Spec) (interface{}, interface{}, error) {
+	return nil, nil, errors.New("test error")
+}
+
+func TestPlan_Apply(t *testing.T) {
+	t.Run("NewResources_CreatesWithResolvedReferences", func(t *testing.T) {
+		registry, parent, child := newTestRegistry()
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"parent_id": "parent1"}},
+			{Kind: "test/parent", Name: "parent1", State: StatePresent, Spec: map[string]interface{}{}},
+		}}
+		plan, _ := NewPlan(registry, m)
+
+		var applied []string
+		err := plan.Apply(func(step *Step) {
+			applied = append(applied, step.Resource.String())
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"test/parent [parent1]", "test/child [child1]"}, applied)
+		assert.Equal(t, []string{"create parent-1", "create child-1"}, *parent.calls)
+		assert.Equal(t, "parent-1", child.models["child-1"].ParentID)
+		assert.Equal(t, "child1", child.models["child-1"].Name)
+	})
+
+	t.Run("ExistingResources_UpdatesChangedFields", func(t *testing.T) {
+		registry, _, child := newTestRegistry()
+		child.models["child-1"] = testModel{Name: "child1", Size: 1}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"size": 2}},
+		}}
+		plan, _ := NewPlan(registry, m)
+
+		err := plan.Apply(nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"update child-1 [size]"}, *child.calls)
+		assert.Equal(t, 2, child.models["child-1"].Size)
+	})
+
+	t.Run("AbsentResources_Deletes", func(t *testing.T) {
+		registry, parent, _ := newTestRegistry()
+		parent.models["parent-1"] = testModel{Name: "parent1"}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/parent", Name: "parent1", State: StateAbsent, Spec: map[string]interface{}{}},
+		}}
+		plan, _ := NewPlan(registry, m)
+
+		err := plan.Apply(nil)
+
+		assert.Nil(t, err)
+		assert.Empty(t, parent.models)
+	})
+
+	t.Run("NonUpdatableChange_ReturnsError", func(t *testing.T) {
+		registry, _, child := newTestRegistry()
+		child.models["child-1"] = testModel{Name: "child1", Region: "a"}
+		m := &Manifest{Resources: []*Resource{
+			{Kind: "test/child", Name: "child1", State: StatePresent, Spec: map[string]interface{}{"region": "b"}},
+		}}
+		plan, _ := NewPlan(registry, m)
+
+		err := plan.Apply(nil)
+
+		assert.Equal(t, "test/child [child1]: field [region] cannot be updated, resource must be removed and recreated", err.Error())
+		assert.Empty(t, *child.calls)
+	})
+
+	t.Run("CreateError_ReturnsError", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("test/error", &createErrorHandler{testHandler: newTestHandler()})
+		m := &Manifest{Resources: []*Resource{{Kind: "test/error", Name: "a", State: StatePresent}}}
+		plan, _ := NewPlan(registry, m)
+
+		err := plan.Apply(nil)
+
+		assert.Equal(t, "Failed to create test/error [a]: test error", err.Error())
+	})
+}
+
+type createErrorHandler struct {
+	*testHandler
+}
+
+func (h *createErrorHandler) Create(spec Spec) (interface{}, error) {
+	return nil, errors.New("test error")
+}
+
+func TestChange_String(t *testing.T) {
+	t.Run("Updatable", func(t *testing.T) {
+		c := Change{Field: "size", Current: 1, Desired: 2, Updatable: true}
+
+		assert.Equal(t, "size: 1 => 2", c.String())
+	})
+
+	t.Run("NotUpdatable", func(t *testing.T) {
+		c := Change{Field: "region", Current: "a", Desired: nil}
+
+		assert.Equal(t, "region: a => <nil> (not updatable)", c.String())
+	})
+}
+
+type unnamedTestHandler struct {
+	*testHandler
+	specs []Spec
+}
+
+func (h *unnamedTestHandler) Unnamed() {}
+
+func (h *unnamedTestHandler) Find(spec Spec) (interface{}, interface{}, error) {
+	h.specs = append(h.specs, spec)
+	return nil, nil, nil
+}
+
+func TestPlan_ResolveName(t *testing.T) {
+	t.Run("UnnamedHandler_NameNotSetInSpec", func(t *testing.T) {
+		handler := &unnamedTestHandler{testHandler: newTestHandler()}
+		registry := NewRegistry()
+		registry.Register("test/rule", handler)
+		m := &Manifest{Resources: []*Resource{{Kind: "test/rule", Name: "rule-1", State: StatePresent, Spec: map[string]interface{}{"ip": "1.2.3.4"}}}}
+
+		_, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.Equal(t, Spec{"ip": "1.2.3.4"}, handler.specs[0])
+	})
+
+	t.Run("NameInSpec_NameNotOverridden", func(t *testing.T) {
+		registry, parent, _ := newTestRegistry()
+		parent.models["parent-1"] = testModel{Name: "parent"}
+		m := &Manifest{Resources: []*Resource{{Kind: "test/parent", Name: "parent-2", State: StatePresent, Spec: map[string]interface{}{"name": "parent"}}}}
+
+		plan, err := NewPlan(registry, m)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "parent-1", plan.Steps[0].ID)
+	})
+}
@@ -0,0 +1,69 @@
+package mockserver
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+)
+
+// Fixtures contains the items to seed the mock server with, keyed by resource name, e.g.
+//
+//	safedns_zones:
+//	  - name: example.com
+//	safedns_records:
+//	  - zone: example.com
+//	    name: www.example.com
+//	    type: A
+//	    content: 1.2.3.4
+type Fixtures map[string][]map[string]interface{}
+
+// LoadFixtures reads fixtures from the YAML file at given path
+func LoadFixtures(fs afero.Fs, path string) (Fixtures, error) {
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %s", err)
+	}
+
+	var raw map[string][]map[string]interface{}
+	err = yaml.Unmarshal(content, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures: %s", err)
+	}
+
+	fixtures := make(Fixtures)
+	for name, items := range raw {
+		for _, item := range items {
+			fixtures[name] = append(fixtures[name], normalizeYAML(item).(map[string]interface{}))
+		}
+	}
+
+	return fixtures, nil
+}
+
+// normalizeYAML converts maps unmarshalled by yaml (map[interface{}]interface{}) into
+// map[string]interface{}, so they can be marshalled as JSON
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for key, value := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for key, value := range v {
+			m[key] = normalizeYAML(value)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			s[i] = normalizeYAML(value)
+		}
+		return s
+	}
+
+	return value
+}
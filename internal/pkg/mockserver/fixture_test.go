@@ -0,0 +1,38 @@
+package mockserver
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	t.Run("ValidFixtures_ReturnsFixtures", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/fixtures.yml", []byte("ecloud_instances:\n  - id: i-abcdef12\n    sync:\n      status: complete\n"), 0644)
+
+		fixtures, err := LoadFixtures(fs, "/fixtures.yml")
+
+		assert.Nil(t, err)
+		assert.Len(t, fixtures["ecloud_instances"], 1)
+		assert.Equal(t, "complete", fixtures["ecloud_instances"][0]["sync"].(map[string]interface{})["status"])
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		_, err := LoadFixtures(fs, "/fixtures.yml")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("InvalidFixtures_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/fixtures.yml", []byte("ecloud_instances: invalid\n"), 0644)
+
+		_, err := LoadFixtures(fs, "/fixtures.yml")
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,113 @@
+package mockserver
+
+import (
+	"strings"
+)
+
+type IDType int
+
+const (
+	// IDTypeInt specifies resource IDs are incrementing integers
+	IDTypeInt IDType = iota
+	// IDTypePrefixed specifies resource IDs are random strings with a prefix, e.g. 'i-abcdef12'
+	IDTypePrefixed
+	// IDTypeProvided specifies resource IDs are provided when the resource is created, e.g. zone names
+	IDTypeProvided
+)
+
+// Resource describes an API resource emulated by the mock server
+type Resource struct {
+	// Name is the name of the resource, used as the key for fixtures
+	Name string
+	// Path is the path of the resource collection, with '{}' denoting parent resource IDs,
+	// e.g. '/safedns/v1/zones/{}/records'
+	Path string
+	// ParentField is the field storing the ID of the parent resource, for nested resources
+	ParentField string
+	// IDField is the field storing the resource ID
+	IDField  string
+	IDType   IDType
+	IDPrefix string
+	// Sync specifies the resource has an eCloud V2 style sync status, with modifications
+	// creating tasks and transitioning the sync status from 'in-progress' to 'complete'
+	Sync bool
+}
+
+// DefaultResources are the resources emulated by the mock server by default
+var DefaultResources = []Resource{
+	{Name: "safedns_zones", Path: "/safedns/v1/zones", IDField: "name", IDType: IDTypeProvided},
+	{Name: "safedns_records", Path: "/safedns/v1/zones/{}/records", ParentField: "zone", IDField: "id", IDType: IDTypeInt},
+	{Name: "safedns_notes", Path: "/safedns/v1/zones/{}/notes", ParentField: "zone", IDField: "id", IDType: IDTypeInt},
+	{Name: "safedns_templates", Path: "/safedns/v1/templates", IDField: "id", IDType: IDTypeInt},
+	{Name: "ecloud_vpcs", Path: "/ecloud/v2/vpcs", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "vpc-", Sync: true},
+	{Name: "ecloud_instances", Path: "/ecloud/v2/instances", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "i-", Sync: true},
+	{Name: "ecloud_networks", Path: "/ecloud/v2/networks", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "net-", Sync: true},
+	{Name: "ecloud_routers", Path: "/ecloud/v2/routers", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "rtr-", Sync: true},
+	{Name: "ecloud_volumes", Path: "/ecloud/v2/volumes", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "vol-", Sync: true},
+	{Name: "ecloud_floatingips", Path: "/ecloud/v2/floating-ips", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "fip-", Sync: true},
+	{Name: "ecloud_tasks", Path: "/ecloud/v2/tasks", IDField: "id", IDType: IDTypePrefixed, IDPrefix: "task-"},
+	{Name: "loadbalancer_clusters", Path: "/loadbalancers/v2/clusters", IDField: "id", IDType: IDTypeInt},
+	{Name: "loadbalancer_targetgroups", Path: "/loadbalancers/v2/target-groups", IDField: "id", IDType: IDTypeInt},
+	{Name: "loadbalancer_listeners", Path: "/loadbalancers/v2/listeners", IDField: "id", IDType: IDTypeInt},
+}
+
+// routeMatch is the result of matching a request path against a resource
+type routeMatch struct {
+	resource  *Resource
+	parentIDs []string
+	// id is the resource ID, when the request targets a single resource
+	id string
+	// action is the sub-path following the resource ID, e.g. 'power-on'
+	action string
+}
+
+// match attempts to match path against resource r
+func (r *Resource) match(path string) (routeMatch, bool) {
+	pattern := splitPath(r.Path)
+	segments := splitPath(path)
+	if len(segments) < len(pattern) || len(segments) > len(pattern)+2 {
+		return routeMatch{}, false
+	}
+
+	m := routeMatch{resource: r}
+	for i, segment := range pattern {
+		if segment == "{}" {
+			m.parentIDs = append(m.parentIDs, segments[i])
+			continue
+		}
+		if segment != segments[i] {
+			return routeMatch{}, false
+		}
+	}
+
+	if len(segments) > len(pattern) {
+		m.id = segments[len(pattern)]
+	}
+	if len(segments) > len(pattern)+1 {
+		m.action = segments[len(pattern)+1]
+	}
+
+	return m, true
+}
+
+// matchResource returns the most specific resource matching path
+func matchResource(resources []Resource, path string) (routeMatch, bool) {
+	var best routeMatch
+	found := false
+	for i := range resources {
+		m, ok := resources[i].match(path)
+		if !ok {
+			continue
+		}
+		if !found || len(splitPath(m.resource.Path)) > len(splitPath(best.resource.Path)) {
+			best = m
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
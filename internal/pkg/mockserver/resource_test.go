@@ -0,0 +1,49 @@
+package mockserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchResource(t *testing.T) {
+	t.Run("Collection_ReturnsMatch", func(t *testing.T) {
+		m, ok := matchResource(DefaultResources, "/safedns/v1/zones")
+
+		assert.True(t, ok)
+		assert.Equal(t, "safedns_zones", m.resource.Name)
+		assert.Equal(t, "", m.id)
+	})
+
+	t.Run("Item_ReturnsMatchWithID", func(t *testing.T) {
+		m, ok := matchResource(DefaultResources, "/safedns/v1/zones/example.com")
+
+		assert.True(t, ok)
+		assert.Equal(t, "safedns_zones", m.resource.Name)
+		assert.Equal(t, "example.com", m.id)
+	})
+
+	t.Run("NestedItem_ReturnsMostSpecificMatch", func(t *testing.T) {
+		m, ok := matchResource(DefaultResources, "/safedns/v1/zones/example.com/records/123")
+
+		assert.True(t, ok)
+		assert.Equal(t, "safedns_records", m.resource.Name)
+		assert.Equal(t, []string{"example.com"}, m.parentIDs)
+		assert.Equal(t, "123", m.id)
+	})
+
+	t.Run("Action_ReturnsMatchWithAction", func(t *testing.T) {
+		m, ok := matchResource(DefaultResources, "/ecloud/v2/instances/i-abcdef12/power-on")
+
+		assert.True(t, ok)
+		assert.Equal(t, "ecloud_instances", m.resource.Name)
+		assert.Equal(t, "i-abcdef12", m.id)
+		assert.Equal(t, "power-on", m.action)
+	})
+
+	t.Run("Unknown_ReturnsFalse", func(t *testing.T) {
+		_, ok := matchResource(DefaultResources, "/unknown/v1/resources")
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,533 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultPerPage = 15
+
+// Server is a http.Handler emulating UKFast API endpoints for a set of resources, with state held
+// in-memory. Modifications to resources with a sync status (eCloud V2) create tasks, with the sync
+// status of the resource and status of the task transitioning from 'in-progress' to 'complete' after
+// being retrieved SyncPolls times, allowing for commands supporting --wait to be exercised
+type Server struct {
+	SyncPolls int
+
+	resources []Resource
+	mutex     sync.Mutex
+	items     map[string][]map[string]interface{}
+	pending   map[string]int
+	lastID    map[string]int
+}
+
+// NewServer returns a new Server emulating resources, seeded with items from fixtures
+func NewServer(resources []Resource, fixtures Fixtures, syncPolls int) (*Server, error) {
+	s := &Server{
+		SyncPolls: syncPolls,
+		resources: resources,
+		items:     make(map[string][]map[string]interface{}),
+		pending:   make(map[string]int),
+		lastID:    make(map[string]int),
+	}
+
+	for name, items := range fixtures {
+		resource := s.getResource(name)
+		if resource == nil {
+			return nil, fmt.Errorf("unknown fixture resource [%s]", name)
+		}
+
+		for _, item := range items {
+			if resource.ParentField != "" && item[resource.ParentField] == nil {
+				return nil, fmt.Errorf("fixture for resource [%s] missing parent field [%s]", name, resource.ParentField)
+			}
+			if item[resource.IDField] == nil {
+				if resource.IDType == IDTypeProvided {
+					return nil, fmt.Errorf("fixture for resource [%s] missing ID field [%s]", name, resource.IDField)
+				}
+				item[resource.IDField] = s.nextID(resource)
+			}
+			if id, ok := item[resource.IDField].(int); ok && id > s.lastID[name] {
+				s.lastID[name] = id
+			}
+			if resource.Sync && item["sync"] == nil {
+				item["sync"] = map[string]interface{}{"status": "complete", "type": "update"}
+			}
+
+			s.items[name] = append(s.items[name], item)
+		}
+	}
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	m, ok := matchResource(s.resources, r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	parent := s.getParentResource(m.resource)
+	if parent != nil && s.find(parent.Name, nil, m.parentIDs[len(m.parentIDs)-1]) < 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Parent resource [%s] not found", m.parentIDs[len(m.parentIDs)-1]))
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPatch || r.Method == http.MethodPut) {
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil && err.Error() != "EOF" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+	}
+
+	switch {
+	case m.id == "" && r.Method == http.MethodGet:
+		s.handleList(w, r, m)
+	case m.id == "" && r.Method == http.MethodPost:
+		s.handleCreate(w, m, body)
+	case m.id == "":
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	case m.action == "" && r.Method == http.MethodGet:
+		s.handleGet(w, m)
+	case m.action == "" && (r.Method == http.MethodPatch || r.Method == http.MethodPut):
+		s.handleUpdate(w, m, body)
+	case m.action == "" && r.Method == http.MethodDelete:
+		s.handleDelete(w, m)
+	case m.action == "tasks" && m.resource.Sync && r.Method == http.MethodGet:
+		s.handleResourceTasks(w, r, m)
+	case m.action != "" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		s.handleAction(w, m)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request, m routeMatch) {
+	var items []map[string]interface{}
+	for _, item := range s.items[m.resource.Name] {
+		if s.isChild(m, item) {
+			items = append(items, item)
+		}
+	}
+
+	s.writeList(w, r.URL.Query(), m.resource, items)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, m routeMatch) {
+	i := s.find(m.resource.Name, &m, m.id)
+	if i < 0 {
+		writeNotFound(w, m)
+		return
+	}
+
+	item := s.items[m.resource.Name][i]
+	s.poll(m.resource, item)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": item})
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, m routeMatch, body map[string]interface{}) {
+	item := make(map[string]interface{})
+	for key, value := range body {
+		item[key] = value
+	}
+
+	if m.resource.IDType == IDTypeProvided {
+		id, _ := item[m.resource.IDField].(string)
+		if id == "" {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Field [%s] is required", m.resource.IDField))
+			return
+		}
+		if s.find(m.resource.Name, &m, id) >= 0 {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Resource [%s] already exists", id))
+			return
+		}
+	} else {
+		item[m.resource.IDField] = s.nextID(m.resource)
+	}
+	if m.resource.ParentField != "" {
+		item[m.resource.ParentField] = m.parentIDs[len(m.parentIDs)-1]
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	item["created_at"] = now
+	item["updated_at"] = now
+
+	s.items[m.resource.Name] = append(s.items[m.resource.Name], item)
+
+	data := map[string]interface{}{"id": item[m.resource.IDField]}
+	if m.resource.Sync {
+		data["task_id"] = s.startSync(m.resource, item, "update", "create")
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"data": data})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": data})
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, m routeMatch, body map[string]interface{}) {
+	i := s.find(m.resource.Name, &m, m.id)
+	if i < 0 {
+		writeNotFound(w, m)
+		return
+	}
+
+	item := s.items[m.resource.Name][i]
+	for key, value := range body {
+		if key == m.resource.IDField || key == m.resource.ParentField {
+			continue
+		}
+		item[key] = value
+	}
+	item["updated_at"] = time.Now().Format(time.RFC3339)
+
+	data := map[string]interface{}{"id": item[m.resource.IDField]}
+	if m.resource.Sync {
+		data["task_id"] = s.startSync(m.resource, item, "update", "update")
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"data": data})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, m routeMatch) {
+	i := s.find(m.resource.Name, &m, m.id)
+	if i < 0 {
+		writeNotFound(w, m)
+		return
+	}
+
+	items := s.items[m.resource.Name]
+	s.items[m.resource.Name] = append(items[:i:i], items[i+1:]...)
+	delete(s.pending, pendingKey(m.resource.Name, m.id))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAction(w http.ResponseWriter, m routeMatch) {
+	i := s.find(m.resource.Name, &m, m.id)
+	if i < 0 {
+		writeNotFound(w, m)
+		return
+	}
+
+	if !m.resource.Sync {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	item := s.items[m.resource.Name][i]
+	taskID := s.startSync(m.resource, item, "update", m.action)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"data": map[string]interface{}{"id": item[m.resource.IDField], "task_id": taskID}})
+}
+
+func (s *Server) handleResourceTasks(w http.ResponseWriter, r *http.Request, m routeMatch) {
+	taskResource := s.getResource("ecloud_tasks")
+	if taskResource == nil || s.find(m.resource.Name, &m, m.id) < 0 {
+		writeNotFound(w, m)
+		return
+	}
+
+	var tasks []map[string]interface{}
+	for _, task := range s.items["ecloud_tasks"] {
+		if fmt.Sprint(task["resource_id"]) == m.id {
+			tasks = append(tasks, task)
+		}
+	}
+
+	s.writeList(w, r.URL.Query(), taskResource, tasks)
+}
+
+// startSync marks item as in-progress and creates a task for the operation, returning the task ID
+func (s *Server) startSync(resource *Resource, item map[string]interface{}, syncType string, operation string) string {
+	item["sync"] = map[string]interface{}{"status": "in-progress", "type": syncType}
+	s.pending[pendingKey(resource.Name, fmt.Sprint(item[resource.IDField]))] = s.SyncPolls
+	s.poll(resource, item)
+
+	taskResource := s.getResource("ecloud_tasks")
+	if taskResource == nil {
+		return ""
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	task := map[string]interface{}{
+		"id":          s.nextID(taskResource),
+		"resource_id": item[resource.IDField],
+		"name":        strings.TrimSuffix(strings.TrimPrefix(resource.Name, "ecloud_"), "s") + "_" + operation,
+		"status":      "in-progress",
+		"created_at":  now,
+		"updated_at":  now,
+	}
+	s.items[taskResource.Name] = append(s.items[taskResource.Name], task)
+	s.pending[pendingKey(taskResource.Name, task["id"].(string))] = s.SyncPolls
+
+	s.poll(taskResource, task)
+
+	return task["id"].(string)
+}
+
+// poll is called each time item is retrieved, completing its sync/task status once retrieved
+// SyncPolls times
+func (s *Server) poll(resource *Resource, item map[string]interface{}) {
+	key := pendingKey(resource.Name, fmt.Sprint(item[resource.IDField]))
+	remaining, ok := s.pending[key]
+	if !ok {
+		return
+	}
+	if remaining > 0 {
+		s.pending[key] = remaining - 1
+		return
+	}
+
+	delete(s.pending, key)
+	if sync, ok := item["sync"].(map[string]interface{}); ok {
+		sync["status"] = "complete"
+	}
+	if _, ok := item["status"]; ok && resource.Name == "ecloud_tasks" {
+		item["status"] = "complete"
+	}
+}
+
+func (s *Server) writeList(w http.ResponseWriter, query url.Values, resource *Resource, items []map[string]interface{}) {
+	items, err := filterItems(query, items)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sortItems(query.Get("sort"), items)
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	totalPages := int(math.Ceil(float64(len(items)) / float64(perPage)))
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	data := items[start:end]
+	if data == nil {
+		data = []map[string]interface{}{}
+	}
+	for _, item := range data {
+		s.poll(resource, item)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": data,
+		"meta": map[string]interface{}{
+			"pagination": map[string]interface{}{
+				"total":       len(items),
+				"count":       len(data),
+				"per_page":    perPage,
+				"total_pages": totalPages,
+			},
+		},
+	})
+}
+
+// find returns the index of the item with given ID for resource, or -1 if not found. If m is
+// provided, the item must also be a child of the matched parent resource
+func (s *Server) find(name string, m *routeMatch, id string) int {
+	resource := s.getResource(name)
+	for i, item := range s.items[name] {
+		if fmt.Sprint(item[resource.IDField]) == id && (m == nil || s.isChild(*m, item)) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) isChild(m routeMatch, item map[string]interface{}) bool {
+	if m.resource.ParentField == "" {
+		return true
+	}
+
+	return fmt.Sprint(item[m.resource.ParentField]) == m.parentIDs[len(m.parentIDs)-1]
+}
+
+func (s *Server) nextID(resource *Resource) interface{} {
+	if resource.IDType == IDTypePrefixed {
+		return fmt.Sprintf("%s%08x", resource.IDPrefix, rand.Uint32())
+	}
+
+	s.lastID[resource.Name]++
+	return s.lastID[resource.Name]
+}
+
+func (s *Server) getResource(name string) *Resource {
+	for i := range s.resources {
+		if s.resources[i].Name == name {
+			return &s.resources[i]
+		}
+	}
+
+	return nil
+}
+
+// getParentResource returns the parent of nested resource r, or nil if r isn't nested
+func (s *Server) getParentResource(r *Resource) *Resource {
+	i := strings.LastIndex(r.Path, "/{}")
+	if i < 0 {
+		return nil
+	}
+
+	for j := range s.resources {
+		if s.resources[j].Path == r.Path[:i] {
+			return &s.resources[j]
+		}
+	}
+
+	return nil
+}
+
+func pendingKey(name string, id string) string {
+	return name + "/" + id
+}
+
+// filterItems filters items using API style filter query parameters, e.g. 'name:lk=test*'
+func filterItems(query url.Values, items []map[string]interface{}) ([]map[string]interface{}, error) {
+	var filtered []map[string]interface{}
+	for _, item := range items {
+		match := true
+		for key, values := range query {
+			if key == "page" || key == "per_page" || key == "sort" {
+				continue
+			}
+
+			property, operator := key, "eq"
+			if i := strings.LastIndex(key, ":"); i >= 0 {
+				property, operator = key[:i], key[i+1:]
+			}
+
+			ok, err := filterMatches(item[property], operator, values[0])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered, nil
+}
+
+func filterMatches(value interface{}, operator string, filter string) (bool, error) {
+	str := fmt.Sprint(value)
+	switch operator {
+	case "eq":
+		return str == filter, nil
+	case "neq":
+		return str != filter, nil
+	case "lk":
+		matched, _ := path.Match(strings.ToLower(filter), strings.ToLower(str))
+		return matched, nil
+	case "nlk":
+		matched, _ := path.Match(strings.ToLower(filter), strings.ToLower(str))
+		return !matched, nil
+	case "in", "nin":
+		in := false
+		for _, f := range strings.Split(filter, ",") {
+			if str == f {
+				in = true
+			}
+		}
+		return in == (operator == "in"), nil
+	case "lt", "gt":
+		a, errA := strconv.ParseFloat(str, 64)
+		b, errB := strconv.ParseFloat(filter, 64)
+		if errA != nil || errB != nil {
+			return false, nil
+		}
+		if operator == "lt" {
+			return a < b, nil
+		}
+		return a > b, nil
+	}
+
+	return false, fmt.Errorf("Unsupported filter operator [%s]", operator)
+}
+
+// sortItems sorts items using an API style sort query parameter, e.g. 'name:desc'
+func sortItems(sorting string, items []map[string]interface{}) {
+	if sorting == "" {
+		return
+	}
+
+	property, direction := sorting, "asc"
+	if i := strings.LastIndex(sorting, ":"); i >= 0 {
+		property, direction = sorting[:i], sorting[i+1:]
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if direction == "desc" {
+			return compareValues(items[j][property], items[i][property])
+		}
+		return compareValues(items[i][property], items[j][property])
+	})
+}
+
+func compareValues(a interface{}, b interface{}) bool {
+	fa, errA := strconv.ParseFloat(fmt.Sprint(a), 64)
+	fb, errB := strconv.ParseFloat(fmt.Sprint(b), 64)
+	if errA == nil && errB == nil {
+		return fa < fb
+	}
+
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func writeNotFound(w http.ResponseWriter, m routeMatch) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Resource [%s] not found", m.id))
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{"title": http.StatusText(status), "detail": detail, "status": status},
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,166 @@
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, syncPolls int) *Server {
+	s, err := NewServer(DefaultResources, Fixtures{
+		"safedns_zones": {
+			{"name": "example.com"},
+		},
+		"safedns_records": {
+			{"zone": "example.com", "id": 1, "name": "www.example.com"},
+			{"zone": "example.com", "id": 2, "name": "mail.example.com"},
+			{"zone": "example.com", "id": 3, "name": "ftp.example.com"},
+		},
+		"ecloud_instances": {
+			{"id": "i-abcdef12", "name": "test"},
+		},
+	}, syncPolls)
+	assert.Nil(t, err)
+
+	return s
+}
+
+func doTestRequest(s *Server, method string, path string, body interface{}) (int, map[string]interface{}) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&reqBody).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, path, &reqBody)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	var respBody map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&respBody)
+
+	return w.Code, respBody
+}
+
+func TestNewServer(t *testing.T) {
+	t.Run("UnknownFixtureResource_ReturnsError", func(t *testing.T) {
+		_, err := NewServer(DefaultResources, Fixtures{"unknown": {{"id": 1}}}, 0)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("FixtureMissingParent_ReturnsError", func(t *testing.T) {
+		_, err := NewServer(DefaultResources, Fixtures{"safedns_records": {{"id": 1}}}, 0)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	t.Run("List_ReturnsPaginatedItems", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, body := doTestRequest(s, "GET", "/safedns/v1/zones/example.com/records?page=2&per_page=2", nil)
+
+		assert.Equal(t, 200, code)
+		assert.Len(t, body["data"], 1)
+		pagination := body["meta"].(map[string]interface{})["pagination"].(map[string]interface{})
+		assert.Equal(t, float64(3), pagination["total"])
+		assert.Equal(t, float64(2), pagination["total_pages"])
+	})
+
+	t.Run("ListWithFilterAndSort_ReturnsFilteredItems", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, body := doTestRequest(s, "GET", "/safedns/v1/zones/example.com/records?id:gt=1&sort=name:asc", nil)
+
+		assert.Equal(t, 200, code)
+		assert.Len(t, body["data"], 2)
+		assert.Equal(t, "ftp.example.com", body["data"].([]interface{})[0].(map[string]interface{})["name"])
+	})
+
+	t.Run("MissingParent_Returns404", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, _ := doTestRequest(s, "GET", "/safedns/v1/zones/missing.com/records", nil)
+
+		assert.Equal(t, 404, code)
+	})
+
+	t.Run("CreateNested_AssignsIDAndParent", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, body := doTestRequest(s, "POST", "/safedns/v1/zones/example.com/records", map[string]interface{}{"name": "test.example.com"})
+		assert.Equal(t, 201, code)
+		assert.Equal(t, float64(4), body["data"].(map[string]interface{})["id"])
+
+		code, body = doTestRequest(s, "GET", "/safedns/v1/zones/example.com/records/4", nil)
+		assert.Equal(t, 200, code)
+		assert.Equal(t, "example.com", body["data"].(map[string]interface{})["zone"])
+	})
+
+	t.Run("CreateProvidedIDExists_Returns409", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, _ := doTestRequest(s, "POST", "/safedns/v1/zones", map[string]interface{}{"name": "example.com"})
+
+		assert.Equal(t, 409, code)
+	})
+
+	t.Run("UpdateAndDelete_ModifiesItem", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, _ := doTestRequest(s, "PATCH", "/safedns/v1/zones/example.com", map[string]interface{}{"description": "updated"})
+		assert.Equal(t, 200, code)
+		_, body := doTestRequest(s, "GET", "/safedns/v1/zones/example.com", nil)
+		assert.Equal(t, "updated", body["data"].(map[string]interface{})["description"])
+
+		code, _ = doTestRequest(s, "DELETE", "/safedns/v1/zones/example.com", nil)
+		assert.Equal(t, 204, code)
+		code, _ = doTestRequest(s, "GET", "/safedns/v1/zones/example.com", nil)
+		assert.Equal(t, 404, code)
+	})
+
+	t.Run("CreateSyncResource_TransitionsSyncStatusAndTask", func(t *testing.T) {
+		s := newTestServer(t, 2)
+
+		code, body := doTestRequest(s, "POST", "/ecloud/v2/instances", map[string]interface{}{"name": "new"})
+		assert.Equal(t, 202, code)
+		id := body["data"].(map[string]interface{})["id"].(string)
+		taskID := body["data"].(map[string]interface{})["task_id"].(string)
+
+		_, body = doTestRequest(s, "GET", "/ecloud/v2/instances/"+id, nil)
+		assert.Equal(t, "in-progress", body["data"].(map[string]interface{})["sync"].(map[string]interface{})["status"])
+		_, body = doTestRequest(s, "GET", "/ecloud/v2/instances/"+id, nil)
+		assert.Equal(t, "complete", body["data"].(map[string]interface{})["sync"].(map[string]interface{})["status"])
+
+		_, body = doTestRequest(s, "GET", "/ecloud/v2/tasks/"+taskID, nil)
+		assert.Equal(t, "in-progress", body["data"].(map[string]interface{})["status"])
+		_, body = doTestRequest(s, "GET", "/ecloud/v2/tasks/"+taskID, nil)
+		assert.Equal(t, "complete", body["data"].(map[string]interface{})["status"])
+	})
+
+	t.Run("SyncResourceAction_CreatesTask", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, body := doTestRequest(s, "PUT", "/ecloud/v2/instances/i-abcdef12/power-on", nil)
+		assert.Equal(t, 202, code)
+		assert.NotEmpty(t, body["data"].(map[string]interface{})["task_id"])
+
+		_, body = doTestRequest(s, "GET", "/ecloud/v2/instances/i-abcdef12/tasks", nil)
+		assert.Len(t, body["data"], 1)
+		assert.Equal(t, "complete", body["data"].([]interface{})[0].(map[string]interface{})["status"])
+	})
+
+	t.Run("UnknownResource_Returns404", func(t *testing.T) {
+		s := newTestServer(t, 0)
+
+		code, body := doTestRequest(s, "GET", "/unknown/v1/resources", nil)
+
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.NotNil(t, body["errors"])
+	})
+}
@@ -0,0 +1,283 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+)
+
+var dateRegexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+
+// dateTruncateLengths holds the length of date values truncated to each supported period
+var dateTruncateLengths = map[string]int{
+	"year":  4,
+	"month": 7,
+	"day":   10,
+}
+
+// Aggregate is a function calculated for each group of rows, e.g. 'count' or 'sum:total'
+type Aggregate struct {
+	Function string
+	Field    string
+}
+
+// Name returns the name of the field output for the aggregate, e.g. 'sum_total'
+func (a Aggregate) Name() string {
+	if a.Field == "" {
+		return a.Function
+	}
+
+	return a.Function + "_" + a.Field
+}
+
+// ParseAggregate parses an aggregate in the format 'count', 'sum:<field>' or 'avg:<field>'
+func ParseAggregate(s string) (Aggregate, error) {
+	parts := strings.SplitN(s, ":", 2)
+	a := Aggregate{Function: strings.ToLower(parts[0])}
+	if len(parts) > 1 {
+		a.Field = parts[1]
+	}
+
+	switch {
+	case a.Function == "count" && a.Field == "":
+		return a, nil
+	case (a.Function == "sum" || a.Function == "avg") && a.Field != "":
+		return a, nil
+	}
+
+	return Aggregate{}, clierrors.NewErrValidation(fmt.Errorf("Invalid aggregate [%s], expected one of: count, sum:<field>, avg:<field>", s))
+}
+
+// Aggregation groups rows by the value of a field, calculating aggregates for each group. When no
+// group field is specified, aggregates are calculated for all rows
+type Aggregation struct {
+	GroupBy    string
+	Truncate   string
+	Aggregates []Aggregate
+}
+
+// NewAggregation returns an Aggregation grouping by field groupBy, which may have a date period
+// suffix (e.g. 'date:month') to group date values by year, month or day. When aggregates is empty,
+// rows are counted
+func NewAggregation(groupBy string, aggregates []string) (*Aggregation, error) {
+	a := &Aggregation{}
+
+	if groupBy != "" {
+		parts := strings.SplitN(groupBy, ":", 2)
+		a.GroupBy = parts[0]
+		if len(parts) > 1 {
+			a.Truncate = strings.ToLower(parts[1])
+			if _, ok := dateTruncateLengths[a.Truncate]; !ok {
+				return nil, clierrors.NewErrValidation(fmt.Errorf("Invalid group-by period [%s], expected one of: year, month, day", parts[1]))
+			}
+		}
+	}
+
+	for _, s := range aggregates {
+		aggregate, err := ParseAggregate(s)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Aggregates = append(a.Aggregates, aggregate)
+	}
+
+	if len(a.Aggregates) == 0 {
+		a.Aggregates = []Aggregate{{Function: "count"}}
+	}
+
+	return a, nil
+}
+
+type aggregateGroup struct {
+	key    string
+	count  int
+	totals []float64
+	counts []int
+}
+
+// Apply groups and aggregates rows, returning a row for each group, along with the same values as
+// maps for serialised output formats. Values of monetary fields are summed in pennies, so that
+// totals aren't subject to floating point errors
+func (a *Aggregation) Apply(rows []*OrderedFields) ([]*OrderedFields, []map[string]interface{}, error) {
+	err := a.validateFields(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	monetary := make(map[string]bool)
+	var groups []*aggregateGroup
+	groupIndexes := make(map[string]int)
+	if a.GroupBy == "" {
+		groups = append(groups, a.newGroup(""))
+	}
+
+	for _, row := range rows {
+		var group *aggregateGroup
+		if a.GroupBy == "" {
+			group = groups[0]
+		} else {
+			key := a.groupKey(row)
+			index, ok := groupIndexes[key]
+			if !ok {
+				index = len(groups)
+				groupIndexes[key] = index
+				groups = append(groups, a.newGroup(key))
+			}
+			group = groups[index]
+		}
+
+		group.count++
+		for i, aggregate := range a.Aggregates {
+			if aggregate.Field == "" {
+				continue
+			}
+
+			field := row.Get(aggregate.Field)
+			value := strings.TrimSpace(field.Value)
+			if value == "" || value == "<nil>" {
+				continue
+			}
+
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, nil, clierrors.NewErrValidation(fmt.Errorf("Invalid value [%s] for field [%s], expected number", field.Value, aggregate.Field))
+			}
+
+			if field.Monetary {
+				monetary[aggregate.Field] = true
+				number = math.Round(number * 100)
+			}
+
+			group.totals[i] += number
+			group.counts[i]++
+		}
+	}
+
+	var fields []*OrderedFields
+	var data []map[string]interface{}
+	for _, group := range groups {
+		groupFields := NewOrderedFields()
+		groupData := make(map[string]interface{})
+		if a.GroupBy != "" {
+			groupFields.Set(a.GroupBy, NewFieldValue(group.key, true))
+			groupData[a.GroupBy] = group.key
+		}
+
+		for i, aggregate := range a.Aggregates {
+			value, formatted := a.aggregateValue(aggregate, group, i, monetary[aggregate.Field])
+			groupFields.Set(aggregate.Name(), NewFieldValue(formatted, true))
+			groupData[aggregate.Name()] = value
+		}
+
+		fields = append(fields, groupFields)
+		data = append(data, groupData)
+	}
+
+	return fields, data, nil
+}
+
+func (a *Aggregation) newGroup(key string) *aggregateGroup {
+	return &aggregateGroup{
+		key:    key,
+		totals: make([]float64, len(a.Aggregates)),
+		counts: make([]int, len(a.Aggregates)),
+	}
+}
+
+// validateFields returns an error if any rows are provided, but none contain the group or
+// aggregate fields
+func (a *Aggregation) validateFields(rows []*OrderedFields) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	fieldExists := func(field string) bool {
+		for _, row := range rows {
+			if row.Exists(field) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if a.GroupBy != "" && !fieldExists(a.GroupBy) {
+		return clierrors.NewErrValidation(fmt.Errorf("Invalid group-by field [%s]", a.GroupBy))
+	}
+
+	for _, aggregate := range a.Aggregates {
+		if aggregate.Field != "" && !fieldExists(aggregate.Field) {
+			return clierrors.NewErrValidation(fmt.Errorf("Invalid aggregate field [%s]", aggregate.Field))
+		}
+	}
+
+	return nil
+}
+
+// groupKey returns the group key for row, truncating date values to the configured period
+func (a *Aggregation) groupKey(row *OrderedFields) string {
+	key := row.Get(a.GroupBy).Value
+	if a.Truncate != "" && dateRegexp.MatchString(key) {
+		return key[:dateTruncateLengths[a.Truncate]]
+	}
+
+	return key
+}
+
+// aggregateValue returns the value of aggregate at index i for group, along with the value
+// formatted for field output
+func (a *Aggregation) aggregateValue(aggregate Aggregate, group *aggregateGroup, i int, monetary bool) (interface{}, string) {
+	var value float64
+	switch aggregate.Function {
+	case "count":
+		return group.count, strconv.Itoa(group.count)
+	case "sum":
+		value = group.totals[i]
+	case "avg":
+		if group.counts[i] > 0 {
+			value = group.totals[i] / float64(group.counts[i])
+		}
+	}
+
+	if monetary {
+		value = math.Round(value) / 100
+		return value, fmt.Sprintf("%.2f", value)
+	}
+
+	return value, strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// AggregateOutputHandlerDataProvider wraps an OutputHandlerDataProvider, replacing data and field
+// data with the result of an Aggregation
+type AggregateOutputHandlerDataProvider struct {
+	fields []*OrderedFields
+	data   []map[string]interface{}
+}
+
+// NewAggregateOutputHandlerDataProvider returns an AggregateOutputHandlerDataProvider, applying
+// aggregation to all fields of dataProvider
+func NewAggregateOutputHandlerDataProvider(dataProvider OutputHandlerDataProvider, aggregation *Aggregation) (*AggregateOutputHandlerDataProvider, error) {
+	rows, err := dataProvider.GetFieldData()
+	if err != nil {
+		return nil, err
+	}
+
+	fields, data, err := aggregation.Apply(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AggregateOutputHandlerDataProvider{fields: fields, data: data}, nil
+}
+
+func (p *AggregateOutputHandlerDataProvider) GetData() interface{} {
+	return p.data
+}
+
+func (p *AggregateOutputHandlerDataProvider) GetFieldData() ([]*OrderedFields, error) {
+	return p.fields, nil
+}
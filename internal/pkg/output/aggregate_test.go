@@ -0,0 +1,164 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/test"
+)
+
+type testAggregateData struct {
+	Date  string  `json:"date"`
+	Type  string  `json:"type"`
+	Gross float32 `json:"gross"`
+	Size  int     `json:"size"`
+}
+
+func testAggregateProvider() OutputHandlerDataProvider {
+	return NewSerializedOutputHandlerDataProvider([]testAggregateData{
+		{Date: "2020-01-01", Type: "A", Gross: 0.1, Size: 1},
+		{Date: "2020-01-15", Type: "MX", Gross: 0.2, Size: 2},
+		{Date: "2020-02-01", Type: "A", Gross: 10.05, Size: 4},
+	}).WithDefaultFields([]string{"date", "type"}).WithMonetaryFields([]string{"gross"})
+}
+
+func TestParseAggregate(t *testing.T) {
+	t.Run("Count_Parsed", func(t *testing.T) {
+		a, err := ParseAggregate("count")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "count", a.Name())
+	})
+
+	t.Run("SumWithField_Parsed", func(t *testing.T) {
+		a, err := ParseAggregate("sum:gross")
+
+		assert.Nil(t, err)
+		assert.Equal(t, Aggregate{Function: "sum", Field: "gross"}, a)
+		assert.Equal(t, "sum_gross", a.Name())
+	})
+
+	t.Run("SumWithoutField_ReturnsValidationError", func(t *testing.T) {
+		_, err := ParseAggregate("sum")
+
+		assert.Equal(t, "Invalid aggregate [sum], expected one of: count, sum:<field>, avg:<field>", err.Error())
+		assert.Equal(t, clierrors.ExitCodeValidation, clierrors.ExitCode(err))
+	})
+
+	t.Run("UnknownFunction_ReturnsError", func(t *testing.T) {
+		_, err := ParseAggregate("max:size")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAggregation_Apply(t *testing.T) {
+	rows, _ := testAggregateProvider().GetFieldData()
+
+	t.Run("GroupByWithoutAggregates_Counts", func(t *testing.T) {
+		a, _ := NewAggregation("type", nil)
+
+		fields, data, err := a.Apply(rows)
+
+		assert.Nil(t, err)
+		assert.Len(t, fields, 2)
+		assert.Equal(t, "A", fields[0].Get("type").Value)
+		assert.Equal(t, "2", fields[0].Get("count").Value)
+		assert.Equal(t, "MX", fields[1].Get("type").Value)
+		assert.Equal(t, "1", fields[1].Get("count").Value)
+		assert.Equal(t, []map[string]interface{}{{"type": "A", "count": 2}, {"type": "MX", "count": 1}}, data)
+	})
+
+	t.Run("MonetarySum_SummedInPence", func(t *testing.T) {
+		a, _ := NewAggregation("", []string{"sum:gross", "avg:gross"})
+
+		fields, data, err := a.Apply(rows)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"sum_gross", "avg_gross"}, fields[0].Keys())
+		assert.Equal(t, "10.35", fields[0].Get("sum_gross").Value)
+		assert.Equal(t, "3.45", fields[0].Get("avg_gross").Value)
+		assert.Equal(t, 10.35, data[0]["sum_gross"])
+	})
+
+	t.Run("GroupByDatePeriod_TruncatesDates", func(t *testing.T) {
+		a, _ := NewAggregation("date:month", []string{"count", "sum:size", "avg:size"})
+
+		fields, _, err := a.Apply(rows)
+
+		assert.Nil(t, err)
+		assert.Len(t, fields, 2)
+		assert.Equal(t, "2020-01", fields[0].Get("date").Value)
+		assert.Equal(t, "3", fields[0].Get("sum_size").Value)
+		assert.Equal(t, "1.5", fields[0].Get("avg_size").Value)
+		assert.Equal(t, "2020-02", fields[1].Get("date").Value)
+		assert.Equal(t, "4", fields[1].Get("sum_size").Value)
+	})
+
+	t.Run("InvalidGroupByField_ReturnsError", func(t *testing.T) {
+		a, _ := NewAggregation("invalid", nil)
+
+		_, _, err := a.Apply(rows)
+
+		assert.Equal(t, "Invalid group-by field [invalid]", err.Error())
+	})
+
+	t.Run("NonNumericField_ReturnsError", func(t *testing.T) {
+		a, _ := NewAggregation("", []string{"sum:type"})
+
+		_, _, err := a.Apply(rows)
+
+		assert.Equal(t, "Invalid value [A] for field [type], expected number", err.Error())
+	})
+
+	t.Run("InvalidPeriod_ReturnsError", func(t *testing.T) {
+		_, err := NewAggregation("date:week", nil)
+
+		assert.Equal(t, "Invalid group-by period [week], expected one of: year, month, day", err.Error())
+	})
+}
+
+func TestOutputHandler_Handle_Aggregated(t *testing.T) {
+	t.Run("CSV_OutputsGroups", func(t *testing.T) {
+		handler := NewOutputHandler(testAggregateProvider(), "csv", "")
+		handler.GroupBy = "type"
+		handler.Aggregates = []string{"count", "sum:gross"}
+
+		output := test.CatchStdOut(t, func() {
+			handler.Handle()
+		})
+
+		assert.Equal(t, "type,count,sum_gross\nA,2,10.15\nMX,1,0.20\n", output)
+	})
+
+	t.Run("JSON_OutputsGroups", func(t *testing.T) {
+		handler := NewOutputHandler(testAggregateProvider(), "json", "")
+		handler.GroupBy = "type"
+
+		output := test.CatchStdOut(t, func() {
+			handler.Handle()
+		})
+
+		assert.Equal(t, `[{"count":2,"type":"A"},{"count":1,"type":"MX"}]`, output)
+	})
+
+	t.Run("Stream_AggregatesAllProviders", func(t *testing.T) {
+		providers := []OutputHandlerDataProvider{testAggregateProvider(), testAggregateProvider()}
+		handler := NewOutputHandler(nil, "value", "")
+		handler.Aggregates = []string{"count", "sum:size"}
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(func() (OutputHandlerDataProvider, error) {
+				if len(providers) == 0 {
+					return nil, nil
+				}
+				p := providers[0]
+				providers = providers[1:]
+				return p, nil
+			})
+		})
+
+		assert.Equal(t, "6 14\n", output)
+	})
+}
@@ -0,0 +1,182 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+)
+
+const (
+	ErrorFormatText = "text"
+	ErrorFormatJSON = "json"
+)
+
+var errorFormat = ErrorFormatText
+var failedResources = make(map[string]bool)
+var resourceAliases = make(map[string]string)
+var errorCount int
+
+// CommandError describes an error output by a command
+type CommandError struct {
+	Message    string `json:"message"`
+	ResourceID string `json:"resource_id,omitempty"`
+	Row        int    `json:"row,omitempty"`
+	Operation  string `json:"operation,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+	ExitCode   int    `json:"exit_code"`
+}
+
+func (e CommandError) Error() string {
+	return e.Message
+}
+
+// SetErrorFormat sets the format errors are output in, either 'text' (default) or 'json'. When
+// using the 'json' format, each error is output to stderr as a JSON object on a single line
+func SetErrorFormat(format string) error {
+	switch format {
+	case ErrorFormatText, ErrorFormatJSON:
+		errorFormat = format
+		return nil
+	}
+
+	return fmt.Errorf("Invalid error format [%s], expected one of: %s, %s", format, ErrorFormatText, ErrorFormatJSON)
+}
+
+// NewCommandError returns a CommandError for err
+func NewCommandError(err error) CommandError {
+	return CommandError{
+		Message:    err.Error(),
+		StatusCode: clierrors.StatusCode(err),
+		Detail:     clierrors.Detail(err),
+		ExitCode:   clierrors.ExitCode(err),
+	}
+}
+
+// NewCommandErrorf returns a CommandError with message formatted from format and a. The resource
+// ID is taken from the first bracketed argument (e.g. 'Error retrieving instance [%s]: %s'), with
+// the operation (e.g. 'retrieving instance') taken from the preceding text. The status code,
+// detail and exit code are determined from the first error argument, with messages for invalid
+// resource IDs (e.g. 'Invalid request ID [%s]') treated as validation errors
+func NewCommandErrorf(format string, a ...interface{}) CommandError {
+	e := CommandError{
+		Message:  fmt.Sprintf(format, a...),
+		ExitCode: clierrors.ExitCodeError,
+	}
+
+	for _, arg := range a {
+		if err, ok := arg.(error); ok {
+			e.StatusCode = clierrors.StatusCode(err)
+			e.Detail = clierrors.Detail(err)
+			e.ExitCode = clierrors.ExitCode(err)
+			break
+		}
+	}
+
+	if strings.HasPrefix(format, "Invalid ") && e.StatusCode == 0 {
+		e.ExitCode = clierrors.ExitCodeValidation
+	}
+
+	index := strings.Index(format, "[%")
+	if index < 0 {
+		return e
+	}
+
+	if strings.HasPrefix(format, "Error ") {
+		e.Operation = strings.TrimSpace(strings.TrimPrefix(format[:index], "Error "))
+	}
+
+	argIndex := countFormatVerbs(format[:index])
+	if argIndex < len(a) {
+		e.ResourceID = fmt.Sprintf("%v", a[argIndex])
+	}
+
+	return e
+}
+
+// countFormatVerbs returns the number of formatting verbs within format, excluding '%%'
+func countFormatVerbs(format string) int {
+	count := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		count++
+	}
+
+	return count
+}
+
+// OutputCommandError outputs e to stderr in the configured error format, and updates the error
+// level. Where errors with differing exit codes are output, the error level is set to
+// ExitCodeError
+func OutputCommandError(e CommandError) {
+	if suppressed {
+		return
+	}
+
+	writeCommandError(e)
+	errorCount++
+
+	if e.ResourceID != "" {
+		failedResources[e.ResourceID] = true
+	}
+	if errorLevel != 0 && errorLevel != e.ExitCode {
+		errorLevel = clierrors.ExitCodeError
+		return
+	}
+	errorLevel = e.ExitCode
+}
+
+// AddResourceAlias records alias (e.g. a resource name) as referring to the resource with given ID,
+// so that errors output for the ID are attributed to alias when determining the exit code
+func AddResourceAlias(alias string, id string) {
+	resourceAliases[alias] = id
+}
+
+// ErrorCount returns the number of errors output with OutputCommandError
+func ErrorCount() int {
+	return errorCount
+}
+
+func writeCommandError(e CommandError) {
+	if errorFormat != ErrorFormatJSON {
+		Error(e.Message)
+		return
+	}
+
+	content, err := json.Marshal(e)
+	if err != nil {
+		Error(e.Message)
+		return
+	}
+
+	Error(string(content))
+}
+
+// getErrorLevel returns the error level to exit with. When resources are provided and errors
+// were output for some, but not all, of resources, ExitCodePartialFailure is returned
+func getErrorLevel(resources []string) int {
+	if errorLevel == 0 || len(resources) < 2 {
+		return errorLevel
+	}
+
+	failed := 0
+	for _, resource := range resources {
+		if failedResources[resource] || failedResources[resourceAliases[resource]] {
+			failed++
+		}
+	}
+
+	if failed > 0 && failed < len(resources) {
+		return clierrors.ExitCodePartialFailure
+	}
+
+	return errorLevel
+}
@@ -0,0 +1,159 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/test"
+)
+
+// resetErrors resets error output state, returning a func to restore the error format
+func resetErrors(format string) func() {
+	errorFormat = format
+	errorLevel = 0
+	failedResources = make(map[string]bool)
+	resourceAliases = make(map[string]string)
+
+	return func() {
+		errorFormat = ErrorFormatText
+		errorLevel = 0
+		failedResources = make(map[string]bool)
+		resourceAliases = make(map[string]string)
+	}
+}
+
+func TestSetErrorFormat(t *testing.T) {
+	defer resetErrors(ErrorFormatText)()
+
+	assert.Nil(t, SetErrorFormat("json"))
+	assert.Equal(t, ErrorFormatJSON, errorFormat)
+
+	err := SetErrorFormat("xml")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid error format [xml], expected one of: text, json", err.Error())
+}
+
+func TestNewCommandErrorf(t *testing.T) {
+	t.Run("ResourceAndAPIError", func(t *testing.T) {
+		err := errors.New(`unexpected status code (403): title="Forbidden", detail="Access denied", status="403", source=""`)
+
+		e := NewCommandErrorf("Error retrieving instance [%s]: %s", "i-abcdef12", err)
+
+		assert.Equal(t, "Error retrieving instance [i-abcdef12]: "+err.Error(), e.Message)
+		assert.Equal(t, "i-abcdef12", e.ResourceID)
+		assert.Equal(t, "retrieving instance", e.Operation)
+		assert.Equal(t, 403, e.StatusCode)
+		assert.Equal(t, "Access denied", e.Detail)
+		assert.Equal(t, clierrors.ExitCodeAuth, e.ExitCode)
+	})
+
+	t.Run("ResourceAfterOtherArgs", func(t *testing.T) {
+		e := NewCommandErrorf("Error removing record %s [%d]: %s", "example.co.uk", 123, errors.New("test error"))
+
+		assert.Equal(t, "123", e.ResourceID)
+		assert.Equal(t, clierrors.ExitCodeError, e.ExitCode)
+	})
+
+	t.Run("InvalidID_ValidationError", func(t *testing.T) {
+		e := NewCommandErrorf("Invalid request ID [%s]", "abc")
+
+		assert.Equal(t, "abc", e.ResourceID)
+		assert.Equal(t, "", e.Operation)
+		assert.Equal(t, clierrors.ExitCodeValidation, e.ExitCode)
+	})
+
+	t.Run("NoResource", func(t *testing.T) {
+		e := NewCommandErrorf("Error waiting for task: %s", fmt.Errorf("wrapped: %w", &clierrors.ErrCommandTimeout{}))
+
+		assert.Equal(t, "", e.ResourceID)
+		assert.Equal(t, clierrors.ExitCodeTimeout, e.ExitCode)
+	})
+}
+
+func TestOutputWithErrorLevelf(t *testing.T) {
+	t.Run("JSONFormat_OutputsJSON", func(t *testing.T) {
+		defer resetErrors(ErrorFormatJSON)()
+
+		output := test.CatchStdErr(t, func() {
+			OutputWithErrorLevelf("Invalid request ID [%s]", "abc")
+		})
+
+		assert.Equal(t, "{\"message\":\"Invalid request ID [abc]\",\"resource_id\":\"abc\",\"exit_code\":2}\n", output)
+		assert.Equal(t, clierrors.ExitCodeValidation, errorLevel)
+	})
+
+	t.Run("DifferingExitCodes_SetsGeneralErrorLevel", func(t *testing.T) {
+		defer resetErrors(ErrorFormatText)()
+
+		test.CatchStdErr(t, func() {
+			OutputWithErrorLevelf("Invalid request ID [%s]", "abc")
+			OutputWithErrorLevelf("Error retrieving request [%s]: %s", "123", errors.New("unexpected status code (404): "))
+		})
+
+		assert.Equal(t, clierrors.ExitCodeError, errorLevel)
+	})
+}
+
+func TestExitWithErrorLevel(t *testing.T) {
+	t.Run("SomeResourcesFailed_ExitsWithPartialFailure", func(t *testing.T) {
+		defer resetErrors(ErrorFormatText)()
+		code := 0
+		oldOutputExit := SetOutputExit(func(c int) { code = c })
+		defer func() { outputExit = oldOutputExit }()
+
+		test.CatchStdErr(t, func() {
+			OutputWithErrorLevelf("Error retrieving instance [%s]: %s", "i-abcdef12", errors.New("unexpected status code (404): "))
+		})
+		ExitWithErrorLevel("i-abcdef12", "i-abcdef34")
+
+		assert.Equal(t, clierrors.ExitCodePartialFailure, code)
+	})
+
+	t.Run("SomeAliasedResourcesFailed_ExitsWithPartialFailure", func(t *testing.T) {
+		defer resetErrors(ErrorFormatText)()
+		code := 0
+		oldOutputExit := SetOutputExit(func(c int) { code = c })
+		defer func() { outputExit = oldOutputExit }()
+
+		AddResourceAlias("web-01", "i-abcdef12")
+		AddResourceAlias("web-02", "i-abcdef34")
+		test.CatchStdErr(t, func() {
+			OutputWithErrorLevelf("Error retrieving instance [%s]: %s", "i-abcdef12", errors.New("unexpected status code (404): "))
+		})
+		ExitWithErrorLevel("web-01", "web-02")
+
+		assert.Equal(t, clierrors.ExitCodePartialFailure, code)
+	})
+
+	t.Run("AllResourcesFailed_ExitsWithErrorLevel", func(t *testing.T) {
+		defer resetErrors(ErrorFormatText)()
+		code := 0
+		oldOutputExit := SetOutputExit(func(c int) { code = c })
+		defer func() { outputExit = oldOutputExit }()
+
+		test.CatchStdErr(t, func() {
+			OutputWithErrorLevelf("Error retrieving instance [%s]: %s", "i-abcdef12", errors.New("unexpected status code (404): "))
+			OutputWithErrorLevelf("Error retrieving instance [%s]: %s", "i-abcdef34", errors.New("unexpected status code (404): "))
+		})
+		ExitWithErrorLevel("i-abcdef12", "i-abcdef34")
+
+		assert.Equal(t, clierrors.ExitCodeNotFound, code)
+	})
+}
+
+func TestFatalError(t *testing.T) {
+	defer resetErrors(ErrorFormatJSON)()
+	code := 0
+	oldOutputExit := SetOutputExit(func(c int) { code = c })
+	defer func() { outputExit = oldOutputExit }()
+
+	output := test.CatchStdErr(t, func() {
+		FatalError(clierrors.NewErrValidation(errors.New("Missing instance")))
+	})
+
+	assert.Equal(t, "{\"message\":\"Missing instance\",\"exit_code\":2}\n", output)
+	assert.Equal(t, clierrors.ExitCodeValidation, code)
+}
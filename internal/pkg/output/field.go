@@ -39,10 +39,12 @@ func (o *OrderedFields) Keys() []string {
 	return o.keys
 }
 
-// FieldValue holds the value for a table field
+// FieldValue holds the value for a table field. Monetary is set for fields holding monetary
+// values, which are aggregated with pence precision
 type FieldValue struct {
-	Value   string
-	Default bool
+	Value    string
+	Default  bool
+	Monetary bool
 }
 
 // NewFieldValue returns a new, initialized FieldValue struct
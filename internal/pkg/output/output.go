@@ -2,6 +2,7 @@ package output
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -12,13 +13,14 @@ import (
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"gopkg.in/yaml.v2"
 	"k8s.io/client-go/util/jsonpath"
-
-	"github.com/ukfast/sdk-go/pkg/connection"
 )
 
 var outputExit func(code int) = os.Exit
 var errorLevel int
+var suppressed bool
 
 func SetOutputExit(e func(code int)) func(code int) {
 	oldOutputExit := outputExit
@@ -50,6 +52,14 @@ func (l *DebugLogger) Trace(msg string) {
 	Error(msg)
 }
 
+// Suppress suppresses all further command output, command errors and error levels. This is used
+// once output would no longer reflect actual results, e.g. after a request has been intercepted in
+// dry-run mode, where retrieving the resulting resource will fail. Other stderr output (e.g.
+// request traces) is unaffected
+func Suppress() {
+	suppressed = true
+}
+
 // Error writes specified string to stderr
 func Error(str string) {
 	os.Stderr.WriteString(str + "\n")
@@ -60,11 +70,10 @@ func Errorf(format string, a ...interface{}) {
 	Error(fmt.Sprintf(format, a...))
 }
 
-// Fatal writes specified string to stderr and calls outputExit to
-// exit with 1
+// Fatal writes specified string to stderr and calls outputExit to exit with 1. If output is
+// suppressed, outputExit is called to exit with 0
 func Fatal(str string) {
-	Error(str)
-	outputExit(1)
+	FatalCommandError(CommandError{Message: str, ExitCode: clierrors.ExitCodeError})
 }
 
 // Fatalf writes specified string with formatting to stderr and calls
@@ -73,33 +82,60 @@ func Fatalf(format string, a ...interface{}) {
 	Fatal(fmt.Sprintf(format, a...))
 }
 
+// FatalError writes err to stderr and calls outputExit to exit with the exit code for err
+func FatalError(err error) {
+	FatalCommandError(NewCommandError(err))
+}
+
+// FatalCommandError writes e to stderr and calls outputExit to exit with the exit code of e. If
+// output is suppressed, outputExit is called to exit with 0
+func FatalCommandError(e CommandError) {
+	if suppressed {
+		outputExit(0)
+		return
+	}
+
+	writeCommandError(e)
+	outputExit(e.ExitCode)
+}
+
 // OutputWithCustomErrorLevel is a wrapper for OutputError, which sets global
 // var errorLevel with provided level
 func OutputWithCustomErrorLevel(level int, str string) {
-	Error(str)
-	errorLevel = level
+	OutputCommandError(CommandError{Message: str, ExitCode: level})
 }
 
 // OutputWithCustomErrorLevelf is a wrapper for OutputWithCustomErrorLevel, which sets global
 // var errorLevel with provided level
 func OutputWithCustomErrorLevelf(level int, format string, a ...interface{}) {
-	OutputWithCustomErrorLevel(level, fmt.Sprintf(format, a...))
+	e := NewCommandErrorf(format, a...)
+	e.ExitCode = level
+	OutputCommandError(e)
 }
 
-// OutputWithErrorLevelf is a wrapper for OutputWithCustomErrorLevelf, which sets global
-// var errorLevel to 1
+// OutputWithErrorLevelf outputs an error formatted using NewCommandErrorf, setting global var
+// errorLevel to the exit code for the error, e.g. ExitCodeNotFound for missing resources
 func OutputWithErrorLevelf(format string, a ...interface{}) {
-	OutputWithCustomErrorLevelf(1, format, a...)
+	OutputCommandError(NewCommandErrorf(format, a...))
 }
 
 // OutputWithErrorLevel is a wrapper for OutputWithCustomErrorLevel, which sets global
 // var errorLevel to 1
 func OutputWithErrorLevel(str string) {
-	OutputWithCustomErrorLevel(1, str)
+	OutputWithCustomErrorLevel(clierrors.ExitCodeError, str)
+}
+
+// SetErrorLevel sets global var errorLevel without outputting an error, e.g. for external commands
+// which have already output their own errors
+func SetErrorLevel(level int) {
+	errorLevel = level
 }
 
-func ExitWithErrorLevel() {
-	outputExit(errorLevel)
+// ExitWithErrorLevel calls outputExit with global var errorLevel. resources should contain the
+// resources a command operates on, if multiple resources are supported, so that
+// ExitCodePartialFailure can be used when errors were output for only some of them
+func ExitWithErrorLevel(resources ...string) {
+	outputExit(getErrorLevel(resources))
 }
 
 // Value will format specified rows using given includeProperties by extracting field values,
@@ -132,6 +168,99 @@ func JSON(v interface{}) error {
 	return err
 }
 
+// NDJSON marshals and outputs value v to stdout as newline-delimited JSON, with each element
+// output on a separate line if v is a slice
+func NDJSON(v interface{}) error {
+	for _, item := range dataItems(v) {
+		out, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("failed to marshal json: %s", err)
+		}
+
+		fmt.Println(string(out))
+	}
+
+	return nil
+}
+
+// YAML marshals and outputs value v to stdout. Value v is first marshalled as JSON, so that
+// property names and ordering match the JSON output format
+func YAML(v interface{}) error {
+	y, err := toYAMLValue(v)
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(y)
+	if err != nil {
+		return fmt.Errorf("failed to marshal yaml: %s", err)
+	}
+
+	_, err = fmt.Print(string(out))
+
+	return err
+}
+
+// toYAMLValue converts v into a value which can be marshalled as YAML, via JSON. Objects are
+// converted to yaml.MapSlice to retain property ordering
+func toYAMLValue(v interface{}) (interface{}, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json: %s", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	decoder.UseNumber()
+
+	return decodeYAMLValue(decoder)
+}
+
+func decodeYAMLValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			m := yaml.MapSlice{}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeYAMLValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				m = append(m, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err = decoder.Token()
+			return m, err
+		case '[':
+			s := []interface{}{}
+			for decoder.More() {
+				value, err := decodeYAMLValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, value)
+			}
+			_, err = decoder.Token()
+			return s, err
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	}
+
+	return token, nil
+}
+
 // JSONPath marshals and outputs value v to stdout
 func JSONPath(query string, v interface{}) error {
 	j := jsonpath.New("clioutput")
@@ -178,6 +307,11 @@ func Table(rows []*OrderedFields) error {
 
 // CSV outputs provided rows as CSV to stdout
 func CSV(rows []*OrderedFields) error {
+	return writeCSV(rows, true)
+}
+
+// writeCSV outputs provided rows as CSV to stdout, with a header row if header is true
+func writeCSV(rows []*OrderedFields, header bool) error {
 	if len(rows) < 1 {
 		return nil
 	}
@@ -186,9 +320,11 @@ func CSV(rows []*OrderedFields) error {
 
 	// First retrieve properties and write to CSV buffer
 	headers := rows[0].Keys()
-	err := w.Write(headers)
-	if err != nil {
-		return err
+	if header {
+		err := w.Write(headers)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, row := range rows {
@@ -213,6 +349,54 @@ func CSV(rows []*OrderedFields) error {
 	return nil
 }
 
+// Markdown outputs provided rows as a GitHub flavoured Markdown table to stdout
+func Markdown(rows []*OrderedFields) error {
+	return writeMarkdown(rows, true)
+}
+
+// writeMarkdown outputs provided rows as Markdown table rows to stdout, preceded by header and
+// delimiter rows if header is true
+func writeMarkdown(rows []*OrderedFields, header bool) error {
+	if len(rows) < 1 {
+		return nil
+	}
+
+	f := bufio.NewWriter(os.Stdout)
+	defer f.Flush()
+
+	headers := rows[0].Keys()
+	if header {
+		var delimiters []string
+		for range headers {
+			delimiters = append(delimiters, "---")
+		}
+
+		f.WriteString(markdownRow(headers))
+		f.WriteString(markdownRow(delimiters))
+	}
+
+	for _, row := range rows {
+		var rowData []string
+		for _, header := range headers {
+			rowData = append(rowData, row.Get(header).Value)
+		}
+		f.WriteString(markdownRow(rowData))
+	}
+
+	return nil
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+func markdownRow(values []string) string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = markdownEscaper.Replace(value)
+	}
+
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
 // List will format specified rows using given includeProperties by extracting fields,
 // and output them to stdout
 func List(rows []*OrderedFields) error {
@@ -287,17 +471,37 @@ func Template(t string, i interface{}) error {
 	return nil
 }
 
-func CommandOutputPaginated(cmd *cobra.Command, out OutputHandlerDataProvider, paginated connection.Paginated) error {
-	err := CommandOutput(cmd, out)
+func CommandOutput(cmd *cobra.Command, out OutputHandlerDataProvider) error {
+	if suppressed {
+		return nil
+	}
+
+	query, err := getCommandQuery(cmd)
 	if err != nil {
 		return err
 	}
+	if query != nil {
+		out, err = NewQueryOutputHandlerDataProvider(out, query)
+		if err != nil {
+			return err
+		}
+	}
 
-	Errorf("Page %d/%d", paginated.CurrentPage(), paginated.TotalPages())
-	return nil
+	maxItems, _ := cmd.Flags().GetInt("max-items")
+	if maxItems > 0 {
+		out = NewLimitedOutputHandlerDataProvider(out, maxItems)
+	}
+
+	if activeWatcher != nil {
+		return activeWatcher.output(cmd, out)
+	}
+
+	return NewCommandOutputHandler(cmd, out).Handle()
 }
 
-func CommandOutput(cmd *cobra.Command, out OutputHandlerDataProvider) error {
+// NewCommandOutputHandler returns an OutputHandler for given data provider, configured from
+// global output flags
+func NewCommandOutputHandler(cmd *cobra.Command, out OutputHandlerDataProvider) *OutputHandler {
 	// Format flag deprecated, however we'll check to see whether populated first and use it
 	var flag string
 	if cmd.Flags().Changed("format") {
@@ -315,6 +519,8 @@ func CommandOutput(cmd *cobra.Command, out OutputHandlerDataProvider) error {
 
 	handler := NewOutputHandler(out, name, arg)
 	handler.Properties, _ = cmd.Flags().GetStringSlice("property")
+	handler.GroupBy, _ = cmd.Flags().GetString("group-by")
+	handler.Aggregates, _ = cmd.Flags().GetStringArray("aggregate")
 
-	return handler.Handle()
+	return handler
 }
@@ -13,6 +13,8 @@ type OutputHandler struct {
 	Format           string
 	FormatArg        string
 	Properties       []string
+	GroupBy          string
+	Aggregates       []string
 	SupportedFormats []string
 	DataProvider     OutputHandlerDataProvider
 }
@@ -41,9 +43,25 @@ func (o *OutputHandler) Handle() error {
 		return fmt.Errorf("Unsupported output format [%s], supported formats: %s", o.Format, strings.Join(o.SupportedFormats, ", "))
 	}
 
+	if o.aggregated() {
+		aggregation, err := NewAggregation(o.GroupBy, o.Aggregates)
+		if err != nil {
+			return err
+		}
+
+		o.DataProvider, err = NewAggregateOutputHandlerDataProvider(o.DataProvider, aggregation)
+		if err != nil {
+			return err
+		}
+	}
+
 	switch o.Format {
 	case "json":
 		return JSON(o.DataProvider.GetData())
+	case "ndjson":
+		return NDJSON(o.DataProvider.GetData())
+	case "yaml":
+		return YAML(o.DataProvider.GetData())
 	case "jsonpath":
 		return JSONPath(o.FormatArg, o.DataProvider.GetData())
 	case "template":
@@ -66,6 +84,12 @@ func (o *OutputHandler) Handle() error {
 			return err
 		}
 		return List(d)
+	case "markdown":
+		d, err := o.getProcessedFieldData()
+		if err != nil {
+			return err
+		}
+		return Markdown(d)
 	default:
 		Errorf("Invalid output format [%s], defaulting to 'table'", o.Format)
 		fallthrough
@@ -78,10 +102,22 @@ func (o *OutputHandler) Handle() error {
 	}
 }
 
+// aggregated returns true if output should be grouped/aggregated, with the result output rather
+// than the provided data
+func (o *OutputHandler) aggregated() bool {
+	return o.GroupBy != "" || len(o.Aggregates) > 0
+}
+
 func (o *OutputHandler) getProcessedFieldData() ([]*OrderedFields, error) {
+	return o.processFieldData(o.DataProvider)
+}
+
+// processFieldData retrieves field data from given dataProvider, filtered by o.Properties or
+// default fields if no properties specified
+func (o *OutputHandler) processFieldData(dataProvider OutputHandlerDataProvider) ([]*OrderedFields, error) {
 	var filteredFieldsCollectionArray []*OrderedFields
 
-	fieldsCollectionArray, err := o.DataProvider.GetFieldData()
+	fieldsCollectionArray, err := dataProvider.GetFieldData()
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +178,9 @@ func (o *SerializedOutputHandlerDataProvider) convertField(v *OrderedFields, fie
 
 func (o *SerializedOutputHandlerDataProvider) hydrateField(v *OrderedFields, fieldName string, fieldValue string) *OrderedFields {
 	if !o.isIgnoredField(fieldName) {
-		v.Set(fieldName, NewFieldValue(fieldValue, o.isDefaultField(fieldName)))
+		value := NewFieldValue(fieldValue, o.isDefaultField(fieldName))
+		value.Monetary = o.isMonetaryField(fieldName)
+		v.Set(fieldName, value)
 	}
 
 	return v
@@ -205,3 +207,40 @@ func (o *SerializedOutputHandlerDataProvider) fieldInFields(name string, fields
 
 	return false
 }
+
+// LimitedOutputHandlerDataProvider wraps an OutputHandlerDataProvider, limiting data and field data
+// to the first n items
+type LimitedOutputHandlerDataProvider struct {
+	dataProvider OutputHandlerDataProvider
+	limit        int
+}
+
+func NewLimitedOutputHandlerDataProvider(dataProvider OutputHandlerDataProvider, limit int) *LimitedOutputHandlerDataProvider {
+	return &LimitedOutputHandlerDataProvider{
+		dataProvider: dataProvider,
+		limit:        limit,
+	}
+}
+
+func (p *LimitedOutputHandlerDataProvider) GetData() interface{} {
+	data := p.dataProvider.GetData()
+	if data == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice && v.Len() > p.limit {
+		return v.Slice(0, p.limit).Interface()
+	}
+
+	return data
+}
+
+func (p *LimitedOutputHandlerDataProvider) GetFieldData() ([]*OrderedFields, error) {
+	fields, err := p.dataProvider.GetFieldData()
+	if len(fields) > p.limit {
+		fields = fields[:p.limit]
+	}
+
+	return fields, err
+}
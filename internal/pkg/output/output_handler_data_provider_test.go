@@ -280,3 +280,19 @@ func TestSerializedOutputHandlerDataProvider_convertField(t *testing.T) {
 		assert.Equal(t, "[some value]", output.Get("property_1").Value)
 	})
 }
+
+func TestLimitedOutputHandlerDataProvider(t *testing.T) {
+	data := []testOutputData{{TestProperty1: "value1"}, {TestProperty1: "value2"}, {TestProperty1: "value3"}}
+	p := NewLimitedOutputHandlerDataProvider(NewSerializedOutputHandlerDataProvider(data), 2)
+
+	t.Run("GetData_ReturnsLimitedData", func(t *testing.T) {
+		assert.Len(t, p.GetData(), 2)
+	})
+
+	t.Run("GetFieldData_ReturnsLimitedFieldData", func(t *testing.T) {
+		fields, err := p.GetFieldData()
+
+		assert.Nil(t, err)
+		assert.Len(t, fields, 2)
+	})
+}
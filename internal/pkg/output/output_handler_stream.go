@@ -0,0 +1,213 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// OutputHandlerDataProviderFunc returns the next OutputHandlerDataProvider in a stream, such as the
+// next page of a paginated response. A nil provider indicates the stream is exhausted
+type OutputHandlerDataProviderFunc func() (OutputHandlerDataProvider, error)
+
+// HandleStream outputs each OutputHandlerDataProvider returned by next in the format specified in
+// struct property 'Format'. Formats which can be written incrementally are output as each provider
+// is received, with tables output per provider, whereas formats requiring the complete data set
+// (jsonpath) are output once the stream is exhausted. Aggregated output also requires the complete
+// data set, so all providers are collected before being output
+func (o *OutputHandler) HandleStream(next OutputHandlerDataProviderFunc) error {
+	if !o.supportedFormat() {
+		return fmt.Errorf("Unsupported output format [%s], supported formats: %s", o.Format, strings.Join(o.SupportedFormats, ", "))
+	}
+
+	if o.aggregated() {
+		dataProvider, err := collectStreamProvider(next)
+		if err != nil {
+			return err
+		}
+
+		o.DataProvider = dataProvider
+		return o.Handle()
+	}
+
+	switch o.Format {
+	case "json":
+		return o.streamJSON(next)
+	case "ndjson":
+		return streamProviders(next, func(dataProvider OutputHandlerDataProvider, first bool) error {
+			return NDJSON(dataProvider.GetData())
+		})
+	case "yaml":
+		return streamProviders(next, func(dataProvider OutputHandlerDataProvider, first bool) error {
+			// Each provider is output as a YAML sequence, which when concatenated form a single sequence
+			items := dataItems(dataProvider.GetData())
+			if len(items) < 1 {
+				return nil
+			}
+			return YAML(items)
+		})
+	case "jsonpath":
+		data, err := collectStreamData(next)
+		if err != nil {
+			return err
+		}
+		return JSONPath(o.FormatArg, data)
+	case "template":
+		return streamProviders(next, func(dataProvider OutputHandlerDataProvider, first bool) error {
+			return Template(o.FormatArg, dataProvider.GetData())
+		})
+	case "value":
+		return o.streamFieldData(next, func(rows []*OrderedFields, first bool) error {
+			return Value(rows)
+		})
+	case "csv":
+		return o.streamFieldData(next, func(rows []*OrderedFields, first bool) error {
+			return writeCSV(rows, first)
+		})
+	case "list":
+		return o.streamFieldData(next, func(rows []*OrderedFields, first bool) error {
+			if !first && len(rows) > 0 {
+				fmt.Print("\n")
+			}
+			return List(rows)
+		})
+	case "markdown":
+		return o.streamFieldData(next, func(rows []*OrderedFields, first bool) error {
+			return writeMarkdown(rows, first)
+		})
+	default:
+		Errorf("Invalid output format [%s], defaulting to 'table'", o.Format)
+		fallthrough
+	case "table":
+		// Column widths can't be known until all rows are, so a table is output per provider
+		return o.streamFieldData(next, func(rows []*OrderedFields, first bool) error {
+			return Table(rows)
+		})
+	}
+}
+
+// streamJSON outputs a single JSON array containing the items from all providers, marshalling
+// each item as it's received
+func (o *OutputHandler) streamJSON(next OutputHandlerDataProviderFunc) error {
+	fmt.Print("[")
+
+	count := 0
+	err := streamProviders(next, func(dataProvider OutputHandlerDataProvider, first bool) error {
+		for _, item := range dataItems(dataProvider.GetData()) {
+			out, err := json.Marshal(item)
+			if err != nil {
+				return fmt.Errorf("failed to marshal json: %s", err)
+			}
+
+			if count > 0 {
+				fmt.Print(",")
+			}
+			fmt.Print(string(out))
+			count++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("]")
+	return nil
+}
+
+// streamFieldData calls f with processed field data for each provider returned by next. Parameter
+// first will be true for the first set of non-empty rows
+func (o *OutputHandler) streamFieldData(next OutputHandlerDataProviderFunc, f func(rows []*OrderedFields, first bool) error) error {
+	first := true
+	return streamProviders(next, func(dataProvider OutputHandlerDataProvider, _ bool) error {
+		rows, err := o.processFieldData(dataProvider)
+		if err != nil {
+			return err
+		}
+		if len(rows) < 1 {
+			return nil
+		}
+
+		err = f(rows, first)
+		first = false
+		return err
+	})
+}
+
+func streamProviders(next OutputHandlerDataProviderFunc, f func(dataProvider OutputHandlerDataProvider, first bool) error) error {
+	first := true
+	for {
+		dataProvider, err := next()
+		if err != nil {
+			return err
+		}
+		if dataProvider == nil {
+			return nil
+		}
+
+		err = f(dataProvider, first)
+		if err != nil {
+			return err
+		}
+		first = false
+	}
+}
+
+// collectStreamData concatenates data from all providers returned by next into a single slice
+func collectStreamData(next OutputHandlerDataProviderFunc) (interface{}, error) {
+	var items []interface{}
+	err := streamProviders(next, func(dataProvider OutputHandlerDataProvider, first bool) error {
+		items = append(items, dataItems(dataProvider.GetData())...)
+		return nil
+	})
+
+	return items, err
+}
+
+// collectStreamProvider returns a provider containing the data and field data from all providers
+// returned by next
+func collectStreamProvider(next OutputHandlerDataProviderFunc) (OutputHandlerDataProvider, error) {
+	var items []interface{}
+	var fields []*OrderedFields
+	err := streamProviders(next, func(dataProvider OutputHandlerDataProvider, first bool) error {
+		providerFields, err := dataProvider.GetFieldData()
+		if err != nil {
+			return err
+		}
+
+		items = append(items, dataItems(dataProvider.GetData())...)
+		fields = append(fields, providerFields...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGenericOutputHandlerDataProvider(
+		WithData(items),
+		WithFieldDataFunc(func() ([]*OrderedFields, error) {
+			return fields, nil
+		}),
+	), nil
+}
+
+// dataItems returns the elements of data if data is a slice, otherwise a slice containing data
+func dataItems(data interface{}) []interface{} {
+	if data == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return []interface{}{data}
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items[i] = v.Index(i).Interface()
+	}
+
+	return items
+}
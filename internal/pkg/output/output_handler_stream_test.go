@@ -0,0 +1,120 @@
+package output
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test"
+)
+
+func newTestStream(pages ...[]testOutputData) OutputHandlerDataProviderFunc {
+	i := 0
+	return func() (OutputHandlerDataProvider, error) {
+		if i >= len(pages) {
+			return nil, nil
+		}
+
+		i++
+		return NewSerializedOutputHandlerDataProvider(pages[i-1]).WithDefaultFields([]string{"test_property_1"}), nil
+	}
+}
+
+var testStreamPages = [][]testOutputData{
+	{{TestProperty1: "value1"}, {TestProperty1: "value2"}},
+	{{TestProperty1: "value3"}},
+}
+
+func TestOutputHandler_HandleStream(t *testing.T) {
+	t.Run("JSONFormat_OutputsSingleArray", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "json", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "[{\"TestProperty1\":\"value1\",\"TestProperty2\":\"\"},{\"TestProperty1\":\"value2\",\"TestProperty2\":\"\"},{\"TestProperty1\":\"value3\",\"TestProperty2\":\"\"}]", output)
+	})
+
+	t.Run("NDJSONFormat_OutputsLinePerItem", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "ndjson", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "{\"TestProperty1\":\"value1\",\"TestProperty2\":\"\"}\n{\"TestProperty1\":\"value2\",\"TestProperty2\":\"\"}\n{\"TestProperty1\":\"value3\",\"TestProperty2\":\"\"}\n", output)
+	})
+
+	t.Run("YAMLFormat_OutputsSingleSequence", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "yaml", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(append(testStreamPages, []testOutputData{})...))
+		})
+
+		assert.Equal(t, "- TestProperty1: value1\n  TestProperty2: \"\"\n- TestProperty1: value2\n  TestProperty2: \"\"\n- TestProperty1: value3\n  TestProperty2: \"\"\n", output)
+	})
+
+	t.Run("MarkdownFormat_OutputsSingleHeader", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "markdown", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "| test_property_1 |\n| --- |\n| value1 |\n| value2 |\n| value3 |\n", output)
+	})
+
+	t.Run("JSONPathFormat_ExpectedOutput", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "jsonpath", "{[*].TestProperty1}")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "value1 value2 value3", output)
+	})
+
+	t.Run("CSVFormat_OutputsSingleHeader", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "csv", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "test_property_1\nvalue1\nvalue2\nvalue3\n", output)
+	})
+
+	t.Run("ListFormat_SeparatesPages", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "list", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "test_property_1 : value1\n\ntest_property_1 : value2\n\ntest_property_1 : value3\n", output)
+	})
+
+	t.Run("TableFormat_OutputsTablePerProvider", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "table", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.HandleStream(newTestStream(testStreamPages...))
+		})
+
+		assert.Equal(t, "+-----------------+\n| TEST PROPERTY 1 |\n+-----------------+\n| value1          |\n| value2          |\n+-----------------+\n"+
+			"+-----------------+\n| TEST PROPERTY 1 |\n+-----------------+\n| value3          |\n+-----------------+\n", output)
+	})
+
+	t.Run("StreamError_ReturnsError", func(t *testing.T) {
+		handler := NewOutputHandler(nil, "value", "")
+
+		err := handler.HandleStream(func() (OutputHandlerDataProvider, error) {
+			return nil, errors.New("test error")
+		})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "test error", err.Error())
+	})
+}
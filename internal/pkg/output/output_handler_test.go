@@ -35,6 +35,36 @@ func TestOutputHandler_Handle(t *testing.T) {
 		assert.Equal(t, "{\"TestProperty1\":\"testvalue1\",\"TestProperty2\":\"testvalue2\"}", output)
 	})
 
+	t.Run("NDJSONFormat_ExpectedOutput", func(t *testing.T) {
+		handler := NewOutputHandler(testOutputHandlerDataProvider, "ndjson", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.Handle()
+		})
+
+		assert.Equal(t, "{\"TestProperty1\":\"testvalue1\",\"TestProperty2\":\"testvalue2\"}\n", output)
+	})
+
+	t.Run("YAMLFormat_ExpectedOutput", func(t *testing.T) {
+		handler := NewOutputHandler(testOutputHandlerDataProvider, "yaml", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.Handle()
+		})
+
+		assert.Equal(t, "TestProperty1: testvalue1\nTestProperty2: testvalue2\n", output)
+	})
+
+	t.Run("MarkdownFormat_ExpectedOutput", func(t *testing.T) {
+		handler := NewOutputHandler(testOutputHandlerDataProvider, "markdown", "")
+
+		output := test.CatchStdOut(t, func() {
+			handler.Handle()
+		})
+
+		assert.Equal(t, "| test_property_1 | test_property_2 |\n| --- | --- |\n| fields1 test value 1 | fields1 test value 2 |\n| fields2 test value 1 | fields2 test value 2 |\n", output)
+	})
+
 	t.Run("TemplateFormat_ExpectedOutput", func(t *testing.T) {
 		handler := NewOutputHandler(testOutputHandlerDataProvider, "template", "{{ .TestProperty1 }}")
 
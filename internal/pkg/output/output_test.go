@@ -3,6 +3,7 @@ package output
 import (
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/ukfast/cli/test"
 	"github.com/ukfast/sdk-go/pkg/service/safedns"
@@ -126,6 +127,53 @@ func TestFatalf_ExpectedExitCode(t *testing.T) {
 	assert.Equal(t, 1, code)
 }
 
+func TestSuppress(t *testing.T) {
+	t.Run("SuppressesCommandOutput", func(t *testing.T) {
+		defer func() { suppressed = false }()
+
+		Suppress()
+
+		output := test.CatchStdOut(t, func() {
+			CommandOutput(&cobra.Command{}, NewGenericOutputHandlerDataProvider(
+				WithData("test"),
+			))
+		})
+
+		assert.Equal(t, "", output)
+	})
+
+	t.Run("SuppressesErrorsAndErrorLevel", func(t *testing.T) {
+		defer func() { suppressed, errorLevel = false, 0 }()
+
+		Suppress()
+
+		output := test.CatchStdErr(t, func() {
+			OutputWithErrorLevel("test")
+		})
+
+		assert.Equal(t, "", output)
+		assert.Equal(t, 0, errorLevel)
+	})
+
+	t.Run("FatalExitsWithZero", func(t *testing.T) {
+		defer func() { suppressed = false }()
+		code := 1
+		oldOutputExit := SetOutputExit(func(c int) {
+			code = c
+		})
+		defer func() { outputExit = oldOutputExit }()
+
+		Suppress()
+
+		output := test.CatchStdErr(t, func() {
+			Fatal("test")
+		})
+
+		assert.Equal(t, "", output)
+		assert.Equal(t, 0, code)
+	})
+}
+
 func TestValue_ExpectedStdout(t *testing.T) {
 	t.Run("SingleRowDefaultFields", func(t *testing.T) {
 		var rows []*OrderedFields
@@ -206,6 +254,82 @@ func TestOutput_JSON_ExpectedStdout(t *testing.T) {
 	})
 }
 
+func TestNDJSON_ExpectedStdout(t *testing.T) {
+	t.Run("Slice_OutputsLinePerElement", func(t *testing.T) {
+		zones := []safedns.Zone{{Name: "testzone1.com"}, {Name: "testzone2.com"}}
+
+		output := test.CatchStdOut(t, func() {
+			NDJSON(zones)
+		})
+
+		assert.Equal(t, "{\"name\":\"testzone1.com\",\"description\":\"\"}\n{\"name\":\"testzone2.com\",\"description\":\"\"}\n", output)
+	})
+
+	t.Run("MarshalError_ReturnsError", func(t *testing.T) {
+		type teststruct struct {
+			Invalid chan int
+		}
+
+		err := NDJSON(teststruct{})
+
+		assert.Equal(t, "failed to marshal json: json: unsupported type: chan int", err.Error())
+	})
+}
+
+func TestYAML_ExpectedStdout(t *testing.T) {
+	t.Run("Struct_OutputsJSONPropertiesInOrder", func(t *testing.T) {
+		zone := safedns.Zone{Name: "testzone.com", Description: "testdescription"}
+
+		output := test.CatchStdOut(t, func() {
+			YAML(zone)
+		})
+
+		assert.Equal(t, "name: testzone.com\ndescription: testdescription\n", output)
+	})
+
+	t.Run("NestedValues_ExpectedStdout", func(t *testing.T) {
+		v := map[string]interface{}{"count": 3, "ratio": 0.5, "items": []string{"a"}, "empty": nil}
+
+		output := test.CatchStdOut(t, func() {
+			YAML(v)
+		})
+
+		assert.Equal(t, "count: 3\nempty: null\nitems:\n- a\nratio: 0.5\n", output)
+	})
+
+	t.Run("MarshalError_ReturnsError", func(t *testing.T) {
+		type teststruct struct {
+			Invalid chan int
+		}
+
+		err := YAML(teststruct{})
+
+		assert.Equal(t, "failed to marshal json: json: unsupported type: chan int", err.Error())
+	})
+}
+
+func TestMarkdown_ExpectedStdout(t *testing.T) {
+	t.Run("EscapesValues", func(t *testing.T) {
+		fields := NewOrderedFields()
+		fields.Set("testproperty1", FieldValue{Value: "a|b"})
+		fields.Set("testproperty2", FieldValue{Value: "line1\nline2"})
+
+		output := test.CatchStdOut(t, func() {
+			Markdown([]*OrderedFields{fields})
+		})
+
+		assert.Equal(t, "| testproperty1 | testproperty2 |\n| --- | --- |\n| a\\|b | line1<br>line2 |\n", output)
+	})
+
+	t.Run("NoRows_NoOutput", func(t *testing.T) {
+		output := test.CatchStdOut(t, func() {
+			Markdown([]*OrderedFields{})
+		})
+
+		assert.Equal(t, "", output)
+	})
+}
+
 func TestOutput_JSONPath_ExpectedStdout(t *testing.T) {
 	t.Run("WithJSONPathTemplate_ExpectedStdOut", func(t *testing.T) {
 		zone := safedns.Zone{Name: "testzone.com", Description: "testdescription"}
@@ -0,0 +1,196 @@
+package output
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+// PaginatedProviderFunc returns an OutputHandlerDataProvider for the items of given page
+type PaginatedProviderFunc func(page connection.Paginated) OutputHandlerDataProvider
+
+// AddPaginatedFlags adds the 'all' and 'page-concurrency' flags to cmd, for commands which output
+// paginated responses with CommandOutputPaginated
+func AddPaginatedFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Specifies all pages should be retrieved")
+	AddPageConcurrencyFlag(cmd)
+}
+
+// AddPageConcurrencyFlag adds the 'page-concurrency' flag to cmd, for commands which output
+// paginated responses with CommandOutputAllPages
+func AddPageConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("page-concurrency", 1, "Specifies the number of pages to retrieve concurrently when retrieving further pages")
+}
+
+// CommandOutputPaginated outputs the first page of a paginated response, outputting the current page
+// and total pages to stderr. When the --all or global --max-items flags are specified, remaining
+// pages are instead retrieved via getFunc and streamed to the output handler as they're received,
+// with up to --page-concurrency pages retrieved concurrently. The global --query flag is applied to
+// each page. The --all and --page-concurrency flags are added to cmd with AddPaginatedFlags
+func CommandOutputPaginated(cmd *cobra.Command, params connection.APIRequestParameters, page connection.Paginated, getFunc connection.PaginatedGetFunc, providerFunc PaginatedProviderFunc) error {
+	all, _ := cmd.Flags().GetBool("all")
+	maxItems, _ := cmd.Flags().GetInt("max-items")
+	if !all && maxItems < 1 {
+		err := CommandOutput(cmd, providerFunc(page))
+		if err != nil {
+			return err
+		}
+
+		Errorf("Page %d/%d", page.CurrentPage(), page.TotalPages())
+		return nil
+	}
+
+	return CommandOutputAllPages(cmd, params, page, getFunc, providerFunc)
+}
+
+// CommandOutputAllPages outputs page and all remaining pages of a paginated response, retrieved via
+// getFunc and streamed to the output handler as they're received. The --page-concurrency flag and
+// global --max-items and --query flags are applied as with CommandOutputPaginated
+func CommandOutputAllPages(cmd *cobra.Command, params connection.APIRequestParameters, page connection.Paginated, getFunc connection.PaginatedGetFunc, providerFunc PaginatedProviderFunc) error {
+	maxItems, _ := cmd.Flags().GetInt("max-items")
+	query, err := getCommandQuery(cmd)
+	if err != nil {
+		return err
+	}
+
+	concurrency, _ := cmd.Flags().GetInt("page-concurrency")
+	fetcher := NewPageFetcher(params, page, getFunc, providerFunc, concurrency, maxItems).WithQuery(query)
+
+	// Changes can only be determined once all pages have been retrieved when watching
+	if activeWatcher != nil {
+		dataProvider, err := collectStreamProvider(fetcher.Next)
+		if err != nil {
+			return err
+		}
+
+		return activeWatcher.output(cmd, dataProvider)
+	}
+
+	return NewCommandOutputHandler(cmd, nil).HandleStream(fetcher.Next)
+}
+
+type pageResult struct {
+	page connection.Paginated
+	err  error
+}
+
+// PageFetcher retrieves the pages of a paginated response in order, prefetching up to concurrency
+// pages ahead of the page being consumed so that memory usage remains bounded
+type PageFetcher struct {
+	params       connection.APIRequestParameters
+	first        connection.Paginated
+	getFunc      connection.PaginatedGetFunc
+	providerFunc PaginatedProviderFunc
+	concurrency  int
+	maxItems     int
+	query        *Query
+
+	currentPage int
+	lastPage    int
+	started     int
+	itemCount   int
+	results     map[int]chan pageResult
+}
+
+// NewPageFetcher returns a PageFetcher, which will return providers for first page and each subsequent
+// page retrieved via getFunc. A maxItems value greater than 0 limits the total items returned
+func NewPageFetcher(params connection.APIRequestParameters, first connection.Paginated, getFunc connection.PaginatedGetFunc, providerFunc PaginatedProviderFunc, concurrency int, maxItems int) *PageFetcher {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &PageFetcher{
+		params:       params,
+		first:        first,
+		getFunc:      getFunc,
+		providerFunc: providerFunc,
+		concurrency:  concurrency,
+		maxItems:     maxItems,
+		results:      make(map[int]chan pageResult),
+	}
+}
+
+// WithQuery sets the query used to filter the items of each page. maxItems is applied to matching
+// items only
+func (f *PageFetcher) WithQuery(query *Query) *PageFetcher {
+	f.query = query
+	return f
+}
+
+// Next returns a provider for the next page, or nil once all pages (or maxItems items) have been returned
+func (f *PageFetcher) Next() (OutputHandlerDataProvider, error) {
+	if f.maxItems > 0 && f.itemCount >= f.maxItems {
+		return nil, nil
+	}
+
+	var page connection.Paginated
+	if f.currentPage == 0 {
+		page = f.first
+		f.currentPage = page.CurrentPage()
+		f.started = f.currentPage
+		f.lastPage = f.calculateLastPage()
+	} else {
+		f.currentPage++
+		if f.currentPage > f.lastPage {
+			return nil, nil
+		}
+
+		f.prefetch()
+		result := <-f.results[f.currentPage]
+		delete(f.results, f.currentPage)
+		if result.err != nil {
+			return nil, result.err
+		}
+
+		page = result.page
+	}
+
+	var dataProvider OutputHandlerDataProvider = f.providerFunc(page)
+	if f.query != nil {
+		var err error
+		dataProvider, err = NewQueryOutputHandlerDataProvider(dataProvider, f.query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	count := len(dataItems(dataProvider.GetData()))
+	if f.maxItems > 0 && f.itemCount+count > f.maxItems {
+		dataProvider = NewLimitedOutputHandlerDataProvider(dataProvider, f.maxItems-f.itemCount)
+		count = f.maxItems - f.itemCount
+	}
+	f.itemCount += count
+
+	return dataProvider, nil
+}
+
+// calculateLastPage returns the last page to retrieve, limited by maxItems based on the size of
+// the first page. Pages aren't limited when using a query, as matching items may be on any page
+func (f *PageFetcher) calculateLastPage() int {
+	lastPage := f.first.TotalPages()
+	perPage := len(dataItems(f.providerFunc(f.first).GetData()))
+	if f.maxItems > 0 && perPage > 0 && f.query == nil {
+		maxPage := f.currentPage + (f.maxItems-1)/perPage
+		if maxPage < lastPage {
+			lastPage = maxPage
+		}
+	}
+
+	return lastPage
+}
+
+// prefetch starts retrieval of pages up to concurrency pages ahead of the current page
+func (f *PageFetcher) prefetch() {
+	for f.started < f.lastPage && f.started < f.currentPage+f.concurrency-1 {
+		f.started++
+
+		params := f.params.Copy()
+		params.Pagination.Page = f.started
+
+		result := make(chan pageResult, 1)
+		f.results[f.started] = result
+		go func() {
+			page, err := f.getFunc(params)
+			result <- pageResult{page: page, err: err}
+		}()
+	}
+}
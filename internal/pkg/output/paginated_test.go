@@ -0,0 +1,175 @@
+package output
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/test"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+type testPaginated struct {
+	*connection.PaginatedBase
+	Items []testOutputData
+}
+
+// newTestPaginatedGetFunc returns a PaginatedGetFunc returning totalPages pages, each containing
+// perPage items
+func newTestPaginatedGetFunc(totalPages int, perPage int) connection.PaginatedGetFunc {
+	var getFunc connection.PaginatedGetFunc
+	getFunc = func(params connection.APIRequestParameters) (connection.Paginated, error) {
+		page := params.Pagination.Page
+		if page < 1 {
+			page = 1
+		}
+
+		var items []testOutputData
+		for i := 0; i < perPage; i++ {
+			items = append(items, testOutputData{TestProperty1: "page" + string(rune('0'+page)), TestProperty2: string(rune('a' + i))})
+		}
+
+		params.Pagination.Page = page
+		return &testPaginated{
+			PaginatedBase: connection.NewPaginatedBase(params, connection.APIResponseMetadataPagination{TotalPages: totalPages}, getFunc),
+			Items:         items,
+		}, nil
+	}
+
+	return getFunc
+}
+
+func testPaginatedProviderFunc(page connection.Paginated) OutputHandlerDataProvider {
+	return NewSerializedOutputHandlerDataProvider(page.(*testPaginated).Items).WithDefaultFields([]string{"test_property_1", "test_property_2"})
+}
+
+func collectPageFetcher(t *testing.T, f *PageFetcher) []interface{} {
+	var items []interface{}
+	for {
+		p, err := f.Next()
+		assert.Nil(t, err)
+		if p == nil {
+			return items
+		}
+
+		items = append(items, dataItems(p.GetData())...)
+	}
+}
+
+func TestPageFetcher_Next(t *testing.T) {
+	t.Run("AllPages_ReturnsAllItems", func(t *testing.T) {
+		getFunc := newTestPaginatedGetFunc(3, 2)
+		first, _ := getFunc(connection.APIRequestParameters{})
+
+		f := NewPageFetcher(connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc, 1, 0)
+		items := collectPageFetcher(t, f)
+
+		assert.Len(t, items, 6)
+		assert.Equal(t, "page1", items[0].(testOutputData).TestProperty1)
+		assert.Equal(t, "page3", items[5].(testOutputData).TestProperty1)
+	})
+
+	t.Run("Concurrent_ReturnsItemsInOrder", func(t *testing.T) {
+		getFunc := newTestPaginatedGetFunc(10, 2)
+		first, _ := getFunc(connection.APIRequestParameters{})
+
+		f := NewPageFetcher(connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc, 4, 0)
+		items := collectPageFetcher(t, f)
+
+		assert.Len(t, items, 20)
+		for i, item := range items {
+			assert.Equal(t, "page"+string(rune('0'+(i/2)+1)), item.(testOutputData).TestProperty1)
+		}
+	})
+
+	t.Run("MaxItems_LimitsItemsAndRequests", func(t *testing.T) {
+		var mutex sync.Mutex
+		requests := 0
+		testGetFunc := newTestPaginatedGetFunc(10, 2)
+		getFunc := func(params connection.APIRequestParameters) (connection.Paginated, error) {
+			mutex.Lock()
+			requests++
+			mutex.Unlock()
+			return testGetFunc(params)
+		}
+		first, _ := testGetFunc(connection.APIRequestParameters{})
+
+		f := NewPageFetcher(connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc, 4, 5)
+		items := collectPageFetcher(t, f)
+
+		assert.Len(t, items, 5)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("GetFuncError_ReturnsError", func(t *testing.T) {
+		testGetFunc := newTestPaginatedGetFunc(3, 2)
+		getFunc := func(params connection.APIRequestParameters) (connection.Paginated, error) {
+			return nil, errors.New("test error")
+		}
+		first, _ := testGetFunc(connection.APIRequestParameters{})
+
+		f := NewPageFetcher(connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc, 1, 0)
+		f.Next()
+		_, err := f.Next()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "test error", err.Error())
+	})
+}
+
+func TestCommandOutputPaginated(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().Int("max-items", 0, "")
+		cmd.Flags().String("query", "", "")
+		AddPaginatedFlags(cmd)
+		return cmd
+	}
+
+	t.Run("SinglePage_OutputsPageAndPageNumber", func(t *testing.T) {
+		getFunc := newTestPaginatedGetFunc(3, 2)
+		first, _ := getFunc(connection.APIRequestParameters{})
+		cmd := newCmd()
+		cmd.Flags().Set("output", "value")
+
+		stdOut, stdErr := test.CatchStdOutStdErr(t, func() {
+			CommandOutputPaginated(cmd, connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc)
+		})
+
+		assert.Equal(t, "page1 a\npage1 b\n", stdOut)
+		assert.Equal(t, "Page 1/3\n", stdErr)
+	})
+
+	t.Run("All_OutputsAllPages", func(t *testing.T) {
+		getFunc := newTestPaginatedGetFunc(2, 2)
+		first, _ := getFunc(connection.APIRequestParameters{})
+		cmd := newCmd()
+		cmd.Flags().Set("output", "csv")
+		cmd.Flags().Set("all", "true")
+
+		stdOut, stdErr := test.CatchStdOutStdErr(t, func() {
+			CommandOutputPaginated(cmd, connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc)
+		})
+
+		assert.Equal(t, "test_property_1,test_property_2\npage1,a\npage1,b\npage2,a\npage2,b\n", stdOut)
+		assert.Equal(t, "", stdErr)
+	})
+
+	t.Run("QueryWithMaxItems_LimitsMatchingItems", func(t *testing.T) {
+		getFunc := newTestPaginatedGetFunc(3, 2)
+		first, _ := getFunc(connection.APIRequestParameters{})
+		cmd := newCmd()
+		cmd.Flags().Set("output", "csv")
+		cmd.Flags().Set("max-items", "2")
+		cmd.Flags().Set("query", `TestProperty2 == "b"`)
+
+		stdOut := test.CatchStdOut(t, func() {
+			CommandOutputPaginated(cmd, connection.APIRequestParameters{}, first, getFunc, testPaginatedProviderFunc)
+		})
+
+		assert.Equal(t, "test_property_1,test_property_2\npage1,b\npage2,b\n", stdOut)
+	})
+}
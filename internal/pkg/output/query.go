@@ -0,0 +1,158 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+)
+
+// Query is a client-side expression used to filter output items, e.g.
+// 'status == "Complete" and not (name matches "^test-")'. Expressions are evaluated against the
+// JSON representation of each item, so properties are referenced by the same names as JSON output,
+// with nested properties accessed with '.', e.g. 'volume_group.name'
+type Query struct {
+	expression string
+	program    *vm.Program
+}
+
+// NewQuery returns a Query for expression, or an error if expression is invalid
+func NewQuery(expression string) (*Query, error) {
+	program, err := expr.Compile(expression)
+	if err != nil {
+		return nil, clierrors.NewErrValidation(fmt.Errorf("Invalid query [%s]: %s", expression, err))
+	}
+
+	return &Query{expression: expression, program: program}, nil
+}
+
+// getCommandQuery returns a Query for the global --query flag, or nil if not specified
+func getCommandQuery(cmd *cobra.Command) (*Query, error) {
+	expression, _ := cmd.Flags().GetString("query")
+	if expression == "" {
+		return nil, nil
+	}
+
+	return NewQuery(expression)
+}
+
+// Match returns true if item matches the query. An error is returned if the query doesn't evaluate
+// to a boolean
+func (q *Query) Match(item interface{}) (bool, error) {
+	env, err := queryEnv(item)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := expr.Run(q.program, env)
+	if err != nil {
+		return false, fmt.Errorf("Error evaluating query [%s]: %s", q.expression, err)
+	}
+
+	matched, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("Error evaluating query [%s]: expected boolean result, got %T", q.expression, result)
+	}
+
+	return matched, nil
+}
+
+// queryEnv returns the properties of item as a map, via its JSON representation
+func queryEnv(item interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(content, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	env, ok := value.(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+
+	return env, nil
+}
+
+// FilteredOutputHandlerDataProvider wraps an OutputHandlerDataProvider, filtering data and field
+// data to the items at given indexes
+type FilteredOutputHandlerDataProvider struct {
+	dataProvider OutputHandlerDataProvider
+	indexes      []int
+	count        int
+}
+
+// NewFilteredOutputHandlerDataProvider returns a FilteredOutputHandlerDataProvider for the items of
+// dataProvider at indexes
+func NewFilteredOutputHandlerDataProvider(dataProvider OutputHandlerDataProvider, indexes []int) *FilteredOutputHandlerDataProvider {
+	return &FilteredOutputHandlerDataProvider{
+		dataProvider: dataProvider,
+		indexes:      indexes,
+		count:        len(dataItems(dataProvider.GetData())),
+	}
+}
+
+// NewQueryOutputHandlerDataProvider returns a FilteredOutputHandlerDataProvider for the items of
+// dataProvider matching query
+func NewQueryOutputHandlerDataProvider(dataProvider OutputHandlerDataProvider, query *Query) (*FilteredOutputHandlerDataProvider, error) {
+	var indexes []int
+	for i, item := range dataItems(dataProvider.GetData()) {
+		matched, err := query.Match(item)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return NewFilteredOutputHandlerDataProvider(dataProvider, indexes), nil
+}
+
+func (p *FilteredOutputHandlerDataProvider) GetData() interface{} {
+	data := p.dataProvider.GetData()
+	if data == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		if len(p.indexes) > 0 {
+			return data
+		}
+		return nil
+	}
+
+	filtered := reflect.MakeSlice(v.Type(), 0, len(p.indexes))
+	for _, i := range p.indexes {
+		filtered = reflect.Append(filtered, v.Index(i))
+	}
+
+	return filtered.Interface()
+}
+
+func (p *FilteredOutputHandlerDataProvider) GetFieldData() ([]*OrderedFields, error) {
+	fields, err := p.dataProvider.GetFieldData()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) != p.count {
+		return nil, fmt.Errorf("Unable to filter output of this command, try a JSON based output format")
+	}
+
+	var filtered []*OrderedFields
+	for _, i := range p.indexes {
+		filtered = append(filtered, fields[i])
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+)
+
+type testQueryVolumeGroup struct {
+	Name string `json:"name"`
+}
+
+type testQueryData struct {
+	Name        string                `json:"name"`
+	Status      string                `json:"status"`
+	CPU         int                   `json:"cpu"`
+	VolumeGroup *testQueryVolumeGroup `json:"volume_group"`
+}
+
+func TestQuery_Match(t *testing.T) {
+	item := testQueryData{Name: "web01", Status: "Complete", CPU: 2, VolumeGroup: &testQueryVolumeGroup{Name: "group1"}}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   bool
+	}{
+		{"Equality", `status == "Complete"`, true},
+		{"BooleanLogic", `status == "Complete" and (cpu > 4 or name == "web01")`, true},
+		{"Negation", `not (status == "Complete")`, false},
+		{"Regex", `name matches "^web[0-9]+$"`, true},
+		{"NestedProperty", `volume_group.name == "group1"`, true},
+		{"MissingProperty", `unknown == nil`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewQuery(tt.expression)
+			assert.Nil(t, err)
+
+			matched, err := q.Match(item)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+
+	t.Run("NonBooleanResult_ReturnsError", func(t *testing.T) {
+		q, _ := NewQuery("cpu")
+
+		_, err := q.Match(item)
+
+		assert.Equal(t, "Error evaluating query [cpu]: expected boolean result, got float64", err.Error())
+	})
+}
+
+func TestNewQuery(t *testing.T) {
+	t.Run("InvalidExpression_ReturnsValidationError", func(t *testing.T) {
+		_, err := NewQuery(`name ==`)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, clierrors.ExitCodeValidation, clierrors.ExitCode(err))
+	})
+}
+
+func TestNewQueryOutputHandlerDataProvider(t *testing.T) {
+	items := []testQueryData{{Name: "web01", CPU: 2}, {Name: "db01", CPU: 8}, {Name: "web02", CPU: 4}}
+	q, _ := NewQuery(`cpu < 8`)
+
+	p, err := NewQueryOutputHandlerDataProvider(NewSerializedOutputHandlerDataProvider(items).WithDefaultFields([]string{"name"}), q)
+	assert.Nil(t, err)
+
+	t.Run("GetData_ReturnsMatchingItems", func(t *testing.T) {
+		assert.Equal(t, []testQueryData{items[0], items[2]}, p.GetData())
+	})
+
+	t.Run("GetFieldData_ReturnsMatchingItemFields", func(t *testing.T) {
+		fields, err := p.GetFieldData()
+
+		assert.Nil(t, err)
+		assert.Len(t, fields, 2)
+		assert.Equal(t, "web01", fields[0].Get("name").Value)
+		assert.Equal(t, "web02", fields[1].Get("name").Value)
+	})
+
+	t.Run("SingleItemNotMatched_GetDataReturnsNil", func(t *testing.T) {
+		p, _ := NewQueryOutputHandlerDataProvider(NewSerializedOutputHandlerDataProvider(items[1]), q)
+
+		assert.Nil(t, p.GetData())
+	})
+}
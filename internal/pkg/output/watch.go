@@ -0,0 +1,322 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+)
+
+// DefaultWatchInterval is the interval commands are re-run at when --watch is specified without a value
+const DefaultWatchInterval = 5 * time.Second
+
+const (
+	colourReset   = "\033[0m"
+	colourAdded   = "\033[32m"
+	colourRemoved = "\033[31m"
+	colourChanged = "\033[1;33m"
+	clearScreen   = "\033[H\033[2J"
+)
+
+// watchSleep is invoked between each run of a watched command
+var watchSleep = time.Sleep
+
+// activeWatcher is the watcher command output is rendered with while watching a command
+var activeWatcher *watcher
+
+// Watch invokes run every --watch interval until an error is returned or the --until condition is
+// met. While watching, command output is compared with the previous output. When stdout is a
+// terminal and output is a table, the table is redrawn in place with changed cells highlighted and
+// added or removed rows marked. Otherwise, output is streamed, with the initial output followed by
+// added and changed items as they're detected
+func Watch(cmd *cobra.Command, run func() error) error {
+	interval, _ := cmd.Flags().GetDuration("watch")
+	if interval <= 0 {
+		if cmd.Flags().Changed("watch") {
+			return clierrors.NewErrValidation(fmt.Errorf("Invalid watch interval [%s], expected positive duration, e.g. '5s'", interval))
+		}
+		interval = DefaultWatchInterval
+	}
+
+	w := &watcher{
+		title:    fmt.Sprintf("Every %s: %s", interval, strings.Join(os.Args, " ")),
+		terminal: IsTerminal(os.Stdout),
+	}
+
+	until, _ := cmd.Flags().GetString("until")
+	if until != "" {
+		var err error
+		w.until, err = parseWatchCondition(until)
+		if err != nil {
+			return err
+		}
+	}
+
+	activeWatcher = w
+	defer func() {
+		activeWatcher = nil
+	}()
+
+	for {
+		err := run()
+		if err != nil {
+			return err
+		}
+		if w.done {
+			return nil
+		}
+
+		watchSleep(interval)
+	}
+}
+
+// watchCondition is a condition items are matched against when watching, in the format
+// 'property=value' or 'property!=value', with nested properties separated by '.'
+type watchCondition struct {
+	path   []string
+	value  string
+	negate bool
+}
+
+func parseWatchCondition(s string) (*watchCondition, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[0] == "!" {
+		return nil, clierrors.NewErrValidation(fmt.Errorf("Invalid until condition [%s], expected format 'property=value' or 'property!=value'", s))
+	}
+
+	c := &watchCondition{value: parts[1]}
+	property := parts[0]
+	if strings.HasSuffix(property, "!") {
+		c.negate = true
+		property = strings.TrimSuffix(property, "!")
+	}
+	c.path = strings.Split(property, ".")
+
+	return c, nil
+}
+
+// match returns true if the property of item at the condition path matches the condition value,
+// compared case-insensitively
+func (c *watchCondition) match(item interface{}) (bool, error) {
+	env, err := queryEnv(item)
+	if err != nil {
+		return false, err
+	}
+
+	var value interface{} = env
+	for _, key := range c.path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			value = nil
+			break
+		}
+		value = m[key]
+	}
+
+	actual := ""
+	if value != nil {
+		actual = fmt.Sprint(value)
+	}
+
+	return strings.EqualFold(actual, c.value) != c.negate, nil
+}
+
+type watchRowStatus int
+
+const (
+	watchRowUnchanged watchRowStatus = iota
+	watchRowAdded
+	watchRowChanged
+	watchRowRemoved
+)
+
+type watchRow struct {
+	key     string
+	fields  *OrderedFields
+	status  watchRowStatus
+	changed map[string]bool
+}
+
+type watcher struct {
+	title    string
+	terminal bool
+	until    *watchCondition
+	done     bool
+
+	initialised bool
+	previous    map[string]*OrderedFields
+	keys        []string
+}
+
+// output outputs the changes between out and the previous output of the watched command
+func (w *watcher) output(cmd *cobra.Command, out OutputHandlerDataProvider) error {
+	handler := NewCommandOutputHandler(cmd, out)
+	if handler.aggregated() {
+		aggregation, err := NewAggregation(handler.GroupBy, handler.Aggregates)
+		if err != nil {
+			return err
+		}
+
+		out, err = NewAggregateOutputHandlerDataProvider(out, aggregation)
+		if err != nil {
+			return err
+		}
+
+		handler.DataProvider = out
+		handler.GroupBy = ""
+		handler.Aggregates = nil
+	}
+
+	if !handler.supportedFormat() {
+		return handler.Handle()
+	}
+
+	fields, err := out.GetFieldData()
+	if err != nil {
+		return err
+	}
+	rows, err := handler.getProcessedFieldData()
+	if err != nil {
+		return err
+	}
+
+	if w.until != nil {
+		w.done = true
+		for _, item := range dataItems(out.GetData()) {
+			matched, err := w.until.match(item)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				w.done = false
+				break
+			}
+		}
+	}
+
+	first := !w.initialised
+	diff := w.diff(watchRowKeys(fields), rows)
+
+	if w.terminal && handler.Format == "table" {
+		return w.render(diff)
+	}
+
+	return w.stream(handler, out, diff, first)
+}
+
+// diff compares rows with the previous output, returning the current rows followed by any removed
+// rows. The watcher is updated with rows for comparison with the next output
+func (w *watcher) diff(keys []string, rows []*OrderedFields) []*watchRow {
+	var diff []*watchRow
+	current := make(map[string]*OrderedFields)
+	for i, fields := range rows {
+		row := &watchRow{key: keys[i], fields: fields, changed: make(map[string]bool)}
+		current[row.key] = fields
+
+		previous, exists := w.previous[row.key]
+		switch {
+		case !w.initialised:
+		case !exists:
+			row.status = watchRowAdded
+		default:
+			for _, key := range fields.Keys() {
+				if fields.Get(key).Value != previous.Get(key).Value {
+					row.changed[key] = true
+					row.status = watchRowChanged
+				}
+			}
+		}
+
+		diff = append(diff, row)
+	}
+
+	for _, key := range w.keys {
+		if _, exists := current[key]; !exists {
+			diff = append(diff, &watchRow{key: key, fields: w.previous[key], status: watchRowRemoved})
+		}
+	}
+
+	w.initialised = true
+	w.previous = current
+	w.keys = keys
+
+	return diff
+}
+
+// render redraws the table in place, colouring added/removed rows and changed cells
+func (w *watcher) render(diff []*watchRow) error {
+	var rows []*OrderedFields
+	for _, row := range diff {
+		fields := NewOrderedFields()
+		for _, key := range row.fields.Keys() {
+			value := row.fields.Get(key)
+			switch {
+			case row.status == watchRowAdded:
+				value.Value = colourAdded + value.Value + colourReset
+			case row.status == watchRowRemoved:
+				value.Value = colourRemoved + value.Value + colourReset
+			case row.changed[key]:
+				value.Value = colourChanged + value.Value + colourReset
+			}
+			fields.Set(key, value)
+		}
+		rows = append(rows, fields)
+	}
+
+	fmt.Print(clearScreen)
+	fmt.Printf("%s\t%s\n\n", w.title, time.Now().Format(time.RFC1123))
+
+	return Table(rows)
+}
+
+// stream outputs all rows initially, and subsequently only added and changed rows. Removed rows
+// are output to stderr
+func (w *watcher) stream(handler *OutputHandler, out OutputHandlerDataProvider, diff []*watchRow, first bool) error {
+	if first {
+		return handler.Handle()
+	}
+
+	var indexes []int
+	for i, row := range diff {
+		switch row.status {
+		case watchRowAdded, watchRowChanged:
+			indexes = append(indexes, i)
+		case watchRowRemoved:
+			Errorf("Removed [%s]", row.key)
+		}
+	}
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	handler.DataProvider = NewFilteredOutputHandlerDataProvider(out, indexes)
+	return handler.Handle()
+}
+
+// watchRowKeys returns a key identifying each row, being the 'id' field if present, otherwise the
+// first field. Duplicate keys are suffixed with their occurrence, so that keys are unique
+func watchRowKeys(rows []*OrderedFields) []string {
+	var keys []string
+	seen := make(map[string]int)
+	for i, row := range rows {
+		key := fmt.Sprint(i)
+		if row.Exists("id") {
+			key = row.Get("id").Value
+		} else if len(row.Keys()) > 0 {
+			key = row.Get(row.Keys()[0]).Value
+		}
+
+		seen[key]++
+		if seen[key] > 1 {
+			key = fmt.Sprintf("%s#%d", key, seen[key])
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
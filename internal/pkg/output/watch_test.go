@@ -0,0 +1,183 @@
+package output
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/cli/internal/pkg/clierrors"
+	"github.com/ukfast/cli/test"
+)
+
+// setTestWatchSleep overrides watchSleep, recording each interval slept for, returning a func to
+// restore it
+func setTestWatchSleep(intervals *[]time.Duration) func() {
+	oldWatchSleep := watchSleep
+	watchSleep = func(d time.Duration) {
+		*intervals = append(*intervals, d)
+	}
+
+	return func() {
+		watchSleep = oldWatchSleep
+	}
+}
+
+func newTestWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().Int("max-items", 0, "")
+	cmd.Flags().String("query", "", "")
+	cmd.Flags().Duration("watch", 0, "")
+	cmd.Flags().String("until", "", "")
+
+	return cmd
+}
+
+func TestWatch(t *testing.T) {
+	runs := [][]testQueryData{
+		{{Name: "web01", Status: "InProgress"}, {Name: "db01", Status: "InProgress"}},
+		{{Name: "web01", Status: "Complete"}, {Name: "db01", Status: "InProgress"}, {Name: "web02", Status: "Complete"}},
+		{{Name: "web01", Status: "Complete"}, {Name: "web02", Status: "Complete"}},
+	}
+
+	t.Run("Until_OutputsChangesUntilConditionMet", func(t *testing.T) {
+		var intervals []time.Duration
+		defer setTestWatchSleep(&intervals)()
+
+		cmd := newTestWatchCmd()
+		cmd.Flags().Set("output", "csv")
+		cmd.Flags().Set("watch", "10s")
+		cmd.Flags().Set("until", "status=complete")
+
+		var err error
+		run := 0
+		stdOut, stdErr := test.CatchStdOutStdErr(t, func() {
+			err = Watch(cmd, func() error {
+				data := runs[run]
+				run++
+				return CommandOutput(cmd, NewSerializedOutputHandlerDataProvider(data).WithDefaultFields([]string{"name", "status"}))
+			})
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, run)
+		assert.Equal(t, []time.Duration{10 * time.Second, 10 * time.Second}, intervals)
+		assert.Equal(t, "name,status\nweb01,InProgress\ndb01,InProgress\n"+
+			"name,status\nweb01,Complete\nweb02,Complete\n", stdOut)
+		assert.Equal(t, "Removed [db01]\n", stdErr)
+	})
+
+	t.Run("RunError_ReturnsError", func(t *testing.T) {
+		var intervals []time.Duration
+		defer setTestWatchSleep(&intervals)()
+
+		cmd := newTestWatchCmd()
+		run := 0
+		err := Watch(cmd, func() error {
+			run++
+			if run == 2 {
+				return errors.New("test error")
+			}
+			return nil
+		})
+
+		assert.Equal(t, "test error", err.Error())
+		assert.Equal(t, []time.Duration{DefaultWatchInterval}, intervals)
+	})
+
+	t.Run("InvalidInterval_ReturnsValidationError", func(t *testing.T) {
+		cmd := newTestWatchCmd()
+		cmd.Flags().Set("watch", "0s")
+
+		err := Watch(cmd, func() error { return nil })
+
+		assert.Equal(t, "Invalid watch interval [0s], expected positive duration, e.g. '5s'", err.Error())
+		assert.Equal(t, clierrors.ExitCodeValidation, clierrors.ExitCode(err))
+	})
+
+	t.Run("InvalidUntil_ReturnsValidationError", func(t *testing.T) {
+		cmd := newTestWatchCmd()
+		cmd.Flags().Set("until", "status")
+
+		err := Watch(cmd, func() error { return nil })
+
+		assert.Equal(t, clierrors.ExitCodeValidation, clierrors.ExitCode(err))
+	})
+}
+
+func TestWatchCondition_Match(t *testing.T) {
+	item := testQueryData{Name: "web01", Status: "Complete", CPU: 2, VolumeGroup: &testQueryVolumeGroup{Name: "group1"}}
+
+	tests := []struct {
+		name      string
+		condition string
+		expected  bool
+	}{
+		{"Equal_CaseInsensitive", "status=complete", true},
+		{"NotEqual", "status!=complete", false},
+		{"Number", "cpu=2", true},
+		{"NestedProperty", "volume_group.name=group1", true},
+		{"MissingProperty_MatchesEmpty", "unknown.name=", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseWatchCondition(tt.condition)
+			assert.Nil(t, err)
+
+			matched, err := c.match(item)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+
+	t.Run("InvalidCondition_ReturnsError", func(t *testing.T) {
+		for _, condition := range []string{"status", "=complete", "!=complete"} {
+			_, err := parseWatchCondition(condition)
+
+			assert.NotNil(t, err, condition)
+		}
+	})
+}
+
+func TestWatcher_diff(t *testing.T) {
+	newRow := func(id string, name string) *OrderedFields {
+		fields := NewOrderedFields()
+		fields.Set("id", NewFieldValue(id, true))
+		fields.Set("name", NewFieldValue(name, true))
+		return fields
+	}
+
+	w := &watcher{}
+	initial := []*OrderedFields{newRow("1", "a"), newRow("2", "b")}
+	diff := w.diff(watchRowKeys(initial), initial)
+
+	assert.Len(t, diff, 2)
+	assert.Equal(t, watchRowUnchanged, diff[0].status)
+	assert.Equal(t, watchRowUnchanged, diff[1].status)
+
+	current := []*OrderedFields{newRow("2", "c"), newRow("3", "d")}
+	diff = w.diff(watchRowKeys(current), current)
+
+	assert.Len(t, diff, 3)
+	assert.Equal(t, watchRowChanged, diff[0].status)
+	assert.Equal(t, map[string]bool{"name": true}, diff[0].changed)
+	assert.Equal(t, watchRowAdded, diff[1].status)
+	assert.Equal(t, "1", diff[2].key)
+	assert.Equal(t, watchRowRemoved, diff[2].status)
+}
+
+func TestWatchRowKeys(t *testing.T) {
+	newRow := func(key string, value string) *OrderedFields {
+		fields := NewOrderedFields()
+		fields.Set(key, NewFieldValue(value, true))
+		return fields
+	}
+
+	keys := watchRowKeys([]*OrderedFields{newRow("name", "a"), newRow("name", "a"), newRow("id", "1")})
+
+	assert.Equal(t, []string{"a", "a#2", "1"}, keys)
+}
@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/afero"
+)
+
+// Prefix is the prefix of plugin executable names, e.g. 'ukfast-provision' for plugin 'provision'
+const Prefix = "ukfast-"
+
+// Plugin is an external executable, invoked as a subcommand of the CLI
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// Dirs returns the directories searched for plugins, in order of precedence: ~/.ukfast/plugins,
+// followed by the directories in PATH
+func Dirs() []string {
+	var dirs []string
+	home, err := homedir.Dir()
+	if err == nil {
+		dirs = append(dirs, filepath.Join(home, ".ukfast", "plugins"))
+	}
+
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+// Discover returns the plugins found within dirs, sorted by name. Where plugins with the same name
+// are found within multiple dirs, the first found is used. Missing or unreadable dirs are ignored
+func Discover(fs afero.Fs, dirs []string) []Plugin {
+	found := make(map[string]Plugin)
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+
+		for _, p := range discoverDir(fs, dir) {
+			if _, exists := found[p.Name]; !exists {
+				found[p.Name] = p
+			}
+		}
+	}
+
+	var plugins []Plugin
+	for _, p := range found {
+		plugins = append(plugins, p)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+
+	return plugins
+}
+
+func discoverDir(fs afero.Fs, dir string) []Plugin {
+	f, err := fs.Open(dir)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	// Only entries with the plugin prefix are stat'd, as PATH dirs may contain many entries
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil
+	}
+
+	var plugins []Plugin
+	for _, fileName := range names {
+		name := pluginName(fileName)
+		if name == "" {
+			continue
+		}
+
+		path := filepath.Join(dir, fileName)
+		info, err := fs.Stat(path)
+		if err != nil || !isExecutable(info) {
+			continue
+		}
+
+		plugins = append(plugins, Plugin{Name: name, Path: path})
+	}
+
+	return plugins
+}
+
+// pluginName returns the plugin name for executable fileName, or an empty string if fileName
+// isn't named as a plugin
+func pluginName(fileName string) string {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return ""
+	}
+
+	name := strings.TrimPrefix(fileName, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if strings.ContainsAny(name, " \t") {
+		return ""
+	}
+
+	return name
+}
+
+func isExecutable(info os.FileInfo) bool {
+	if info.IsDir() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".exe", ".bat", ".cmd", ".com":
+			return true
+		}
+		return false
+	}
+
+	return info.Mode()&0111 != 0
+}
+
+// Environ returns environment base (in the format returned by os.Environ) with vars set,
+// replacing any existing values
+func Environ(base []string, vars map[string]string) []string {
+	var env []string
+	for _, entry := range base {
+		key := strings.SplitN(entry, "=", 2)[0]
+		if _, exists := vars[key]; !exists {
+			env = append(env, entry)
+		}
+	}
+
+	var keys []string
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		env = append(env, key+"="+vars[key])
+	}
+
+	return env
+}
@@ -0,0 +1,51 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Run("ExecutablesWithPrefix_ReturnsPlugins", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/plugins/ukfast-provision", []byte{}, 0755)
+		afero.WriteFile(fs, "/bin/ukfast-audit", []byte{}, 0755)
+		afero.WriteFile(fs, "/bin/ukfast-notexecutable", []byte{}, 0644)
+		afero.WriteFile(fs, "/bin/other", []byte{}, 0755)
+		fs.MkdirAll("/bin/ukfast-dir", 0755)
+
+		plugins := Discover(fs, []string{"/plugins", "/bin"})
+
+		assert.Equal(t, []Plugin{
+			{Name: "audit", Path: "/bin/ukfast-audit"},
+			{Name: "provision", Path: "/plugins/ukfast-provision"},
+		}, plugins)
+	})
+
+	t.Run("DuplicateName_FirstDirTakesPrecedence", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/plugins/ukfast-provision", []byte{}, 0755)
+		afero.WriteFile(fs, "/bin/ukfast-provision", []byte{}, 0755)
+
+		plugins := Discover(fs, []string{"/plugins", "/bin"})
+
+		assert.Equal(t, []Plugin{{Name: "provision", Path: "/plugins/ukfast-provision"}}, plugins)
+	})
+
+	t.Run("MissingDirs_Ignored", func(t *testing.T) {
+		plugins := Discover(afero.NewMemMapFs(), []string{"/missing", ""})
+
+		assert.Empty(t, plugins)
+	})
+}
+
+func TestEnviron(t *testing.T) {
+	env := Environ([]string{"HOME=/home/user", "UKF_API_KEY=oldkey"}, map[string]string{
+		"UKF_API_KEY": "newkey",
+		"UKF_API_URI": "api.example.com",
+	})
+
+	assert.Equal(t, []string{"HOME=/home/user", "UKF_API_KEY=newkey", "UKF_API_URI=api.example.com"}, env)
+}
@@ -0,0 +1,225 @@
+package resource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/ukfast/cli/internal/pkg/output"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+// Locator locates resources of a single type by name, where an ID is expected
+type Locator struct {
+	// Name is the name of the resource type, used within errors, e.g. 'instance'
+	Name string
+	// IsID returns true if value is an ID, in which case the resource isn't located
+	IsID func(value string) bool
+	// Provider locates resources by name
+	Provider ResourceLocatorProvider
+}
+
+// LocateID returns value if it's an ID, otherwise the ID of the single resource located with value
+func (l *Locator) LocateID(value string) (string, error) {
+	if l.IsID(value) {
+		return value, nil
+	}
+
+	item, err := NewResourceLocator(l.Provider).Invoke(value)
+	if err != nil {
+		return "", fmt.Errorf("Error locating %s [%s]: %s", l.Name, value, err)
+	}
+
+	return ID(item)
+}
+
+// FilterLocatorProvider is a ResourceLocatorProvider which locates resources by filtering the
+// results of a list func, e.g. GetInstances, on each of its properties
+type FilterLocatorProvider struct {
+	list       func(parameters connection.APIRequestParameters) (interface{}, error)
+	properties []string
+}
+
+// NewFilterLocatorProvider returns a FilterLocatorProvider for list, locating resources by
+// properties in order
+func NewFilterLocatorProvider(list func(parameters connection.APIRequestParameters) (interface{}, error), properties ...string) *FilterLocatorProvider {
+	return &FilterLocatorProvider{list: list, properties: properties}
+}
+
+func (p *FilterLocatorProvider) SupportedProperties() []string {
+	return p.properties
+}
+
+func (p *FilterLocatorProvider) Locate(property string, value string) (interface{}, error) {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{Property: property, Operator: connection.EQOperator, Value: []string{value}})
+
+	return p.list(params)
+}
+
+// ArgLocatorFunc returns the Locator for a positional argument, given the preceding arguments once
+// located, e.g. to locate targets within a target group. nil is returned where the argument can't
+// be located by name
+type ArgLocatorFunc func(preceding []string) *Locator
+
+// ArgLocator returns an ArgLocatorFunc which returns l for all arguments
+func ArgLocator(l *Locator) ArgLocatorFunc {
+	return func(preceding []string) *Locator {
+		return l
+	}
+}
+
+// CommandLocators holds the Locators for the arguments and flags of the commands for a resource
+type CommandLocators struct {
+	// Args holds a func for each positional argument, with the last func used for any further
+	// arguments
+	Args []ArgLocatorFunc
+	// Flags holds Locators keyed by flag name. A nil Locator disables a common flag Locator
+	Flags map[string]*Locator
+}
+
+// Resolver locates resources named within the arguments and flags of commands, so that resources
+// can be referenced by name wherever an ID is expected
+type Resolver struct {
+	// Root is the name of the service root command, e.g. 'ecloud'
+	Root string
+	// Commands holds CommandLocators keyed by the path from Root to the parent of commands, e.g.
+	// 'targetgroup target'. Where a path isn't found, the CommandLocators of the closest ancestor
+	// are used
+	Commands map[string]*CommandLocators
+	// Flags holds Locators for flags common to all commands, keyed by flag name
+	Flags map[string]*Locator
+}
+
+// Resolve returns args with resource names replaced by their IDs. Names within flag values are
+// replaced in place. Commands without CommandLocators are left unchanged
+func (r *Resolver) Resolve(cmd *cobra.Command, args []string) ([]string, error) {
+	locators := r.commandLocators(cmd)
+	if locators == nil {
+		return args, nil
+	}
+
+	err := r.resolveFlags(cmd, locators)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(locators.Args) == 0 {
+		return args, nil
+	}
+
+	var resolved []string
+	for i, arg := range args {
+		f := locators.Args[len(locators.Args)-1]
+		if i < len(locators.Args) {
+			f = locators.Args[i]
+		}
+
+		var locator *Locator
+		if f != nil {
+			locator = f(resolved)
+		}
+		if locator == nil {
+			resolved = append(resolved, arg)
+			continue
+		}
+
+		id, err := locator.LocateID(arg)
+		if err != nil {
+			return nil, err
+		}
+		if id != arg {
+			// Errors are output against IDs, whereas exit codes are determined from the original args
+			output.AddResourceAlias(arg, id)
+		}
+
+		resolved = append(resolved, id)
+	}
+
+	return resolved, nil
+}
+
+// commandLocators returns the CommandLocators for the closest ancestor of cmd with CommandLocators,
+// or nil if there are none
+func (r *Resolver) commandLocators(cmd *cobra.Command) *CommandLocators {
+	var path []string
+	parent := cmd.Parent()
+	for parent != nil && parent.Name() != r.Root {
+		path = append([]string{parent.Name()}, path...)
+		parent = parent.Parent()
+	}
+	if parent == nil {
+		return nil
+	}
+
+	for i := len(path); i > 0; i-- {
+		locators, ok := r.Commands[strings.Join(path[:i], " ")]
+		if ok {
+			return locators
+		}
+	}
+
+	return nil
+}
+
+// resolveFlags replaces names within the values of changed flags with IDs
+func (r *Resolver) resolveFlags(cmd *cobra.Command, locators *CommandLocators) error {
+	flagLocators := make(map[string]*Locator)
+	for name, locator := range r.Flags {
+		flagLocators[name] = locator
+	}
+	for name, locator := range locators.Flags {
+		flagLocators[name] = locator
+	}
+
+	var names []string
+	for name := range flagLocators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		locator := flagLocators[name]
+		flag := cmd.Flags().Lookup(name)
+		if locator == nil || flag == nil || !flag.Changed {
+			continue
+		}
+
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			var ids []string
+			for _, value := range sliceValue.GetSlice() {
+				id, err := locator.LocateID(value)
+				if err != nil {
+					return err
+				}
+
+				ids = append(ids, id)
+			}
+
+			err := sliceValue.Replace(ids)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if flag.Value.Type() != "string" || flag.Value.String() == "" {
+			continue
+		}
+
+		id, err := locator.LocateID(flag.Value.String())
+		if err != nil {
+			return err
+		}
+
+		err = flag.Value.Set(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
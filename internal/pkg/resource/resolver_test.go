@@ -0,0 +1,202 @@
+package resource
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/ukfast/sdk-go/pkg/connection"
+)
+
+type testResource struct {
+	ID     string
+	Name   string
+	Parent string
+}
+
+var testResources = []testResource{
+	{ID: "res-1", Name: "web", Parent: "par-1"},
+	{ID: "res-2", Name: "db", Parent: "par-1"},
+	{ID: "res-3", Name: "dup"},
+	{ID: "res-4", Name: "dup"},
+	{ID: "par-1", Name: "prod"},
+}
+
+// testLocator returns a Locator for testResources with IDs prefixed with prefix, and within parent
+// where specified, otherwise within any parent
+func testLocator(prefix string, parent string) *Locator {
+	return &Locator{
+		Name: "resource",
+		IsID: func(value string) bool {
+			return strings.HasPrefix(value, prefix+"-")
+		},
+		Provider: NewFilterLocatorProvider(func(parameters connection.APIRequestParameters) (interface{}, error) {
+			var items []testResource
+			for _, item := range testResources {
+				if strings.HasPrefix(item.ID, prefix+"-") && item.Name == parameters.Filtering[0].Value[0] && (parent == "" || item.Parent == parent) {
+					items = append(items, item)
+				}
+			}
+			return items, nil
+		}, "name"),
+	}
+}
+
+// newTestResolverCmd returns a 'show' command beneath the commands in path, with 'parent',
+// 'resources' and 'count' flags
+func newTestResolverCmd(path ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "show"}
+	cmd.Flags().String("parent", "", "")
+	cmd.Flags().StringSlice("resources", []string{}, "")
+	cmd.Flags().Int("count", 0, "")
+
+	child := cmd
+	for i := len(path) - 1; i >= 0; i-- {
+		parent := &cobra.Command{Use: path[i]}
+		parent.AddCommand(child)
+		child = parent
+	}
+
+	return cmd
+}
+
+func newTestResolver() *Resolver {
+	return &Resolver{
+		Root: "test",
+		Commands: map[string]*CommandLocators{
+			"resource": {Args: []ArgLocatorFunc{ArgLocator(testLocator("res", ""))}},
+			"parent child": {
+				Args: []ArgLocatorFunc{
+					ArgLocator(testLocator("par", "")),
+					func(preceding []string) *Locator {
+						return testLocator("res", preceding[0])
+					},
+				},
+			},
+			"unnamed": {
+				Flags: map[string]*Locator{"parent": nil},
+			},
+		},
+		Flags: map[string]*Locator{
+			"parent":    testLocator("par", ""),
+			"resources": testLocator("res", ""),
+			"count":     testLocator("res", ""),
+		},
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Run("NamesAndIDs_ReturnsIDs", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "resource")
+
+		args, err := newTestResolver().Resolve(cmd, []string{"web", "res-2", "db"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"res-1", "res-2", "res-2"}, args)
+	})
+
+	t.Run("ClosestAncestor_UsesAncestorLocators", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "resource", "nested")
+
+		args, err := newTestResolver().Resolve(cmd, []string{"web"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"res-1"}, args)
+	})
+
+	t.Run("ScopedArg_LocatedWithinPreceding", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "parent", "child")
+
+		args, err := newTestResolver().Resolve(cmd, []string{"prod", "web", "db"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"par-1", "res-1", "res-2"}, args)
+	})
+
+	t.Run("Flags_ReplacesNames", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "resource")
+		cmd.ParseFlags([]string{"--parent=prod", "--resources=web,res-2", "--count=1"})
+
+		_, err := newTestResolver().Resolve(cmd, nil)
+
+		assert.Nil(t, err)
+		parent, _ := cmd.Flags().GetString("parent")
+		resources, _ := cmd.Flags().GetStringSlice("resources")
+		assert.Equal(t, "par-1", parent)
+		assert.Equal(t, []string{"res-1", "res-2"}, resources)
+	})
+
+	t.Run("NilFlagLocator_DisablesCommonLocator", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "unnamed")
+		cmd.ParseFlags([]string{"--parent=prod"})
+
+		_, err := newTestResolver().Resolve(cmd, nil)
+
+		assert.Nil(t, err)
+		parent, _ := cmd.Flags().GetString("parent")
+		assert.Equal(t, "prod", parent)
+	})
+
+	t.Run("CommandWithoutLocators_Unchanged", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "other")
+		cmd.ParseFlags([]string{"--parent=prod"})
+
+		args, err := newTestResolver().Resolve(cmd, []string{"web"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"web"}, args)
+		parent, _ := cmd.Flags().GetString("parent")
+		assert.Equal(t, "prod", parent)
+	})
+
+	t.Run("AmbiguousName_ReturnsErrorWithCandidates", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "resource")
+
+		_, err := newTestResolver().Resolve(cmd, []string{"dup"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error locating resource [dup]: More than one item found matching [dup] (name), candidates: res-3, res-4", err.Error())
+	})
+
+	t.Run("UnknownName_ReturnsError", func(t *testing.T) {
+		cmd := newTestResolverCmd("test", "resource")
+
+		_, err := newTestResolver().Resolve(cmd, []string{"unknown"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Error locating resource [unknown]: No items found matching [unknown]", err.Error())
+	})
+}
+
+func TestLocator_LocateID(t *testing.T) {
+	t.Run("ListError_ReturnsError", func(t *testing.T) {
+		l := &Locator{
+			Name: "resource",
+			IsID: func(value string) bool { return false },
+			Provider: NewFilterLocatorProvider(func(parameters connection.APIRequestParameters) (interface{}, error) {
+				return nil, errors.New("test error")
+			}, "name"),
+		}
+
+		_, err := l.LocateID("web")
+
+		assert.Equal(t, "Error locating resource [web]: Error retrieving items: test error", err.Error())
+	})
+}
+
+func TestID(t *testing.T) {
+	t.Run("IntID_ReturnsString", func(t *testing.T) {
+		id, err := ID(struct{ ID int }{ID: 123})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "123", id)
+	})
+
+	t.Run("NoIDField_ReturnsError", func(t *testing.T) {
+		_, err := ID("test")
+
+		assert.NotNil(t, err)
+	})
+}
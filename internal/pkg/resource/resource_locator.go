@@ -3,6 +3,7 @@ package resource
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 type ResourceLocatorProvider interface {
@@ -33,7 +34,12 @@ func (f *ResourceLocator) Invoke(filter string) (interface{}, error) {
 				length := s.Len()
 
 				if length > 1 {
-					return nil, fmt.Errorf("More than one item found matching [%s] (%s)", filter, property)
+					var candidates []string
+					for i := 0; i < length; i++ {
+						candidates = append(candidates, describeCandidate(s.Index(i).Interface()))
+					}
+
+					return nil, fmt.Errorf("More than one item found matching [%s] (%s), candidates: %s", filter, property, strings.Join(candidates, ", "))
 				}
 
 				if length == 1 {
@@ -47,3 +53,26 @@ func (f *ResourceLocator) Invoke(filter string) (interface{}, error) {
 
 	return nil, fmt.Errorf("No items found matching [%s]", filter)
 }
+
+// ID returns the ID field of item as a string, or an error if item isn't a struct with an ID field
+func ID(item interface{}) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(item))
+	if v.Kind() == reflect.Struct {
+		id := v.FieldByName("ID")
+		if id.IsValid() {
+			return fmt.Sprint(id.Interface()), nil
+		}
+	}
+
+	return "", fmt.Errorf("Unsupported item type [%T], expected ID field", item)
+}
+
+// describeCandidate returns the ID of item where possible, otherwise item formatted as a string
+func describeCandidate(item interface{}) string {
+	id, err := ID(item)
+	if err != nil {
+		return fmt.Sprint(item)
+	}
+
+	return id
+}
@@ -76,7 +76,7 @@ func TestResourceLocator_Invoke(t *testing.T) {
 		_, err := r.Invoke("testvalue1")
 
 		assert.NotNil(t, err)
-		assert.Equal(t, "More than one item found matching [testvalue1] (testproperty1)", err.Error())
+		assert.Equal(t, "More than one item found matching [testvalue1] (testproperty1), candidates: testlocateresult1, testlocateresult2", err.Error())
 	})
 
 	t.Run("NoItems_ReturnsError", func(t *testing.T) {